@@ -202,6 +202,42 @@ type ClusterSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="restore is immutable"
 	// +optional
 	Restore *ClusterRestore `json:"restore,omitempty"`
+
+	// Specifies the hibernation schedule of the Cluster.
+	// The Cluster is stopped and started periodically by Stop and Start OpsRequests issued according to the schedule.
+	//
+	// +optional
+	Hibernation *ClusterHibernation `json:"hibernation,omitempty"`
+
+	// Specifies the maintenance window of the Cluster.
+	//
+	// The disruptive changes, including the OpsRequests that recreate pods and the rolling updates of instances
+	// triggered by spec changes, are deferred until the window opens. The rolling updates already in progress are paused
+	// when the window closes, and resume in the next window.
+	//
+	// If not specified, the maintenance window defined by the annotation "apps.kubeblocks.io/maintenance-window"
+	// of the namespace is used.
+	//
+	// Set the OpsRequest's `spec.force` to true, or annotate the Cluster with "apps.kubeblocks.io/bypass-maintenance-window: true",
+	// to bypass the window for urgent changes.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies how to handle the drifts of the objects owned by the Cluster, such as the InstanceSets, Services,
+	// ConfigMaps and Secrets, which are changed out of band, e.g. by `kubectl edit`.
+	//
+	// The drifts are detected once the objects have been rendered from the current spec, and reported by the
+	// "Drifted" condition of the Cluster and its Components. Choose from the following policies:
+	//
+	// - `Revert`: the drifts are reverted to the desired state.
+	// - `Warn`: the drifts are kept in the live objects and reported as warnings.
+	// - `Adopt`: the drifts are accepted as the new baseline and kept in the live objects, until the desired state of the objects changes. They are reported with the False status.
+	//
+	// Defaults to `Revert`.
+	//
+	// +optional
+	DriftPolicy DriftPolicyType `json:"driftPolicy,omitempty"`
 }
 
 // ClusterStatus defines the observed state of the Cluster.
@@ -232,6 +268,11 @@ type ClusterStatus struct {
 	// +optional
 	Shardings map[string]ClusterShardingStatus `json:"shardings,omitempty"`
 
+	// Records the status of the hibernation schedule of the Cluster.
+	//
+	// +optional
+	Hibernation *ClusterHibernationStatus `json:"hibernation,omitempty"`
+
 	// Represents a list of detailed status of the Cluster object.
 	// Each condition in the list provides real-time information about certain aspect of the Cluster object.
 	//
@@ -488,6 +529,20 @@ type ClusterComponentSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Overrides the PodDisruptionBudget generated for the Component.
+	//
+	// By default, a PodDisruptionBudget is generated for each Component with two or more replicas,
+	// and kept in sync with the replicas and roles:
+	//
+	// - If any role participates in quorum, at most (n-1)/2 pods can be unavailable, where n is the number of quorum members.
+	// - If there are fewer than three quorum members, or no role participates in quorum, at most one pod can be unavailable.
+	//
+	// An exclusive role is held by one quorum member only. If some roles don't participate in quorum, the quorum
+	// members are counted from the roles observed on the pods.
+	//
+	// +optional
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+
 	// Allows for the customization of configuration values for each instance within a Component.
 	// An instance represent a single replica (Pod and associated K8s resources like PVCs, Services, and ConfigMaps).
 	// While instances typically share a common configuration as defined in the ClusterComponentSpec,
@@ -875,6 +930,71 @@ type ClusterBackup struct {
 	IncrementalCronExpression string `json:"incrementalCronExpression,omitempty"`
 }
 
+// ClusterHibernation defines the schedule to stop and start the Cluster periodically.
+type ClusterHibernation struct {
+	// The cron expression for the time to stop the Cluster. See https://en.wikipedia.org/wiki/Cron.
+	//
+	// +kubebuilder:validation:Required
+	StopSchedule string `json:"stopSchedule"`
+
+	// The cron expression for the time to start the Cluster. See https://en.wikipedia.org/wiki/Cron.
+	//
+	// +kubebuilder:validation:Required
+	StartSchedule string `json:"startSchedule"`
+
+	// Specifies the time zone of the cron expressions, in the IANA Time Zone database format, e.g. "Asia/Shanghai".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ClusterHibernationStatus records the status of the hibernation schedule of the Cluster.
+type ClusterHibernationStatus struct {
+	// The next scheduled transition of the Cluster.
+	//
+	// +optional
+	NextTransition HibernationTransition `json:"nextTransition,omitempty"`
+
+	// The time of the next scheduled transition.
+	//
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// The last scheduled transition of the Cluster.
+	//
+	// +optional
+	LastTransition HibernationTransition `json:"lastTransition,omitempty"`
+
+	// The time of the last scheduled transition.
+	//
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// The name of the OpsRequest issued for the last scheduled transition,
+	// it is empty if the transition is skipped.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// Provides additional information about the last scheduled transition, e.g. why it is skipped,
+	// or why the schedule is invalid.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HibernationTransition defines the transitions of the Cluster driven by the hibernation schedule.
+//
+// +enum
+// +kubebuilder:validation:Enum={Stop,Start}
+type HibernationTransition string
+
+const (
+	HibernationStop  HibernationTransition = "Stop"
+	HibernationStart HibernationTransition = "Start"
+)
+
 // ClusterRestore specifies how to initialize a Cluster from a restore source.
 type ClusterRestore struct {
 	// Specifies the restore source.
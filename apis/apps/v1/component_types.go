@@ -247,6 +247,23 @@ type ComponentSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Overrides the PodDisruptionBudget generated for the Component.
+	//
+	// +optional
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// Specifies the maintenance window in which the rolling updates of instances are allowed.
+	// It is resolved and set by the Cluster controller.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies how to handle the drifts of the objects owned by the Component.
+	// It is set by the Cluster controller, defaults to `Revert`.
+	//
+	// +optional
+	DriftPolicy DriftPolicyType `json:"driftPolicy,omitempty"`
+
 	// Specifies the scheduling policy for the Component.
 	//
 	// +optional
@@ -394,6 +411,77 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
+
+	// Records the outputs of the lifecycle actions for the operations in progress.
+	// The outputs are passed to the following actions of the same operation, and removed once the operation is done.
+	//
+	// +optional
+	ActionOutputs []ComponentActionOutputs `json:"actionOutputs,omitempty"`
+
+	// Records the recent invocations of the lifecycle actions, a bounded number of the latest invocations
+	// are kept for each action.
+	//
+	// +optional
+	ActionHistory []ComponentActionHistory `json:"actionHistory,omitempty"`
+}
+
+// ComponentActionOutputs records the outputs of the lifecycle actions invoked by an operation.
+type ComponentActionOutputs struct {
+	// The operation that the outputs are scoped to, e.g., `scaleIn/<pod>` or `upgrade`.
+	//
+	// +kubebuilder:validation:Required
+	Operation string `json:"operation"`
+
+	// The outputs of the actions, keyed by `<action>.<output>`.
+	//
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// ComponentActionHistory records the recent invocations of a lifecycle action.
+type ComponentActionHistory struct {
+	// The name of the lifecycle action.
+	//
+	// +kubebuilder:validation:Required
+	Action string `json:"action"`
+
+	// The recent invocations of the action, ordered from the oldest to the latest.
+	//
+	// +optional
+	Invocations []ComponentActionInvocation `json:"invocations,omitempty"`
+}
+
+// ComponentActionInvocation describes an invocation of a lifecycle action.
+type ComponentActionInvocation struct {
+	// The name of the pod that the action was executed on.
+	//
+	// +kubebuilder:validation:Required
+	Pod string `json:"pod"`
+
+	// The time when the invocation started.
+	//
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// The time when the invocation finished.
+	//
+	// +kubebuilder:validation:Required
+	EndTime metav1.Time `json:"endTime"`
+
+	// Indicates whether the invocation succeeded.
+	//
+	// +kubebuilder:validation:Required
+	Succeeded bool `json:"succeeded"`
+
+	// The exit code of the action, it is known only for the actions executed as a command.
+	//
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// The truncated error message of the failed invocation.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type Sidecar struct {
@@ -476,4 +564,12 @@ const (
 
 	// ComponentConditionProvisioningStarted indicates the operator starts resource provisioning to create or change the cluster.
 	ComponentConditionProvisioningStarted = "ProvisioningStarted"
+
+	// ComponentConditionLifecycleActionFailed indicates a lifecycle action of the component failed,
+	// the message carries the summary of the failed invocation from the audit log of kb-agent.
+	ComponentConditionLifecycleActionFailed = "LifecycleActionFailed"
+
+	// ComponentConditionUpgrading indicates the component is being upgraded to a new service version or component definition,
+	// the reason tells the stage of the upgrade, e.g., running the preUpgrade or postUpgrade action.
+	ComponentConditionUpgrading = "Upgrading"
 )
@@ -526,6 +526,8 @@ type ComponentDefinitionSpec struct {
 	//   - `dataLoad`: Defines the procedure to import data into a replica.
 	//   - `reconfigure`: Defines the procedure that update a replica with new configuration file.
 	//   - `accountProvision`: Defines the procedure to generate a new database account.
+	//   - `preUpgrade`: Defines the procedure to be executed before a Component is upgraded.
+	//   - `postUpgrade`: Defines the procedure to be executed after a Component is upgraded.
 	//
 	// This field is immutable.
 	//
@@ -1803,6 +1805,47 @@ type ComponentLifecycleActions struct {
 	//
 	// +optional
 	AccountProvision *Action `json:"accountProvision,omitempty"`
+
+	// Defines the procedure to be executed before a Component is upgraded to a new service version
+	// or ComponentDefinition.
+	//
+	// Use Case:
+	// This action is designed to run pre-flight checks (e.g., `mysql_upgrade --check`, `pg_upgrade --check`)
+	// before the first replica is updated to the new version.
+	//
+	// The action is executed once for each upgrade target, and no replica will be updated
+	// until it has completed successfully. If the action fails, it is retried and the upgrade stays blocked.
+	// The action runs on the replicas of the version being upgraded from, so it should be defined
+	// in that ComponentDefinition as well.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_UPGRADE_FROM_COMP_DEF: The name of the ComponentDefinition being upgraded from.
+	// - KB_UPGRADE_TO_COMP_DEF: The name of the ComponentDefinition being upgraded to.
+	// - KB_UPGRADE_FROM_SERVICE_VERSION: The service version being upgraded from.
+	// - KB_UPGRADE_TO_SERVICE_VERSION: The service version being upgraded to.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	PreUpgrade *Action `json:"preUpgrade,omitempty"`
+
+	// Defines the procedure to be executed after all replicas of a Component have been upgraded
+	// to a new service version or ComponentDefinition.
+	//
+	// Use Case:
+	// This action is designed to run the migrations required by the new version, such as catalog
+	// or system table upgrades.
+	//
+	// The action is executed once all replicas are running with the new version and ready.
+	// If the action fails, it is retried until it succeeds.
+	//
+	// The container executing this action has access to the same variables as the PreUpgrade action.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	PostUpgrade *Action `json:"postUpgrade,omitempty"`
 }
 
 // Action defines a customizable hook or procedure tailored for different database engines,
@@ -1829,6 +1872,8 @@ type ComponentLifecycleActions struct {
 //   - `dataLoad`: Defines the procedure to import data into a replica.
 //   - `reconfigure`: Defines the procedure that update a replica with new configuration.
 //   - `accountProvision`: Defines the procedure to generate a new database account.
+//   - `preUpgrade`: Defines the procedure to be executed before a Component is upgraded.
+//   - `postUpgrade`: Defines the procedure to be executed after a Component is upgraded.
 //
 // Actions can be executed in different ways:
 //
@@ -1841,6 +1886,10 @@ type ComponentLifecycleActions struct {
 //   - GRPCAction: Issues a unary gRPC call to a target service.
 //     This allows developers to implement Actions using plugins written in programming language like Go,
 //     providing greater flexibility and extensibility.
+//   - TCPAction: Sends a payload to a TCP server and matches the response against an expected pattern.
+//     This allows engines that speak simple text protocols to be probed without a shell or CLI in the image.
+//   - ScriptAction: Evaluates a CEL expression within kb-agent, which can issue HTTP or TCP calls and parse JSON.
+//     This allows simple probes to be implemented without a shell or tools like jq in the image.
 //
 // An action is considered successful on returning 0, or HTTP 2xx for status HTTP actions.
 // Any other return value or HTTP status codes indicate failure,
@@ -1873,6 +1922,20 @@ type Action struct {
 	// +optional
 	GRPC *GRPCAction `json:"grpc,omitempty"`
 
+	// Defines the TCP request to send and the response to expect.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	TCP *TCPAction `json:"tcp,omitempty"`
+
+	// Defines the script to evaluate.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Script *ScriptAction `json:"script,omitempty"`
+
 	// Defines the criteria used to select the target Pod(s) for executing the Action.
 	// This is useful when there is no default target replica identified.
 	// It allows for precise control over which Pod(s) the Action should run in.
@@ -1942,10 +2005,51 @@ type Action struct {
 	//
 	// +optional
 	PreCondition *PreConditionType `json:"preCondition,omitempty"`
+
+	// Defines the lock group of the Action, to prevent it from running concurrently with the conflicting Actions
+	// on the same replica, such as `switchover`, `memberLeave` and `reconfigure`.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Lock *ActionLock `json:"lock,omitempty"`
+
+	// Declares the named outputs of the Action.
+	//
+	// An Action with outputs declared should print a JSON object to stdout, and each output is read from
+	// the top-level field of the same name. Non-string values are kept in their JSON form.
+	//
+	// The outputs are made available to the following Actions of the same operation as the variable
+	// `KB_OUTPUT_<ACTION>_<OUTPUT>`, with the names upper-cased, e.g., the output `leader` of the `switchover`
+	// Action is passed to the `memberLeave` Action that follows it as `KB_OUTPUT_SWITCHOVER_LEADER`.
+	//
+	// Outputs are not supported by the `dataDump` and `dataLoad` Actions, and the ComponentDefinition declaring
+	// them is rejected: their stdout and stdin carry the data transferred by kb-agent. The values that `dataLoad`
+	// needs from the dump, such as the binlog position, should be carried in the data itself, e.g., as a header
+	// that `dataLoad` parses.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Outputs []ActionOutput `json:"outputs,omitempty"`
+
+	// Declares that the Action supports dry-run.
+	//
+	// An Action that supports dry-run is passed the variable `KB_DRY_RUN`, which is "true" when it is called in
+	// dry-run mode, e.g., by a Switchover or Reconfiguring OpsRequest with `dryRun` set. In that mode, the Action should only validate
+	// its preconditions and report what it would do to stdout, without making any change to the replica.
+	// The variable is available both as an environment variable and a template variable.
+	//
+	// The Actions that do not declare the support are refused to be called in dry-run mode.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	SupportDryRun bool `json:"supportDryRun,omitempty"`
 }
 
 func (a *Action) Defined() bool {
-	return a != nil && (a.Exec != nil || a.HTTP != nil || a.GRPC != nil)
+	return a != nil && (a.Exec != nil || a.HTTP != nil || a.GRPC != nil || a.TCP != nil || a.Script != nil)
 }
 
 // ExecAction describes an Action that executes a command inside a container.
@@ -2175,6 +2279,103 @@ type GRPCResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// TCPAction describes an action that sends a payload to a TCP server and matches the response.
+//
+// It is intended for engines that speak simple line-based protocols, such as Redis, Memcached,
+// or the four-letter words of ZooKeeper.
+//
+// Success & output:
+//   - The response is read until it matches the `expect` pattern, the server closes the connection,
+//     the server stays idle for 1 second after it has responded, or the response exceeds 64KiB.
+//   - If `expect` is specified, the action succeeds only if the response matches it,
+//     and the server is expected to respond within 10 seconds.
+//   - If neither `payload` nor `expect` is specified, the action succeeds once the connection is established.
+//   - The response is written to stdout if the action succeeds, or to stderr if it fails.
+type TCPAction struct {
+	// The port to access on the host.
+	// It may be a numeric string (e.g., "6379") or a named port defined in the container spec.
+	//
+	// +kubebuilder:validation:Required
+	Port string `json:"port"`
+
+	// The target host to connect to.
+	// Defaults to "127.0.0.1" if not specified.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// The payload to send after the connection is established, nothing is sent if not specified.
+	//
+	// Supports Go text/template syntax; rendered with predefined variables before sending.
+	//
+	// +optional
+	Payload string `json:"payload,omitempty"`
+
+	// The regular expression (RE2 syntax) that the response is expected to match.
+	//
+	// +optional
+	Expect string `json:"expect,omitempty"`
+
+	// Specifies to perform a TLS handshake after the connection is established.
+	//
+	// +optional
+	TLS *TCPTLSConfig `json:"tls,omitempty"`
+}
+
+// TCPTLSConfig defines the TLS handshake of a TCP action.
+type TCPTLSConfig struct {
+	// The server name to verify the certificate of the server against.
+	// Defaults to the host if not specified.
+	//
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Specifies to skip the verification of the certificate of the server.
+	//
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ScriptAction describes an action that evaluates a CEL expression within kb-agent.
+//
+// Besides the standard CEL functions and the string extensions, the following are available in the expression:
+//   - `env`: a map of the predefined variables and the parameters of the action.
+//   - `httpGet(url)` and `httpPost(url, body)`: issue an HTTP request and return a map with the `status` and `body`
+//     of the response. A non-2xx status is not an error, it is up to the expression to check it.
+//   - `tcpCall(address, payload)` and `tcpCall(address, payload, pattern)`: send the payload to a TCP server and
+//     return the response, which is read until it matches the pattern (RE2 syntax) if specified.
+//   - `parseJSON(string)`: parse the JSON text into a value.
+//
+// Success & output:
+//   - string or bytes: the action succeeds, and the result is the output.
+//   - bool: the action succeeds if the result is true.
+//   - int: the result is the exit code, the action succeeds if it is 0.
+//   - map or list: the action succeeds, and the result is encoded as JSON as the output.
+//
+// The evaluation fails if it exceeds the cost limit, the memory limit, or the timeout of the action.
+type ScriptAction struct {
+	// The CEL expression to evaluate.
+	//
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+
+	// The limit of the runtime cost of the evaluation, which bounds the CPU time it consumes.
+	// Defaults to 1000000 if not specified.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CostLimit *int64 `json:"costLimit,omitempty"`
+
+	// The limit of the bytes of the data held by the evaluation, including the data fetched by the HTTP and TCP calls,
+	// the JSON text parsed by `parseJSON`, and the result.
+	// The memory consumed by the other operations, such as the string concatenations, is bounded by the cost limit.
+	// Defaults to 1MiB if not specified.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MemoryLimit *int64 `json:"memoryLimit,omitempty"`
+}
+
 // TargetPodSelector defines how to select pod(s) to execute an Action.
 // +enum
 // +kubebuilder:validation:Enum={Any,All,Role,Ordinal}
@@ -2222,6 +2423,59 @@ const (
 	ClusterReadyPreConditionType   PreConditionType = "ClusterReady"
 )
 
+// ActionLock defines the lock group of an Action.
+// The Actions in the same lock group are mutually exclusive on a replica.
+type ActionLock struct {
+	// The name of the lock group.
+	//
+	// +kubebuilder:validation:Required
+	Group string `json:"group"`
+
+	// Specifies how to handle the Action if another Action in the same lock group is running on the replica.
+	//
+	// - `Reject`: The Action fails immediately with a busy error, and it will be retried later.
+	// - `Queue`: The Action waits for the running Action to finish, up to `queueTimeoutSeconds`.
+	// - `Preempt`: The running Action is canceled, and the Action is executed right after it exits.
+	//
+	// +kubebuilder:default=Reject
+	// +optional
+	Policy ActionConflictPolicy `json:"policy,omitempty"`
+
+	// Specifies the maximum duration in seconds that the Action waits in the queue.
+	// It is only applicable to the `Queue` policy, and defaults to the timeout of the Action.
+	//
+	// +optional
+	QueueTimeoutSeconds int32 `json:"queueTimeoutSeconds,omitempty"`
+}
+
+// ActionOutput declares a named output of an Action.
+type ActionOutput struct {
+	// The name of the output, it is the top-level field of the JSON object printed by the Action.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z][a-zA-Z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+
+	// Specifies whether the output is required.
+	// The Action is considered failed if a required output is missing from its output.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// ActionConflictPolicy defines how to handle an Action that conflicts with a running one.
+// +enum
+// +kubebuilder:validation:Enum={Reject,Queue,Preempt}
+type ActionConflictPolicy string
+
+const (
+	RejectOnConflict  ActionConflictPolicy = "Reject"
+	QueueOnConflict   ActionConflictPolicy = "Queue"
+	PreemptOnConflict ActionConflictPolicy = "Preempt"
+)
+
 type Probe struct {
 	Action `json:",inline"`
 
@@ -2248,6 +2502,32 @@ type Probe struct {
 	//
 	// +optional
 	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// Randomizes each probe period by up to the percentage of `periodSeconds` in both directions,
+	// to spread the probes of the replicas that are started together.
+	// Not randomized if not specified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	JitterPercent int32 `json:"jitterPercent,omitempty"`
+
+	// Enables the exponential backoff of the probe period once the `failureThreshold` is reached.
+	// The period is doubled on each failure up to the value, and it is reset on success.
+	// Note that the recovery of the replica is noticed at most this many seconds late while backing off.
+	// No backoff if not specified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+
+	// Limits the events sent by the probe per minute, the events exceeding the limit are delayed,
+	// and only the latest one is kept.
+	// No limit if not specified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxEventsPerMinute int32 `json:"maxEventsPerMinute,omitempty"`
 }
 
 // ActionAssertion defines the custom assertions for evaluating the success or failure of an action.
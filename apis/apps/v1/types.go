@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -48,6 +49,8 @@ const (
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components and shardings are running
 	ConditionTypeAvailable           = "Available"           // ConditionTypeAvailable indicates whether the target object is available for serving.
 	ConditionTypeRestore             = "Restore"             // ConditionTypeRestore indicates whether the initial cluster restore has completed.
+	ConditionTypeUpdateDeferred      = "UpdateDeferred"      // ConditionTypeUpdateDeferred indicates that the updates of instances are deferred until the maintenance window opens.
+	ConditionTypeDrifted             = "Drifted"             // ConditionTypeDrifted indicates that the live objects owned by the target object have drifted from the desired state.
 )
 
 type ServiceRef struct {
@@ -660,6 +663,68 @@ type RollingUpdate struct {
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
+// DriftPolicyType defines how the drifts of the owned objects are handled.
+//
+// +enum
+// +kubebuilder:validation:Enum={Revert,Warn,Adopt}
+type DriftPolicyType string
+
+const (
+	// RevertDriftPolicyType indicates that the drifts are reported and reverted to the desired state.
+	RevertDriftPolicyType DriftPolicyType = "Revert"
+
+	// WarnDriftPolicyType indicates that the drifts are reported as warnings and kept in the live objects.
+	WarnDriftPolicyType DriftPolicyType = "Warn"
+
+	// AdoptDriftPolicyType indicates that the drifts are accepted as the new baseline and kept in the live objects,
+	// until the desired state of the objects changes.
+	AdoptDriftPolicyType DriftPolicyType = "Adopt"
+)
+
+// MaintenanceWindow defines the recurring time windows in which the disruptive changes are allowed to start.
+type MaintenanceWindow struct {
+	// Specifies the time when the maintenance window opens, in the Cron format.
+	// For example, "0 2 * * 6" opens the window at 02:00 every Saturday.
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies how long the maintenance window keeps open, e.g. "4h".
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// Specifies the time zone in which the schedule is evaluated, e.g. "Asia/Shanghai".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// DisruptionBudget overrides the PodDisruptionBudget generated for a Component.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.maxUnavailable) && has(self.minAvailable))",message="maxUnavailable and minAvailable are mutually exclusive"
+type DisruptionBudget struct {
+	// Specifies whether to disable the PodDisruptionBudget of the Component.
+	// If true, the PodDisruptionBudget is removed, and the pods can be evicted without any limitation.
+	//
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Specifies the maximum number of pods that can be unavailable during the voluntary disruptions,
+	// such as node drains and cluster-autoscaler scale-downs.
+	// Value can be an absolute number (ex: 1) or a percentage of replicas (ex: 10%).
+	//
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Specifies the minimum number of pods that must be available during the voluntary disruptions.
+	// Value can be an absolute number (ex: 2) or a percentage of replicas (ex: 50%).
+	//
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
 type SchedulingPolicy struct {
 	// If specified, the Pod will be dispatched by specified scheduler.
 	// If not specified, the Pod will be dispatched by default scheduler.
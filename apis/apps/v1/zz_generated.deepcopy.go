@@ -50,6 +50,16 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(GRPCAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(ScriptAction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RetryPolicy != nil {
 		in, out := &in.RetryPolicy, &out.RetryPolicy
 		*out = new(RetryPolicy)
@@ -60,6 +70,16 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(PreConditionType)
 		**out = **in
 	}
+	if in.Lock != nil {
+		in, out := &in.Lock, &out.Lock
+		*out = new(ActionLock)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]ActionOutput, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
@@ -102,6 +122,36 @@ func (in *ActionAssertion) DeepCopy() *ActionAssertion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionLock) DeepCopyInto(out *ActionLock) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionLock.
+func (in *ActionLock) DeepCopy() *ActionLock {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionOutput) DeepCopyInto(out *ActionOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionOutput.
+func (in *ActionOutput) DeepCopy() *ActionOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionOutputMatcher) DeepCopyInto(out *ActionOutputMatcher) {
 	*out = *in
@@ -407,6 +457,11 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Instances != nil {
 		in, out := &in.Instances, &out.Instances
 		*out = make([]InstanceTemplate, len(*in))
@@ -565,6 +620,44 @@ func (in *ClusterDefinitionStatus) DeepCopy() *ClusterDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHibernation) DeepCopyInto(out *ClusterHibernation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHibernation.
+func (in *ClusterHibernation) DeepCopy() *ClusterHibernation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHibernation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHibernationStatus) DeepCopyInto(out *ClusterHibernationStatus) {
+	*out = *in
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHibernationStatus.
+func (in *ClusterHibernationStatus) DeepCopy() *ClusterHibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -780,6 +873,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ClusterRestore)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(ClusterHibernation)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -809,6 +912,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(ClusterHibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -998,6 +1106,72 @@ func (in *Component) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentActionHistory) DeepCopyInto(out *ComponentActionHistory) {
+	*out = *in
+	if in.Invocations != nil {
+		in, out := &in.Invocations, &out.Invocations
+		*out = make([]ComponentActionInvocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentActionHistory.
+func (in *ComponentActionHistory) DeepCopy() *ComponentActionHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentActionHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentActionInvocation) DeepCopyInto(out *ComponentActionInvocation) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentActionInvocation.
+func (in *ComponentActionInvocation) DeepCopy() *ComponentActionInvocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentActionInvocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentActionOutputs) DeepCopyInto(out *ComponentActionOutputs) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentActionOutputs.
+func (in *ComponentActionOutputs) DeepCopy() *ComponentActionOutputs {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentActionOutputs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentAvailable) DeepCopyInto(out *ComponentAvailable) {
 	*out = *in
@@ -1489,6 +1663,16 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreUpgrade != nil {
+		in, out := &in.PreUpgrade, &out.PreUpgrade
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostUpgrade != nil {
+		in, out := &in.PostUpgrade, &out.PostUpgrade
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentLifecycleActions.
@@ -1693,6 +1877,16 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 	if in.SchedulingPolicy != nil {
 		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
 		*out = new(SchedulingPolicy)
@@ -1777,6 +1971,20 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ActionOutputs != nil {
+		in, out := &in.ActionOutputs, &out.ActionOutputs
+		*out = make([]ComponentActionOutputs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActionHistory != nil {
+		in, out := &in.ActionHistory, &out.ActionHistory
+		*out = make([]ComponentActionHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -2183,6 +2391,31 @@ func (in *CustomAction) DeepCopy() *CustomAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudget) DeepCopyInto(out *DisruptionBudget) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudget.
+func (in *DisruptionBudget) DeepCopy() *DisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
@@ -2600,6 +2833,22 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultipleClusterObjectCombinedOption) DeepCopyInto(out *MultipleClusterObjectCombinedOption) {
 	*out = *in
@@ -3014,6 +3263,31 @@ func (in *SchedulingPolicy) DeepCopy() *SchedulingPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptAction) DeepCopyInto(out *ScriptAction) {
+	*out = *in
+	if in.CostLimit != nil {
+		in, out := &in.CostLimit, &out.CostLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptAction.
+func (in *ScriptAction) DeepCopy() *ScriptAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -3912,6 +4186,41 @@ func (in *SystemAccountStatement) DeepCopy() *SystemAccountStatement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPAction) DeepCopyInto(out *TCPAction) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TCPTLSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPAction.
+func (in *TCPAction) DeepCopy() *TCPAction {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPTLSConfig) DeepCopyInto(out *TCPTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPTLSConfig.
+func (in *TCPTLSConfig) DeepCopy() *TCPTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
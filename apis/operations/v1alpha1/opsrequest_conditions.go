@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ const (
 	// condition types
 	ConditionTypeCancelled          = "Cancelled"
 	ConditionTypeWaitForProgressing = "WaitForProgressing"
+	ConditionTypeMaintenanceWindow  = "MaintenanceWindow"
 	ConditionTypeValidated          = "Validated"
 	ConditionTypeSucceed            = "Succeed"
 	ConditionTypeFailed             = "Failed"
@@ -45,6 +47,7 @@ const (
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeClone              = "Clone"
 
 	// condition and event reasons
 	ReasonClusterPhaseMismatch  = "ClusterPhaseMismatch"
@@ -79,6 +82,10 @@ const (
 	ReasonReconfigureRunning              = "ReconfigureRunning"
 	ReasonBackupStarted                   = "BackupStarted"
 	ReasonRestoreStarted                  = "RestoreStarted"
+	ReasonCloneStarted                    = "CloneStarted"
+	ReasonMaintenanceWindowClosed         = "MaintenanceWindowClosed"
+	ReasonMaintenanceWindowOpened         = "MaintenanceWindowOpened"
+	ReasonMaintenanceWindowBypassed       = "MaintenanceWindowBypassed"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -97,6 +104,34 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForMaintenanceWindowCondition the OpsRequest waits for the maintenance window of the cluster to open.
+func NewWaitForMaintenanceWindowCondition(ops *OpsRequest, opensAt time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonMaintenanceWindowClosed,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("the OpsRequest: %s waits for the maintenance window of Cluster: %s, it will start at %s",
+			ops.Name, ops.Spec.GetClusterName(), opensAt.UTC().Format(time.RFC3339)),
+	}
+}
+
+// NewMaintenanceWindowPassedCondition the OpsRequest is allowed to start by the maintenance window of the cluster.
+func NewMaintenanceWindowPassedCondition(ops *OpsRequest) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonMaintenanceWindowOpened,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("the maintenance window of Cluster: %s is open", ops.Spec.GetClusterName()),
+	}
+	if ops.Force() {
+		condition.Reason = ReasonMaintenanceWindowBypassed
+		condition.Message = fmt.Sprintf("the maintenance window of Cluster: %s is bypassed by force", ops.Spec.GetClusterName())
+	}
+	return condition
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -376,6 +411,21 @@ func NewBackupCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewCloneCondition creates a condition that the OpsRequest clones the cluster.
+func NewCloneCondition(ops *OpsRequest) *metav1.Condition {
+	message := "Start to clone the Cluster"
+	if ops.Spec.Clone != nil {
+		message = fmt.Sprintf("Start to clone the Cluster: %s to %s", ops.Spec.GetClusterName(), ops.Spec.Clone.TargetClusterName)
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeClone,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonCloneStarted,
+		LastTransitionTime: metav1.Now(),
+		Message:            message,
+	}
+}
+
 // NewRestoreCondition creates a condition that the OpsRequest restore the cluster.
 func NewRestoreCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
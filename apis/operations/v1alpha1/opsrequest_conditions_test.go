@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sethvargo/go-password/password"
@@ -106,6 +107,10 @@ func TestReasonConstantsStringDrift(t *testing.T) {
 		{"ReasonReconfigureFailed", ReasonReconfigureFailed, "ReconfigureFailed"},
 		{"ReasonBackupStarted", ReasonBackupStarted, "BackupStarted"},
 		{"ReasonRestoreStarted", ReasonRestoreStarted, "RestoreStarted"},
+		{"ReasonCloneStarted", ReasonCloneStarted, "CloneStarted"},
+		{"ReasonMaintenanceWindowClosed", ReasonMaintenanceWindowClosed, "MaintenanceWindowClosed"},
+		{"ReasonMaintenanceWindowOpened", ReasonMaintenanceWindowOpened, "MaintenanceWindowOpened"},
+		{"ReasonMaintenanceWindowBypassed", ReasonMaintenanceWindowBypassed, "MaintenanceWindowBypassed"},
 	}
 	for _, tc := range cases {
 		if tc.got != tc.want {
@@ -144,6 +149,9 @@ func TestNewConditionReasonsMatchConstants(t *testing.T) {
 		{"NewReconfigureFailedCondition", NewReconfigureFailedCondition(opsRequest, nil).Reason, ReasonReconfigureFailed},
 		{"NewBackupCondition", NewBackupCondition(opsRequest).Reason, ReasonBackupStarted},
 		{"NewRestoreCondition", NewRestoreCondition(opsRequest).Reason, ReasonRestoreStarted},
+		{"NewCloneCondition", NewCloneCondition(opsRequest).Reason, ReasonCloneStarted},
+		{"NewWaitForMaintenanceWindowCondition", NewWaitForMaintenanceWindowCondition(opsRequest, time.Now()).Reason, ReasonMaintenanceWindowClosed},
+		{"NewMaintenanceWindowPassedCondition", NewMaintenanceWindowPassedCondition(opsRequest).Reason, ReasonMaintenanceWindowOpened},
 	}
 	for _, tc := range cases {
 		if tc.got != tc.want {
@@ -51,6 +51,8 @@ type OpsRequestSpec struct {
 	// By setting `force` to true, you can bypass the default checks and demand these opsRequests to run
 	// simultaneously.
 	//
+	// The disruptive opsRequest with `force` set to true also bypasses the maintenance window of the cluster.
+	//
 	// Note: Once set, the `force` field is immutable and cannot be updated.
 	//
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.force"
@@ -64,7 +66,7 @@ type OpsRequestSpec struct {
 
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
-	// "Expose", "RebuildInstance", "Custom".
+	// "Expose", "RebuildInstance", "Custom", "Clone".
 	//
 	// Note: This field is immutable once set.
 	//
@@ -228,6 +230,12 @@ type SpecificOpsRequest struct {
 	//
 	// +optional
 	CustomOps *CustomOps `json:"custom,omitempty"`
+	// Specifies the parameters to clone the Cluster.
+	// It creates a new Cluster with the data restored from a Backup of the Cluster specified by `spec.clusterName`.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clone"
+	// +optional
+	Clone *Clone `json:"clone,omitempty"`
 }
 
 // ComponentOps specifies the Component to be operated on.
@@ -317,6 +325,14 @@ type Switchover struct {
 	//
 	// +optional
 	CandidateName string `json:"candidateName,omitempty"`
+	// Specifies that the switchover lifecycle action is only called in dry-run mode.
+	// The action validates its preconditions and reports what it would do, without transferring the role.
+	// The result is recorded in `status.components[*].dryRunResult`.
+	//
+	// The switchover lifecycle action must declare the dry-run support.
+	//
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // Upgrade defines the parameters for an upgrade operation.
@@ -566,6 +582,16 @@ type Reconfigure struct {
 	//
 	// +optional
 	Parameters []ParameterPair `json:"parameters,omitempty"`
+
+	// Specifies that the reconfigure lifecycle action is only called in dry-run mode, on a replica of the Component,
+	// with the parameters passed. The action validates the parameters and reports what it would do, and the
+	// configuration is not changed. The parameters are passed to the action as the variable `KB_CONFIG_PARAMETERS`,
+	// a JSON object of the parameter names and values. The result is recorded in `status.components[*].dryRunResult`.
+	//
+	// The reconfigure lifecycle action must declare the dry-run support.
+	//
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type CustomOps struct {
@@ -953,6 +979,67 @@ type Restore struct {
 	Parameters []dpv1alpha1.ParameterPair `json:"parameters,omitempty"`
 }
 
+// Clone defines the parameters to clone a Cluster.
+type Clone struct {
+	// Specifies the name of the target Cluster to be created.
+	//
+	// +kubebuilder:validation:Required
+	TargetClusterName string `json:"targetClusterName"`
+
+	// Specifies the namespace of the target Cluster. If not specified, the namespace of the opsRequest will be used.
+	// It must be the namespace of the opsRequest, the Cluster can not be cloned to another namespace.
+	//
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// Specifies the name of the Backup of the source Cluster to clone the data from,
+	// the Backup should be in the namespace of the opsRequest.
+	//
+	// If not specified, the latest completed Backup of the source Cluster is used,
+	// or the continuous Backup that covers `restorePointInTime` if it is specified.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Specifies the point in time to clone the data at, a continuous Backup of the source Cluster is required.
+	// Supported time formats:
+	//
+	// - RFC3339 format, e.g. "2023-11-25T18:52:53Z"
+	// - A human-readable date-time format, e.g. "Jul 25,2023 18:52:53 UTC+0800"
+	//
+	// +optional
+	RestorePointInTime string `json:"restorePointInTime,omitempty"`
+
+	// Specifies the overrides of the Components of the target Cluster.
+	//
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:MaxItems=1024
+	// +optional
+	Components []CloneComponent `json:"components,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+}
+
+// CloneComponent defines the overrides of a Component of the target Cluster.
+type CloneComponent struct {
+	// Specifies the name of the Component or Sharding as defined in the cluster.spec.
+	//
+	// +kubebuilder:validation:Required
+	ComponentName string `json:"componentName"`
+
+	// Overrides the number of replicas of the Component.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Overrides the compute resources of the Component's instances.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // OpsRequestStatus represents the observed state of an OpsRequest.
 type OpsRequestStatus struct {
 	// Records the cluster generation after the OpsRequest action has been handled.
@@ -991,10 +1078,14 @@ type OpsRequestStatus struct {
 	// +optional
 	CancelTimestamp metav1.Time `json:"cancelTimestamp,omitempty"`
 
+	// Records the progress of the Clone operation.
+	// +optional
+	Clone *CloneStatus `json:"clone,omitempty"`
+
 	// Describes the detailed status of the OpsRequest.
 	// Possible condition types include "Cancelled", "WaitForProgressing", "Validated", "Succeed", "Failed", "Restarting",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpanding", "Reconfigure", "Switchover", "Stopping", "Starting",
-	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation".
+	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation", "Clone".
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -1003,6 +1094,44 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// CloneStatus records the progress of the Clone operation.
+type CloneStatus struct {
+	// The name of the Backup that the data is cloned from.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The point in time that the data is cloned at, in RFC3339 format.
+	// +optional
+	RestorePointInTime string `json:"restorePointInTime,omitempty"`
+
+	// Records the steps of the Clone operation, in the order they are performed.
+	// +optional
+	Steps []CloneStep `json:"steps,omitempty"`
+}
+
+// CloneStep records the progress of a step of the Clone operation.
+type CloneStep struct {
+	// The name of the step.
+	// +kubebuilder:validation:Required
+	Name CloneStepName `json:"name"`
+
+	// Represents the current processing state of the step, including "Processing", "Pending", "Failed", "Succeed".
+	// +kubebuilder:validation:Required
+	Status ProgressStatus `json:"status"`
+
+	// Provides a human-readable explanation of the step's status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the start time of the step.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// Records the completion time of the step.
+	// +optional
+	EndTime metav1.Time `json:"endTime,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="at least one objectKey or actionName."
 
 type ProgressStatusDetail struct {
@@ -1136,6 +1265,34 @@ type OpsRequestComponentStatus struct {
 	// +kubebuilder:validation:MaxLength=32768
 	// +optional
 	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
+
+	// Records the result of the lifecycle action called in dry-run mode for the Component.
+	// +optional
+	DryRunResult *ActionDryRunResult `json:"dryRunResult,omitempty"`
+}
+
+// ActionDryRunResult records the result of a lifecycle action called in dry-run mode.
+type ActionDryRunResult struct {
+	// The name of the lifecycle action.
+	// +kubebuilder:validation:Required
+	Action string `json:"action"`
+
+	// The name of the Pod on which the action is called.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// Indicates whether the action succeeded, that is, its preconditions are met.
+	// +kubebuilder:validation:Required
+	Succeeded bool `json:"succeeded"`
+
+	// The output of the action that reports what it would do, or the error message if it failed.
+	// +kubebuilder:validation:MaxLength=32768
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// The time when the action was called.
+	// +optional
+	Time metav1.Time `json:"time,omitempty"`
 }
 
 type PreCheckResult struct {
@@ -19,6 +19,10 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 )
 
 var componentName = "mysql"
@@ -197,6 +201,73 @@ func TestValidateUpgradeServiceVersion(t *testing.T) {
 	}
 }
 
+func TestValidateClone(t *testing.T) {
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod"},
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{{Name: "mysql"}},
+			Shardings:      []appsv1.ClusterSharding{{Name: "shard"}},
+		},
+	}
+	tests := []struct {
+		name    string
+		clone   *Clone
+		wantErr bool
+	}{
+		{
+			name:    "nil clone",
+			clone:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty target cluster name",
+			clone:   &Clone{},
+			wantErr: true,
+		},
+		{
+			name:    "target is the source cluster",
+			clone:   &Clone{TargetClusterName: "prod"},
+			wantErr: true,
+		},
+		{
+			name:    "another namespace",
+			clone:   &Clone{TargetClusterName: "staging", TargetNamespace: "staging"},
+			wantErr: true,
+		},
+		{
+			name:    "the namespace of the opsRequest",
+			clone:   &Clone{TargetClusterName: "staging", TargetNamespace: "default"},
+			wantErr: false,
+		},
+		{
+			name: "overrides of the component and sharding",
+			clone: &Clone{
+				TargetClusterName: "staging",
+				Components:        []CloneComponent{{ComponentName: "mysql"}, {ComponentName: "shard"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "overrides of a component not found",
+			clone: &Clone{
+				TargetClusterName: "staging",
+				Components:        []CloneComponent{{ComponentName: "redis"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &OpsRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+			r.Spec.Clone = tt.clone
+			err := r.validateClone(cluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateClone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func strPtr(s string) *string {
 	return &s
 }
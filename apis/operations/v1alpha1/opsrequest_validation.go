@@ -146,6 +146,8 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case CloneType:
+		return r.validateClone(cluster)
 	}
 	return nil
 }
@@ -197,6 +199,29 @@ func (r *OpsRequest) validateRebuildInstance(cluster *appsv1.Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+// validateClone validates spec.clone
+func (r *OpsRequest) validateClone(cluster *appsv1.Cluster) error {
+	clone := r.Spec.Clone
+	if clone == nil {
+		return notEmptyError("spec.clone")
+	}
+	if len(clone.TargetClusterName) == 0 {
+		return notEmptyError("spec.clone.targetClusterName")
+	}
+	// the target cluster is created by the operator, creating it in another namespace would bypass the RBAC of the requester
+	if len(clone.TargetNamespace) > 0 && clone.TargetNamespace != r.Namespace {
+		return fmt.Errorf("the target namespace %s must be the namespace of the opsRequest %s", clone.TargetNamespace, r.Namespace)
+	}
+	if clone.TargetClusterName == cluster.Name && r.Namespace == cluster.Namespace {
+		return fmt.Errorf("the target cluster %s/%s can not be the source cluster", r.Namespace, clone.TargetClusterName)
+	}
+	var compOpsList []ComponentOps
+	for _, v := range clone.Components {
+		compOpsList = append(compOpsList, ComponentOps{ComponentName: v.ComponentName})
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *appsv1.Cluster) error {
 	restartList := r.Spec.RestartList
@@ -72,7 +72,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom,Clone}
 type OpsType string
 
 const (
@@ -90,6 +90,20 @@ const (
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
 	CustomType            OpsType = "Custom"          // use opsDefinition
+	CloneType             OpsType = "Clone"           // CloneType creates a new cluster with the data restored from a backup of the cluster.
+)
+
+// CloneStepName defines the steps of the Clone operation.
+// +enum
+// +kubebuilder:validation:Enum={ResolveBackup,CreateCluster,RenderParameters,RestoreData,ClusterReady}
+type CloneStepName string
+
+const (
+	CloneStepResolveBackup    CloneStepName = "ResolveBackup"    // resolve the Backup of the source cluster to clone the data from
+	CloneStepCreateCluster    CloneStepName = "CreateCluster"    // create the target cluster from the snapshot of the source cluster in the Backup
+	CloneStepRenderParameters CloneStepName = "RenderParameters" // re-render the ComponentParameters with the parameters of the source cluster
+	CloneStepRestoreData      CloneStepName = "RestoreData"      // wait for the data to be restored
+	CloneStepClusterReady     CloneStepName = "ClusterReady"     // wait for the target cluster to be running
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
@@ -31,6 +31,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionDryRunResult) DeepCopyInto(out *ActionDryRunResult) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionDryRunResult.
+func (in *ActionDryRunResult) DeepCopy() *ActionDryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionDryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionTask) DeepCopyInto(out *ActionTask) {
 	*out = *in
@@ -66,6 +82,92 @@ func (in *Backup) DeepCopy() *Backup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Clone) DeepCopyInto(out *Clone) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]CloneComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Clone.
+func (in *Clone) DeepCopy() *Clone {
+	if in == nil {
+		return nil
+	}
+	out := new(Clone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneComponent) DeepCopyInto(out *CloneComponent) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneComponent.
+func (in *CloneComponent) DeepCopy() *CloneComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneStatus) DeepCopyInto(out *CloneStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]CloneStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneStatus.
+func (in *CloneStatus) DeepCopy() *CloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneStep) DeepCopyInto(out *CloneStep) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneStep.
+func (in *CloneStep) DeepCopy() *CloneStep {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompletionProbe) DeepCopyInto(out *CompletionProbe) {
 	*out = *in
@@ -739,6 +841,11 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DryRunResult != nil {
+		in, out := &in.DryRunResult, &out.DryRunResult
+		*out = new(ActionDryRunResult)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -836,6 +943,11 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
 	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
 	in.CancelTimestamp.DeepCopyInto(&out.CancelTimestamp)
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(CloneStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1428,6 +1540,11 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 		*out = new(CustomOps)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(Clone)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificOpsRequest.
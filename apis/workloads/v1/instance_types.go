@@ -193,6 +193,11 @@ type InstanceStatus2 struct {
 	// +optional
 	VolumeExpansion bool `json:"volumeExpansion,omitempty"`
 
+	// Represents the replication status of the instance observed, it is reported by the role probe in the structured output.
+	//
+	// +optional
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+
 	// Represents the config status observed from the running pod of this instance.
 	//
 	// +optional
@@ -209,6 +209,12 @@ type InstanceSetSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Specifies the maintenance window in which the updates of instances are allowed.
+	// The updates are deferred until the window opens.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
 	// Members(Pods) update strategy.
 	//
 	// - serial: update Members one by one that guarantee minimum component unavailable time.
@@ -493,6 +499,11 @@ type InstanceUpdateStrategy = kbappsv1.InstanceUpdateStrategy
 // +kubebuilder:object:generate=false
 type RollingUpdate = kbappsv1.RollingUpdate
 
+// MaintenanceWindow defines the recurring time windows in which the disruptive changes are allowed to start.
+//
+// +kubebuilder:object:generate=false
+type MaintenanceWindow = kbappsv1.MaintenanceWindow
+
 // MemberUpdateStrategy defines Cluster Component update strategy.
 // +enum
 type MemberUpdateStrategy string
@@ -588,6 +599,30 @@ type InstanceStatus struct {
 	//
 	// +optional
 	VolumeExpansion bool `json:"volumeExpansion,omitempty"`
+
+	// Represents the replication status of the instance observed, it is reported by the role probe in the structured output.
+	//
+	// +optional
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+}
+
+// ReplicationStatus represents the replication status of a replica reported by the role probe.
+type ReplicationStatus struct {
+	// The election term, or epoch, of the replication group observed by the replica.
+	//
+	// +optional
+	Term *int64 `json:"term,omitempty"`
+
+	// The replication lag of the replica behind the leader. The unit is defined by the role probe, such as bytes or
+	// seconds, so the lag is only comparable among the replicas of a workload, the smaller the more up-to-date.
+	//
+	// +optional
+	Lag *int64 `json:"lag,omitempty"`
+
+	// The name of the leader replica as observed by the replica.
+	//
+	// +optional
+	Leader string `json:"leader,omitempty"`
 }
 
 type InstanceConfigStatus struct {
@@ -650,6 +685,9 @@ const (
 	// InstanceUpdateRestricted represents a ConditionType that indicates updates to an InstanceSet are blocked(when the
 	// PodUpdatePolicy is set to StrictInPlace but the pods cannot be updated in-place).
 	InstanceUpdateRestricted ConditionType = "InstanceUpdateRestricted"
+
+	// InstanceUpdateDeferred indicates that the updates of instances are deferred until the maintenance window opens.
+	InstanceUpdateDeferred ConditionType = "InstanceUpdateDeferred"
 )
 
 const (
@@ -679,6 +717,9 @@ const (
 
 	// ReasonInstanceUpdateRestricted is a reason for condition InstanceUpdateRestricted.
 	ReasonInstanceUpdateRestricted = "InstanceUpdateRestricted"
+
+	// ReasonMaintenanceWindowClosed is a reason for condition InstanceUpdateDeferred.
+	ReasonMaintenanceWindowClosed = "MaintenanceWindowClosed"
 )
 
 // IsInstancesReady gives Instance level 'ready' state when all instances are available
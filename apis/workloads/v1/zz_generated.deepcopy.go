@@ -312,6 +312,11 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 		*out = new(InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 	if in.MemberUpdateStrategy != nil {
 		in, out := &in.MemberUpdateStrategy, &out.MemberUpdateStrategy
 		*out = new(MemberUpdateStrategy)
@@ -500,6 +505,11 @@ func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
@@ -522,6 +532,11 @@ func (in *InstanceStatus2) DeepCopyInto(out *InstanceStatus2) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Configs != nil {
 		in, out := &in.Configs, &out.Configs
 		*out = make([]InstanceConfigStatus, len(*in))
@@ -653,3 +668,28 @@ func (in *LifecycleActions) DeepCopy() *LifecycleActions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
+	*out = *in
+	if in.Term != nil {
+		in, out := &in.Term, &out.Term
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
+func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -36,12 +36,14 @@ import (
 	kzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/server"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
 	defaultMaxConcurrency = 32
+	defaultAuditLogSize   = 256
 )
 
 var serverConfig server.Config
@@ -54,9 +56,20 @@ func init() {
 	pflag.StringVar(&serverConfig.UnixDomainSocket, "unix-socket", "", "The path of the Unix Domain Socket for kb-agent service.")
 	pflag.IntVar(&serverConfig.Port, "port", kbagent.DefaultHTTPPort, "The HTTP Server listen port for kb-agent service.")
 	pflag.IntVar(&serverConfig.StreamingPort, "streaming-port", kbagent.DefaultStreamingPort, "The listen port used by kb-agent to stream data.")
+	pflag.IntVar(&serverConfig.MetricsPort, "metrics-port", 0, "The listen port to serve the metrics in plain HTTP, the metrics are served on the HTTP server port if it is 0.")
 	pflag.IntVar(&serverConfig.Concurrency, "max-concurrency", defaultMaxConcurrency,
 		fmt.Sprintf("The maximum number of concurrent connections the Server may serve, use the default value %d if <=0.", defaultMaxConcurrency))
 	pflag.BoolVar(&serverConfig.Logging, "api-logging", true, "Enable api logging for kb-agent request.")
+	pflag.StringVar(&serverConfig.AuthTokenFile, "auth-token-file", "", "The file of the bearer token that clients must present, the token check is disabled if empty.")
+	pflag.StringVar(&serverConfig.TLSCAFile, "tls-ca-file", "", "The CA file used to verify client certificates, mTLS is enabled if set together with the cert and key.")
+	pflag.StringVar(&serverConfig.TLSCertFile, "tls-cert-file", "", "The certificate file used to serve TLS, TLS is disabled if empty.")
+	pflag.StringVar(&serverConfig.TLSKeyFile, "tls-key-file", "", "The private key file of the TLS certificate.")
+	pflag.StringVar(&serverConfig.TaskJournalDir, "task-journal-dir", "", "The directory to journal the state of tasks, tasks are not resumed after restart if empty.")
+	pflag.StringVar(&serverConfig.ReportChannel, "report-channel", proto.ReportChannelEvent,
+		fmt.Sprintf("The channel to report the probe and task results, %q or %q.", proto.ReportChannelEvent, proto.ReportChannelStatus))
+	pflag.StringVar(&serverConfig.AuditLogDir, "audit-log-dir", "", "The directory to keep the audit log of action invocations, the audit log is kept in memory only if empty.")
+	pflag.IntVar(&serverConfig.AuditLogSize, "audit-log-size", defaultAuditLogSize, "The maximum number of action invocations kept in the audit log.")
+	pflag.StringSliceVar(&serverConfig.FileAllowedPaths, "file-allowed-paths", nil, "The paths that the file service is allowed to write, read and delete files under, the file service is disabled if empty.")
 }
 
 func main() {
@@ -155,11 +155,20 @@ func init() {
 	viper.SetDefault(intctrlutil.FeatureGateEnableRuntimeMetrics, false)
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
 	viper.SetDefault(constant.FeatureGateInPlacePodVerticalScaling, false)
+	viper.SetDefault(constant.FeatureGateKBAgentAuthentication, false)
+	viper.SetDefault(constant.FeatureGateKBAgentTaskJournal, false)
+	viper.SetDefault(constant.FeatureGateKBAgentStatusReport, false)
+	viper.SetDefault(constant.FeatureGateKBAgentAuditLog, false)
+	viper.SetDefault(constant.FeatureGateKBAgentFileService, false)
 	viper.SetDefault(constant.I18nResourcesName, "kubeblocks-i18n-resources")
 	viper.SetDefault(constant.CfgKBReconcileWorkers, 32)
 	viper.SetDefault(constant.CfgCacheSyncTimeout, 300)
 	viper.SetDefault(constant.CfgClientQPS, 128)
 	viper.SetDefault(constant.CfgClientBurst, 256)
+	viper.SetDefault(constant.CfgKBAgentClientFailureThreshold, 3)
+	viper.SetDefault(constant.CfgKBAgentClientCircuitOpenSeconds, 30)
+	viper.SetDefault(constant.CfgKBAgentClientMaxRetries, 2)
+	viper.SetDefault(constant.CfgKBAgentClientRetryBackoffMS, 200)
 }
 
 type flagName string
@@ -432,6 +441,19 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "Event")
 			os.Exit(1)
 		}
+
+		if viper.GetBool(constant.FeatureGateKBAgentStatusReport) {
+			if err = (&k8scorecontrollers.KBAgentStatusReconciler{
+				Client:           mgr.GetClient(),
+				Scheme:           mgr.GetScheme(),
+				Recorder:         mgr.GetEventRecorderFor("kbagent-status-controller"),
+				AppsEnabled:      appsEnabled,
+				WorkloadsEnabled: workloadsEnabled,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "KBAgentStatus")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if appsEnabled {
@@ -566,6 +588,15 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "OpsRequest")
 			os.Exit(1)
 		}
+
+		if err = (&opscontrollers.ClusterHibernationReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("cluster-hibernation-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterHibernation")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(extensionsFlagKey.viperName()) {
@@ -26,11 +26,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
@@ -65,6 +71,8 @@ import (
 // +kubebuilder:rbac:groups=core,resources=pods/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
 	client.Client
@@ -144,6 +152,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterComponentStatusTransformer{},
 			// add our finalizer to all objects
 			&clusterOwnershipTransformer{},
+			// detect and handle the drifts of the owned objects
+			&clusterDriftTransformer{},
 			// update cluster status
 			&clusterStatusTransformer{},
 			// always safe to put your transformer below
@@ -176,5 +186,44 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Component{}).
 		Owns(&corev1.Service{}). // cluster services
 		Owns(&corev1.Secret{}).  // sharding account secret
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.filterNamespaceClusters),
+			builder.WithPredicates(maintenanceWindowChangedPredicate())). // maintenance window annotated on namespace
 		Complete(r)
 }
+
+// filterNamespaceClusters enqueues all the clusters in the namespace.
+func (r *ClusterReconciler) filterNamespaceClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusters := &appsv1.ClusterList{}
+	if err := r.Client.List(ctx, clusters, client.InNamespace(obj.GetName())); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0)
+	for _, cluster := range clusters.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      cluster.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// maintenanceWindowChangedPredicate filters the namespace events that change the maintenance window annotated.
+func maintenanceWindowChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return len(e.Object.GetAnnotations()[constant.MaintenanceWindowAnnotationKey]) > 0
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetAnnotations()[constant.MaintenanceWindowAnnotationKey] !=
+				e.ObjectNew.GetAnnotations()[constant.MaintenanceWindowAnnotationKey]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -65,6 +65,13 @@ type clusterTransformContext struct {
 
 	// TODO: remove this, annotations to be added to components for sharding, mapping with @allComps.
 	annotations map[string]map[string]string
+
+	// the maintenance window to be applied to components, nil if the cluster has no window or bypasses it
+	maintenanceWindow *appsv1.MaintenanceWindow
+
+	// the owned objects to be checked for drifts, and the drifts detected
+	driftObjects []driftObject
+	drifts       []intctrlutil.ObjectDrift
 }
 
 // clusterPlanBuilder a graph.PlanBuilder implementation for Cluster reconciliation
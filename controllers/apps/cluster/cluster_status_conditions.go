@@ -41,6 +41,7 @@ const (
 	ReasonRestoreCompleted      = "RestoreCompleted"
 	ReasonRestoreRunning        = "RestoreRunning"
 	ReasonRestoreFailed         = "RestoreFailed"
+	ReasonUpdateDeferred        = "MaintenanceWindowClosed" // ReasonUpdateDeferred the updates of components are deferred by the maintenance window
 )
 
 func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
@@ -279,6 +279,9 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1.Component) *appsv1.Com
 	compObjCopy.Spec.PodUpdatePolicy = compProto.Spec.PodUpdatePolicy
 	compObjCopy.Spec.PodUpgradePolicy = compProto.Spec.PodUpgradePolicy
 	compObjCopy.Spec.InstanceUpdateStrategy = compProto.Spec.InstanceUpdateStrategy
+	compObjCopy.Spec.DisruptionBudget = compProto.Spec.DisruptionBudget
+	compObjCopy.Spec.MaintenanceWindow = compProto.Spec.MaintenanceWindow
+	compObjCopy.Spec.DriftPolicy = compProto.Spec.DriftPolicy
 	compObjCopy.Spec.SchedulingPolicy = compProto.Spec.SchedulingPolicy
 	compObjCopy.Spec.TLSConfig = compProto.Spec.TLSConfig
 	compObjCopy.Spec.Instances = compProto.Spec.Instances
@@ -1574,6 +1577,8 @@ func buildComponentWrapper(transCtx *clusterTransformContext,
 	if err != nil {
 		return nil, err
 	}
+	comp.Spec.MaintenanceWindow = transCtx.maintenanceWindow
+	comp.Spec.DriftPolicy = transCtx.Cluster.Spec.DriftPolicy
 	if err = buildComponentSidecars(transCtx, comp, running); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,87 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// driftObject pairs a live object owned by the cluster with the object the controller would write.
+type driftObject struct {
+	live    client.Object
+	desired client.Object
+}
+
+// clusterDriftTransformer detects the drifts of the objects owned by the cluster directly, and handles them by the drift policy.
+// The drifts of the objects owned by the components are handled by the component controller.
+type clusterDriftTransformer struct{}
+
+var _ graph.Transformer = &clusterDriftTransformer{}
+
+func (t *clusterDriftTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	origCluster := transCtx.OrigCluster
+	if origCluster.IsDeleting() {
+		return nil
+	}
+
+	// the objects are being updated to the new spec, the differences are not drifts.
+	if origCluster.Status.ObservedGeneration != origCluster.Generation {
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	policy := transCtx.Cluster.Spec.DriftPolicy
+	for _, obj := range transCtx.driftObjects {
+		drift, err := intctrlutil.DetectObjectDrift(obj.desired, obj.live)
+		if err != nil {
+			return err
+		}
+		if drift == nil {
+			continue
+		}
+		transCtx.drifts = append(transCtx.drifts, *drift)
+		if policy == appsv1.WarnDriftPolicyType || policy == appsv1.AdoptDriftPolicyType {
+			t.keepLiveObject(graphCli, dag, obj.live)
+		}
+	}
+	return nil
+}
+
+// keepLiveObject drops the update of the live object from the plan.
+func (t *clusterDriftTransformer) keepLiveObject(graphCli model.GraphClient, dag *graph.DAG, live client.Object) {
+	vertex := graphCli.FindMatchedVertex(dag, live)
+	if vertex == nil {
+		return
+	}
+	v, _ := vertex.(*model.ObjectVertex)
+	if v.Action != nil && (*v.Action == model.UPDATE || *v.Action == model.PATCH) {
+		dag.RemoveVertex(vertex)
+	}
+}
+
+func (c *clusterTransformContext) trackDrift(live, desired client.Object) {
+	c.driftObjects = append(c.driftObjects, driftObject{live: live, desired: desired})
+}
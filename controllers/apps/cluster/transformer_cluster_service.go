@@ -72,7 +72,7 @@ func (t *clusterServiceTransformer) Transform(ctx graph.TransformContext, dag *g
 		graphCli.Create(dag, protoServices[svc], inDataContext4G())
 	}
 	for svc := range toUpdateServices {
-		t.updateService(dag, graphCli, services[svc], protoServices[svc])
+		t.updateService(transCtx, dag, graphCli, services[svc], protoServices[svc])
 	}
 	for svc := range toDeleteServices {
 		graphCli.Delete(dag, services[svc], inDataContext4G())
@@ -221,12 +221,14 @@ func (t *clusterServiceTransformer) listOwnedClusterServices(transCtx *clusterTr
 	return services, nil
 }
 
-func (t *clusterServiceTransformer) updateService(dag *graph.DAG, graphCli model.GraphClient, running, proto *corev1.Service) {
+func (t *clusterServiceTransformer) updateService(transCtx *clusterTransformContext, dag *graph.DAG,
+	graphCli model.GraphClient, running, proto *corev1.Service) {
 	newSvc := running.DeepCopy()
 	newSvc.Spec = proto.Spec
 	ctrlutil.MergeMetadataMapInplace(proto.Labels, &newSvc.Labels)
 	ctrlutil.MergeMetadataMapInplace(proto.Annotations, &newSvc.Annotations)
 	appsutil.ResolveServiceDefaultFields(&running.Spec, &newSvc.Spec)
+	transCtx.trackDrift(running, newSvc)
 
 	if !reflect.DeepEqual(running, newSvc) {
 		graphCli.Update(dag, running, newSvc, inDataContext4G())
@@ -23,8 +23,10 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +35,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 type clusterStatusTransformer struct{}
@@ -49,7 +52,7 @@ func (t *clusterStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	if err := t.reconcileClusterStatus(transCtx.Context, transCtx.Client, cluster); err != nil {
 		return err
 	}
-	return nil
+	return t.setDriftedCondition(transCtx, cluster)
 }
 
 func (t *clusterStatusTransformer) markClusterDagStatusAction(graphCli model.GraphClient, dag *graph.DAG, origCluster, cluster *appsv1.Cluster) {
@@ -188,9 +191,78 @@ func (t *clusterStatusTransformer) syncClusterConditions(ctx context.Context, cl
 	if err := setAvailableCondition(); err != nil {
 		return err
 	}
+	if err := t.setUpdateDeferredCondition(ctx, cli, cluster); err != nil {
+		return err
+	}
 	return t.setRestoreCondition(ctx, cli, cluster)
 }
 
+// setUpdateDeferredCondition aggregates the components whose updates are deferred by the maintenance window.
+func (t *clusterStatusTransformer) setUpdateDeferredCondition(ctx context.Context, cli client.Reader, cluster *appsv1.Cluster) error {
+	componentList := &appsv1.ComponentList{}
+	if err := cli.List(ctx, componentList, client.InNamespace(cluster.Namespace), client.MatchingLabels(constant.GetClusterLabels(cluster.Name))); err != nil {
+		return err
+	}
+	var messages []string
+	for _, comp := range componentList.Items {
+		cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeUpdateDeferred)
+		// the adopted drifts are reported with the False status
+		if cond != nil && (cond.Status == metav1.ConditionTrue || cond.Reason == intctrlutil.ReasonDriftAdopted) {
+			messages = append(messages, fmt.Sprintf("component %s: %s", comp.Name, cond.Message))
+		}
+	}
+	if len(messages) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, appsv1.ConditionTypeUpdateDeferred)
+		return nil
+	}
+	slices.Sort(messages)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    appsv1.ConditionTypeUpdateDeferred,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonUpdateDeferred,
+		Message: strings.Join(messages, "; "),
+	})
+	return nil
+}
+
+// setDriftedCondition aggregates the drifts of the objects owned by the cluster and its components.
+func (t *clusterStatusTransformer) setDriftedCondition(transCtx *clusterTransformContext, cluster *appsv1.Cluster) error {
+	componentList := &appsv1.ComponentList{}
+	if err := transCtx.Client.List(transCtx.Context, componentList, client.InNamespace(cluster.Namespace), client.MatchingLabels(constant.GetClusterLabels(cluster.Name))); err != nil {
+		return err
+	}
+	var messages []string
+	if len(transCtx.drifts) > 0 {
+		messages = append(messages, intctrlutil.FormatObjectDrifts(transCtx.drifts))
+	}
+	for _, comp := range componentList.Items {
+		cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeDrifted)
+		// the adopted drifts are reported with the False status
+		if cond != nil && (cond.Status == metav1.ConditionTrue || cond.Reason == intctrlutil.ReasonDriftAdopted) {
+			messages = append(messages, fmt.Sprintf("component %s: %s", comp.Name, cond.Message))
+		}
+	}
+	if len(messages) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, appsv1.ConditionTypeDrifted)
+		return nil
+	}
+	slices.Sort(messages)
+	cond := metav1.Condition{
+		Type:    appsv1.ConditionTypeDrifted,
+		Status:  intctrlutil.DriftConditionStatus(cluster.Spec.DriftPolicy),
+		Reason:  intctrlutil.DriftConditionReason(cluster.Spec.DriftPolicy),
+		Message: strings.Join(messages, "; "),
+	}
+	if meta.SetStatusCondition(&cluster.Status.Conditions, cond) && len(transCtx.drifts) > 0 && transCtx.EventRecorder != nil {
+		eventType := corev1.EventTypeWarning
+		if cluster.Spec.DriftPolicy == appsv1.AdoptDriftPolicyType {
+			eventType = corev1.EventTypeNormal
+		}
+		transCtx.EventRecorder.Event(cluster, eventType, cond.Reason, intctrlutil.FormatObjectDrifts(transCtx.drifts))
+	}
+	return nil
+}
+
 func (t *clusterStatusTransformer) setRestoreCondition(ctx context.Context, cli client.Reader, cluster *appsv1.Cluster) error {
 	if cluster.Spec.Restore == nil {
 		return nil
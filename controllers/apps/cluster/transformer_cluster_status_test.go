@@ -32,6 +32,7 @@ import (
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 var _ = Describe("syncClusterConditions", func() {
@@ -227,3 +228,103 @@ var _ = Describe("syncClusterConditions", func() {
 		Expect(availCond.Status).Should(Equal(metav1.ConditionFalse))
 	})
 })
+
+var _ = Describe("setDriftedCondition", func() {
+	const (
+		clusterName = "test-cluster"
+		namespace   = "default"
+	)
+
+	var (
+		transformer clusterStatusTransformer
+		cluster     *appsv1.Cluster
+		reader      *appsutil.MockReader
+		transCtx    *clusterTransformContext
+	)
+
+	newComponent := func(name string, drifted *metav1.Condition) *appsv1.Component {
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      clusterName + "-" + name,
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey: constant.AppName,
+					constant.AppInstanceLabelKey:  clusterName,
+				},
+			},
+		}
+		if drifted != nil {
+			comp.Status.Conditions = []metav1.Condition{*drifted}
+		}
+		return comp
+	}
+
+	BeforeEach(func() {
+		cluster = &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: namespace,
+			},
+		}
+		reader = &appsutil.MockReader{Objects: []client.Object{}}
+		transCtx = &clusterTransformContext{
+			Context: context.Background(),
+			Client:  reader,
+			Cluster: cluster,
+		}
+	})
+
+	It("should aggregate the drifts of the cluster and components", func() {
+		cluster.Spec.DriftPolicy = appsv1.WarnDriftPolicyType
+		reader.Objects = []client.Object{
+			newComponent("comp1", &metav1.Condition{
+				Type:    appsv1.ConditionTypeDrifted,
+				Status:  metav1.ConditionTrue,
+				Reason:  intctrlutil.ReasonDriftDetected,
+				Message: "Service/test-cluster-comp1: spec.type",
+			}),
+			newComponent("comp2", nil),
+		}
+		transCtx.drifts = []intctrlutil.ObjectDrift{{Kind: "Service", Name: "test-cluster-mysql", Paths: []string{"spec.selector.app"}}}
+
+		Expect(transformer.setDriftedCondition(transCtx, cluster)).Should(Succeed())
+
+		cond := meta.FindStatusCondition(cluster.Status.Conditions, appsv1.ConditionTypeDrifted)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(intctrlutil.ReasonDriftDetected))
+		Expect(cond.Message).Should(Equal("Service/test-cluster-mysql: spec.selector.app; component test-cluster-comp1: Service/test-cluster-comp1: spec.type"))
+	})
+
+	It("should report the adopted drifts with the False status", func() {
+		cluster.Spec.DriftPolicy = appsv1.AdoptDriftPolicyType
+		reader.Objects = []client.Object{
+			newComponent("comp1", &metav1.Condition{
+				Type:    appsv1.ConditionTypeDrifted,
+				Status:  metav1.ConditionFalse,
+				Reason:  intctrlutil.ReasonDriftAdopted,
+				Message: "Service/test-cluster-comp1: spec.type",
+			}),
+		}
+
+		Expect(transformer.setDriftedCondition(transCtx, cluster)).Should(Succeed())
+
+		cond := meta.FindStatusCondition(cluster.Status.Conditions, appsv1.ConditionTypeDrifted)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(intctrlutil.ReasonDriftAdopted))
+		Expect(cond.Message).Should(Equal("component test-cluster-comp1: Service/test-cluster-comp1: spec.type"))
+	})
+
+	It("should remove the condition when there is no drift", func() {
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type:   appsv1.ConditionTypeDrifted,
+			Status: metav1.ConditionTrue,
+			Reason: intctrlutil.ReasonDriftReverted,
+		}}
+		reader.Objects = []client.Object{newComponent("comp1", nil)}
+
+		Expect(transformer.setDriftedCondition(transCtx, cluster)).Should(Succeed())
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, appsv1.ConditionTypeDrifted)).Should(BeNil())
+	})
+})
@@ -22,12 +22,15 @@ package cluster
 import (
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -69,6 +72,10 @@ func (t *clusterValidationTransformer) Transform(ctx graph.TransformContext, dag
 			return intctrlutil.NewRequeueError(appsutil.RequeueDuration, err.Error())
 		}
 	}
+
+	if err = loadNCheckMaintenanceWindow(transCtx, cluster); err != nil {
+		return intctrlutil.NewRequeueError(appsutil.RequeueDuration, err.Error())
+	}
 	return nil
 }
 
@@ -188,3 +195,23 @@ func clusterCompCntWithFunc(cluster *appsv1.Cluster, match func(comp appsv1.Clus
 	}
 	return cnt
 }
+
+func loadNCheckMaintenanceWindow(transCtx *clusterTransformContext, cluster *appsv1.Cluster) error {
+	window, err := intctrlutil.GetMaintenanceWindow(transCtx.Context, transCtx.Client, cluster)
+	if err != nil {
+		if !errors.Is(err, intctrlutil.ErrInvalidNamespaceMaintenanceWindow) {
+			return err
+		}
+		// don't block all the clusters in the namespace, the changes are not limited by the window until it is fixed.
+		transCtx.EventRecorder.Event(cluster, corev1.EventTypeWarning, constant.ReasonInvalidMaintenanceWindow, err.Error())
+		window = nil
+	}
+	if _, _, err = intctrlutil.CheckMaintenanceWindow(window, time.Now()); err != nil {
+		return err
+	}
+	if intctrlutil.IsMaintenanceWindowBypassed(cluster) {
+		window = nil
+	}
+	transCtx.maintenanceWindow = window
+	return nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -51,6 +52,9 @@ type componentTransformContext struct {
 	SynthesizeComponent *component.SynthesizedComponent
 	RunningWorkload     *workloads.InstanceSet
 	ProtoWorkload       *workloads.InstanceSet
+
+	// the owned objects to be checked for drifts
+	driftObjects []driftObject
 }
 
 func (c *componentTransformContext) GetContext() context.Context {
@@ -82,6 +86,8 @@ type componentPlan struct {
 	dag      *graph.DAG
 	walkFunc graph.WalkFunc
 	transCtx *componentTransformContext
+	// the number of the pending invocations of lifecycle actions saved by the plan
+	invocations int
 }
 
 var _ graph.TransformContext = &componentTransformContext{}
@@ -115,19 +121,63 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
 
 	plan := &componentPlan{
-		dag:      dag,
-		walkFunc: c.defaultWalkFuncWithLogging,
-		transCtx: c.transCtx,
+		dag:         dag,
+		walkFunc:    c.defaultWalkFuncWithLogging,
+		transCtx:    c.transCtx,
+		invocations: c.saveActionInvocations(dag),
 	}
 	return plan, err
 }
 
+// saveActionInvocations records the pending invocations of lifecycle actions into the status updates of the component,
+// and returns the number of invocations saved. The invocations are dropped if the component is being deleted.
+func (c *componentPlanBuilder) saveActionInvocations(dag *graph.DAG) int {
+	comp := c.transCtx.Component
+	if comp == nil || dag.Root() == nil {
+		return 0
+	}
+	invocations := lifecycle.PendingInvocations(comp.Namespace, comp.Name)
+	if len(invocations) == 0 {
+		return 0
+	}
+	saved := false
+	objs := map[*appsv1.Component]bool{}
+	for _, v := range dag.Vertices() {
+		vertex, ok := v.(*model.ObjectVertex)
+		if !ok || vertex.Action == nil {
+			continue
+		}
+		obj, ok := vertex.Obj.(*appsv1.Component)
+		if !ok || obj.Namespace != comp.Namespace || obj.Name != comp.Name || objs[obj] {
+			continue
+		}
+		switch *vertex.Action {
+		case model.DELETE:
+			return len(invocations)
+		case model.STATUS:
+			for _, invocation := range invocations {
+				recordActionInvocation(obj, invocation)
+			}
+			objs[obj] = true
+			saved = true
+		}
+	}
+	if !saved {
+		return 0 // keep them for the following reconciliation
+	}
+	return len(invocations)
+}
+
 func (p *componentPlan) Execute() error {
 	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
 	if err != nil {
 		p.transCtx.Logger.Info(fmt.Sprintf("execute error: %s", err.Error()))
+		return err
 	}
-	return err
+	if p.invocations > 0 {
+		lifecycle.ForgetInvocations(p.transCtx.Component.Namespace, p.transCtx.Component.Name, p.invocations)
+	}
+	return nil
 }
 
 // newComponentPlanBuilder returns a componentPlanBuilder powered PlanBuilder
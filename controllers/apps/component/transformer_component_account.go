@@ -150,6 +150,10 @@ func (t *componentAccountTransformer) updateAccount(transCtx *componentTransform
 	}
 	ctrlutil.MergeMetadataMapInplace(secret.Labels, &runningCopy.Labels)
 	ctrlutil.MergeMetadataMapInplace(secret.Annotations, &runningCopy.Annotations)
+	if account.SecretRef == nil {
+		// the password synced from the external secret is not rendered from the spec
+		transCtx.trackDrift(running, runningCopy)
+	}
 	if !reflect.DeepEqual(running, runningCopy) {
 		graphCli.Update(dag, running, runningCopy)
 	}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -202,6 +203,7 @@ func compOwnedKinds() []client.ObjectList {
 		&corev1.ServiceList{},
 		&corev1.SecretList{},
 		&corev1.ConfigMapList{},
+		&policyv1.PodDisruptionBudgetList{},
 		&corev1.ServiceAccountList{},
 		&rbacv1.RoleList{},
 		&rbacv1.RoleBindingList{},
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// driftObject pairs a live object owned by the component with the object the controller would write.
+type driftObject struct {
+	live    client.Object
+	desired client.Object
+}
+
+// componentDriftTransformer detects the drifts of the objects owned by the component, and handles them by the drift policy.
+type componentDriftTransformer struct{}
+
+var _ graph.Transformer = &componentDriftTransformer{}
+
+func (t *componentDriftTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	// the workload is the last one to be updated, so that the objects can be regarded as rendered from
+	// the current spec once the generation of the workload is updated.
+	if transCtx.RunningWorkload != nil {
+		for _, obj := range transCtx.driftObjects {
+			if _, ok := obj.live.(*workloads.InstanceSet); !ok {
+				graphCli.DependOn(dag, transCtx.RunningWorkload, obj.live)
+			}
+		}
+	}
+
+	// the objects are being updated to the new spec, the differences are not drifts.
+	if !isCompSpecApplied(transCtx) {
+		return nil
+	}
+
+	policy := transCtx.Component.Spec.DriftPolicy
+	drifts := make([]intctrlutil.ObjectDrift, 0)
+	for _, obj := range transCtx.driftObjects {
+		drift, err := intctrlutil.DetectObjectDrift(obj.desired, obj.live)
+		if err != nil {
+			return err
+		}
+		if drift == nil {
+			continue
+		}
+		drifts = append(drifts, *drift)
+		if policy == appsv1.WarnDriftPolicyType || policy == appsv1.AdoptDriftPolicyType {
+			t.keepLiveObject(graphCli, dag, obj.live)
+		}
+	}
+	t.reconcileDriftedCondition(transCtx, policy, drifts)
+	return nil
+}
+
+// keepLiveObject drops the update of the live object from the plan.
+func (t *componentDriftTransformer) keepLiveObject(graphCli model.GraphClient, dag *graph.DAG, live client.Object) {
+	vertex := graphCli.FindMatchedVertex(dag, live)
+	if vertex == nil {
+		return
+	}
+	v, _ := vertex.(*model.ObjectVertex)
+	if v.Action != nil && (*v.Action == model.UPDATE || *v.Action == model.PATCH) {
+		dag.RemoveVertex(vertex)
+	}
+}
+
+func (t *componentDriftTransformer) reconcileDriftedCondition(transCtx *componentTransformContext,
+	policy appsv1.DriftPolicyType, drifts []intctrlutil.ObjectDrift) {
+	comp := transCtx.Component
+	if len(drifts) == 0 {
+		meta.RemoveStatusCondition(&comp.Status.Conditions, appsv1.ConditionTypeDrifted)
+		return
+	}
+	cond := metav1.Condition{
+		Type:               appsv1.ConditionTypeDrifted,
+		Status:             intctrlutil.DriftConditionStatus(policy),
+		ObservedGeneration: comp.Generation,
+		Reason:             intctrlutil.DriftConditionReason(policy),
+		Message:            intctrlutil.FormatObjectDrifts(drifts),
+	}
+	if meta.SetStatusCondition(&comp.Status.Conditions, cond) && transCtx.EventRecorder != nil {
+		eventType := corev1.EventTypeWarning
+		if policy == appsv1.AdoptDriftPolicyType {
+			eventType = corev1.EventTypeNormal
+		}
+		transCtx.EventRecorder.Event(comp, eventType, cond.Reason, cond.Message)
+	}
+}
+
+// isCompSpecApplied checks whether the objects of the component have been rendered from the current spec.
+func isCompSpecApplied(transCtx *componentTransformContext) bool {
+	its := transCtx.RunningWorkload
+	if its == nil {
+		return false
+	}
+	return its.Annotations[constant.KubeBlocksGenerationKey] == strconv.FormatInt(transCtx.Component.Generation, 10)
+}
+
+func (c *componentTransformContext) trackDrift(live, desired client.Object) {
+	c.driftObjects = append(c.driftObjects, driftObject{live: live, desired: desired})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("component drift transformer test", func() {
+	const (
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+
+	var (
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+		live     *corev1.Service
+		desired  *corev1.Service
+	)
+
+	BeforeEach(func() {
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  testCtx.DefaultNamespace,
+				Name:       constant.GenerateClusterComponentName(clusterName, compName),
+				Generation: 2,
+			},
+		}
+		its := &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testCtx.DefaultNamespace,
+				Name:        comp.Name,
+				Annotations: map[string]string{constant.KubeBlocksGenerationKey: "2"},
+			},
+		}
+		desired = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      comp.Name,
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306}},
+			},
+		}
+		live = desired.DeepCopy()
+		live.Spec.Ports[0].Port = 3307
+
+		graphCli := model.NewGraphClient(&appsutil.MockReader{})
+		dag = graph.NewDAG()
+		graphCli.Root(dag, comp, comp, model.ActionStatusPtr())
+		graphCli.Update(dag, live, desired)
+
+		transCtx = &componentTransformContext{
+			Context:         ctx,
+			Client:          graphCli,
+			Logger:          logger,
+			Component:       comp,
+			ComponentOrig:   comp.DeepCopy(),
+			RunningWorkload: its,
+		}
+		transCtx.trackDrift(live, desired)
+	})
+
+	driftedCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(transCtx.Component.Status.Conditions, appsv1.ConditionTypeDrifted)
+	}
+
+	It("reverts the drifts by default", func() {
+		transformer := &componentDriftTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		Expect(graphCli.IsAction(dag, live, model.ActionUpdatePtr())).Should(BeTrue())
+		cond := driftedCondition()
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(intctrlutil.ReasonDriftReverted))
+		Expect(cond.Message).Should(Equal("Service/" + live.Name + ": spec.ports[0].port"))
+	})
+
+	It("keeps the drifts with the Warn policy", func() {
+		transCtx.Component.Spec.DriftPolicy = appsv1.WarnDriftPolicyType
+		transformer := &componentDriftTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		Expect(graphCli.FindMatchedVertex(dag, live)).Should(BeNil())
+		cond := driftedCondition()
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Reason).Should(Equal(intctrlutil.ReasonDriftDetected))
+	})
+
+	It("keeps the drifts with the Adopt policy", func() {
+		transCtx.Component.Spec.DriftPolicy = appsv1.AdoptDriftPolicyType
+		transformer := &componentDriftTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		Expect(graphCli.FindMatchedVertex(dag, live)).Should(BeNil())
+		cond := driftedCondition()
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(intctrlutil.ReasonDriftAdopted))
+	})
+
+	It("updates the objects to the new spec", func() {
+		transCtx.Component.Spec.DriftPolicy = appsv1.WarnDriftPolicyType
+		transCtx.Component.Generation = 3
+		transformer := &componentDriftTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		Expect(graphCli.IsAction(dag, live, model.ActionUpdatePtr())).Should(BeTrue())
+		Expect(driftedCondition()).Should(BeNil())
+	})
+
+	It("removes the condition if there is no drift", func() {
+		transCtx.Component.Status.Conditions = []metav1.Condition{{
+			Type:   appsv1.ConditionTypeDrifted,
+			Status: metav1.ConditionTrue,
+			Reason: intctrlutil.ReasonDriftDetected,
+		}}
+		transCtx.driftObjects = nil
+		transCtx.trackDrift(desired.DeepCopy(), desired)
+		transformer := &componentDriftTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+		Expect(driftedCondition()).Should(BeNil())
+	})
+})
@@ -49,9 +49,7 @@ func (t *componentHostNetworkTransformer) Transform(ctx graph.TransformContext,
 	}
 
 	comp := transCtx.Component
-	t.updateObjectsWithAllocatedPorts(synthesizedComp, comp, ports)
-
-	return nil
+	return t.updateObjectsWithAllocatedPorts(synthesizedComp, comp, ports)
 }
 
 func (t *componentHostNetworkTransformer) allocateHostPorts(synthesizedComp *component.SynthesizedComponent) (map[string]map[string]int32, error) {
@@ -105,7 +103,7 @@ func (t *componentHostNetworkTransformer) allocateHostPortsWithFunc(pm intctrlut
 }
 
 func (t *componentHostNetworkTransformer) updateObjectsWithAllocatedPorts(synthesizedComp *component.SynthesizedComponent,
-	comp *appsv1.Component, ports map[string]map[string]int32) {
+	comp *appsv1.Component, ports map[string]map[string]int32) error {
 	synthesizedComp.PodSpec.HostNetwork = true
 	if comp.Spec.Network != nil && comp.Spec.Network.DNSPolicy != nil {
 		synthesizedComp.PodSpec.DNSPolicy = *comp.Spec.Network.DNSPolicy
@@ -122,5 +120,5 @@ func (t *componentHostNetworkTransformer) updateObjectsWithAllocatedPorts(synthe
 			}
 		}
 	}
-	component.UpdateKBAgentContainer4HostNetwork(synthesizedComp)
+	return component.UpdateKBAgentContainer4HostNetwork(synthesizedComp)
 }
@@ -0,0 +1,154 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbautil "github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+// componentKBAgentAuthTransformer handles the credential used to secure the kb-agent servers.
+type componentKBAgentAuthTransformer struct{}
+
+var _ graph.Transformer = &componentKBAgentAuthTransformer{}
+
+func (t *componentKBAgentAuthTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	if !t.enabled(synthesizedComp) {
+		return nil
+	}
+
+	secretObj, err := t.secretObject(transCtx, synthesizedComp)
+	if err != nil {
+		return err
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if secretObj == nil {
+		secret, err := t.newSecret(transCtx, synthesizedComp)
+		if err != nil {
+			return err
+		}
+		graphCli.Create(dag, secret)
+	} else {
+		// the token is generated once, the certificate is renewed before it expires
+		proto, err := t.newSecretProto(transCtx, synthesizedComp)
+		if err != nil {
+			return err
+		}
+		secretCopy := secretObj.DeepCopy()
+		secretCopy.Labels = proto.Labels
+		secretCopy.Annotations = proto.Annotations
+		renewed, err := kbautil.RenewCredential(kbautil.CredentialFromData(secretObj.Data), synthesizedComp.FullCompName, time.Now())
+		if err != nil {
+			return err
+		}
+		if renewed != nil {
+			secretCopy.Data = renewed.Data()
+		}
+		transCtx.trackDrift(secretObj, secretCopy)
+		if !reflect.DeepEqual(secretObj, secretCopy) {
+			graphCli.Update(dag, secretObj, secretCopy)
+		}
+	}
+
+	component.AddInstanceAssistantObject(synthesizedComp, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: synthesizedComp.Namespace,
+			Name:      kbagent.AuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+		},
+	})
+	return nil
+}
+
+// enabled checks whether the kb-agent auth secret is mounted, which is decided when building the kb-agent containers.
+func (t *componentKBAgentAuthTransformer) enabled(synthesizedComp *component.SynthesizedComponent) bool {
+	if synthesizedComp.PodSpec == nil {
+		return false
+	}
+	return slices.ContainsFunc(synthesizedComp.PodSpec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == kbagent.AuthVolumeName
+	})
+}
+
+func (t *componentKBAgentAuthTransformer) secretObject(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
+	secretKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      kbagent.AuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	secret := &corev1.Secret{}
+	err := transCtx.Client.Get(transCtx.Context, secretKey, secret)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return secret, nil
+}
+
+func (t *componentKBAgentAuthTransformer) newSecret(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
+	secret, err := t.newSecretProto(transCtx, synthesizedComp)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := kbautil.GenerateCredential(synthesizedComp.FullCompName)
+	if err != nil {
+		return nil, err
+	}
+	secret.Data = cred.Data()
+	return secret, nil
+}
+
+func (t *componentKBAgentAuthTransformer) newSecretProto(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
+	secretName := kbagent.AuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	secret := builder.NewSecretBuilder(synthesizedComp.Namespace, secretName).
+		// priority: static < dynamic < built-in
+		AddLabelsInMap(synthesizedComp.StaticLabels).
+		AddLabelsInMap(synthesizedComp.DynamicLabels).
+		AddLabelsInMap(constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+		AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+		AddAnnotationsInMap(synthesizedComp.DynamicAnnotations).
+		SetData(map[string][]byte{}).
+		GetObject()
+	if err := setCompOwnershipNFinalizer(transCtx.Component, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
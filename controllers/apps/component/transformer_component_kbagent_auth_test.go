@@ -0,0 +1,180 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbautil "github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+var _ = Describe("kb-agent auth transformer test", func() {
+	const (
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+
+	var (
+		reader   *appsutil.MockReader
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+
+		authVolume = corev1.Volume{
+			Name: kbagent.AuthVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: kbagent.AuthSecretName(clusterName, compName),
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		reader = &appsutil.MockReader{}
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+				},
+			},
+			Spec: appsv1.ComponentSpec{},
+		}
+
+		graphCli := model.NewGraphClient(reader)
+		dag = graph.NewDAG()
+		graphCli.Root(dag, comp, comp, model.ActionStatusPtr())
+
+		transCtx = &componentTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: nil,
+			Logger:        logger,
+			CompDef:       &appsv1.ComponentDefinition{},
+			Component:     comp,
+			ComponentOrig: comp.DeepCopy(),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				Namespace:    testCtx.DefaultNamespace,
+				ClusterName:  clusterName,
+				Name:         compName,
+				FullCompName: comp.Name,
+				PodSpec: &corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("disabled", func() {
+		transformer := &componentKBAgentAuthTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		Expect(graphCli.FindAll(dag, &corev1.Secret{})).Should(BeEmpty())
+	})
+
+	It("provision", func() {
+		transCtx.SynthesizeComponent.PodSpec.Volumes = append(transCtx.SynthesizeComponent.PodSpec.Volumes, authVolume)
+
+		transformer := &componentKBAgentAuthTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		objs := graphCli.FindAll(dag, &corev1.Secret{})
+		Expect(objs).Should(HaveLen(1))
+		Expect(graphCli.IsAction(dag, objs[0], model.ActionCreatePtr())).Should(BeTrue())
+		secret := objs[0].(*corev1.Secret)
+		Expect(secret.GetName()).Should(Equal(kbagent.AuthSecretName(clusterName, compName)))
+		cred := kbautil.CredentialFromData(secret.Data)
+		Expect(cred.TokenEnabled()).Should(BeTrue())
+		Expect(cred.TLSEnabled()).Should(BeTrue())
+	})
+
+	It("keeps the existing credential", func() {
+		transCtx.SynthesizeComponent.PodSpec.Volumes = append(transCtx.SynthesizeComponent.PodSpec.Volumes, authVolume)
+		cred, err := kbautil.GenerateCredential(transCtx.SynthesizeComponent.FullCompName)
+		Expect(err).Should(BeNil())
+		reader.Objects = []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      kbagent.AuthSecretName(clusterName, compName),
+					Labels:    constant.GetCompLabels(clusterName, compName),
+				},
+				Data: cred.Data(),
+			},
+		}
+
+		transformer := &componentKBAgentAuthTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		for _, obj := range graphCli.FindAll(dag, &corev1.Secret{}) {
+			Expect(obj.(*corev1.Secret).Data).Should(Equal(cred.Data()))
+			Expect(graphCli.IsAction(dag, obj, model.ActionCreatePtr())).Should(BeFalse())
+		}
+	})
+
+	It("renews the certificate", func() {
+		transCtx.SynthesizeComponent.PodSpec.Volumes = append(transCtx.SynthesizeComponent.PodSpec.Volumes, authVolume)
+		reader.Objects = []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      kbagent.AuthSecretName(clusterName, compName),
+					Labels:    constant.GetCompLabels(clusterName, compName),
+				},
+				// the CA key is not kept
+				Data: map[string][]byte{kbautil.AuthTokenKey: []byte("token")},
+			},
+		}
+
+		transformer := &componentKBAgentAuthTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		graphCli := transCtx.Client.(model.GraphClient)
+		objs := graphCli.FindAll(dag, &corev1.Secret{})
+		Expect(objs).Should(HaveLen(1))
+		Expect(graphCli.IsAction(dag, objs[0], model.ActionUpdatePtr())).Should(BeTrue())
+		secret := objs[0].(*corev1.Secret)
+		Expect(secret.Data).Should(HaveKeyWithValue(kbautil.AuthTokenKey, []byte("token")))
+		Expect(secret.Data).Should(HaveKey(kbautil.AuthCAKeyKey))
+		Expect(kbautil.CredentialFromData(secret.Data).TLSEnabled()).Should(BeTrue())
+	})
+})
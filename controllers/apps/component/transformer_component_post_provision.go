@@ -27,7 +27,6 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
-	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -55,6 +54,8 @@ func (t *componentPostProvisionTransformer) Transform(ctx graph.TransformContext
 		return nil
 	}
 	err := t.postProvision(transCtx)
+	comp := transCtx.Component
+	setLifecycleActionFailedCondition(&comp.Status.Conditions, comp.Generation, "postProvision", lifecycle.IgnoreNotDefined(err))
 	if err != nil {
 		err = lifecycle.IgnoreNotDefined(err)
 		if errors.Is(err, lifecycle.ErrPreconditionFailed) {
@@ -80,8 +81,7 @@ func (t *componentPostProvisionTransformer) markPostProvisionDone(transCtx *comp
 	timeStr := time.Now().Format(time.RFC3339Nano)
 	comp.Annotations[kbCompPostProvisionDoneKey] = timeStr
 
-	graphCli, _ := transCtx.Client.(model.GraphClient)
-	graphCli.Update(dag, compObj, comp, &model.ReplaceIfExistingOption{})
+	patchCompMetadata(transCtx, dag, compObj)
 	return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "requeue to waiting for post-provision annotation to be set")
 }
 
@@ -151,11 +151,33 @@ var _ = Describe("post-provision transformer test", func() {
 			})
 
 			It("ok", func() {
+				comp.Status.Conditions = []metav1.Condition{
+					{
+						Type:   appsv1.ComponentConditionLifecycleActionFailed,
+						Status: metav1.ConditionTrue,
+						Reason: "postProvision",
+					},
+				}
+
 				transformer := &componentPostProvisionTransformer{}
 				err := transformer.Transform(transCtx, dag)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).Should(ContainSubstring("requeue to waiting for post-provision annotation to be set"))
 				Expect(postProvisionCompleted).Should(BeTrue())
+
+				By("check the annotation is patched and the status is kept")
+				root := dag.Root().(*model.ObjectVertex)
+				Expect(*root.Action).Should(Equal(model.PATCH))
+				Expect(root.Obj.GetAnnotations()).Should(HaveKey(kbCompPostProvisionDoneKey))
+				Expect(root.OriObj.GetAnnotations()).ShouldNot(HaveKey(kbCompPostProvisionDoneKey))
+				var status *model.ObjectVertex
+				for _, v := range dag.Vertices() {
+					if ov := v.(*model.ObjectVertex); ov != root && *ov.Action == model.STATUS {
+						status = ov
+					}
+				}
+				Expect(status).ShouldNot(BeNil())
+				Expect(status.Obj.(*appsv1.Component).Status.Conditions).Should(BeEmpty())
 			})
 
 			It("fails when precondition not met", func() {
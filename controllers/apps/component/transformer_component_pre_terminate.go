@@ -82,7 +82,9 @@ func (t *componentPreTerminateTransformer) Transform(ctx graph.TransformContext,
 	if t.checkPreTerminateDone(transCtx, dag) {
 		return nil
 	}
-	if err = t.preTerminate(transCtx, compDef); err != nil {
+	err = t.preTerminate(transCtx, compDef)
+	setLifecycleActionFailedCondition(&comp.Status.Conditions, comp.Generation, "preTerminate", lifecycle.IgnoreNotDefined(err))
+	if err != nil {
 		return lifecycle.IgnoreNotDefined(err)
 	}
 	return t.markPreTerminateDone(transCtx, dag)
@@ -304,6 +304,18 @@ func (t *componentServiceTransformer) createOrUpdateService(ctx graph.TransformC
 		newSvc := originSvc.DeepCopy()
 		intctrlutil.MergeMetadataMapInplace(service.Labels, &newSvc.Labels)
 		intctrlutil.MergeMetadataMapInplace(service.Annotations, &newSvc.Annotations)
+		if transCtx, ok := ctx.(*componentTransformContext); ok {
+			// compare with the rendered spec, the fields defaulted by the server are taken from the live one
+			desiredSvc := newSvc.DeepCopy()
+			desiredSvc.Spec = *service.Spec.DeepCopy()
+			for i := range desiredSvc.Spec.Ports {
+				if len(desiredSvc.Spec.Ports[i].Protocol) == 0 {
+					desiredSvc.Spec.Ports[i].Protocol = corev1.ProtocolTCP
+				}
+			}
+			appsutil.ResolveServiceDefaultFields(&originSvc.Spec, &desiredSvc.Spec)
+			transCtx.trackDrift(originSvc, desiredSvc)
+		}
 
 		// if skip immutable check, update the service directly
 		if skipImmutableCheckForComponentService(originSvc) {
@@ -28,7 +28,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -251,4 +253,80 @@ var _ = Describe("component service transformer test", func() {
 			Expect(graphCli.IsAction(dag, svc, model.ActionCreatePtr())).Should(BeTrue())
 		})
 	})
+
+	Context("drift", func() {
+		var (
+			live *corev1.Service
+		)
+
+		serviceName := func() string {
+			return constant.GenerateComponentServiceName(clusterName, compName, "default")
+		}
+
+		// reconcile runs the service and drift transformers with the live service
+		reconcile := func() {
+			reader.Objects = []client.Object{live}
+			graphCli := transCtx.Client.(model.GraphClient)
+			dag = newDAG(graphCli, transCtx.Component)
+			transCtx.driftObjects = nil
+			Expect((&componentServiceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			Expect((&componentDriftTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		}
+
+		driftedCondition := func() *metav1.Condition {
+			return meta.FindStatusCondition(transCtx.Component.Status.Conditions, appsv1.ConditionTypeDrifted)
+		}
+
+		BeforeEach(func() {
+			transCtx.Component.Generation = 1
+			transCtx.RunningWorkload.Annotations = map[string]string{constant.KubeBlocksGenerationKey: "1"}
+			transCtx.SynthesizeComponent.ComponentServices[0].Spec.Ports = []corev1.ServicePort{
+				{
+					Name:       "mysql",
+					Port:       3306,
+					TargetPort: intstr.FromString("mysql"),
+				},
+			}
+
+			By("render the service and default the fields as the server does")
+			Expect((&componentServiceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(objs).Should(HaveLen(1))
+			live = objs[0].(*corev1.Service).DeepCopy()
+			Expect(live.Name).Should(Equal(serviceName()))
+			live.Spec.Type = corev1.ServiceTypeClusterIP
+			live.Spec.ClusterIP = "10.96.0.10"
+			live.Spec.ClusterIPs = []string{"10.96.0.10"}
+			live.Spec.SessionAffinity = corev1.ServiceAffinityNone
+			live.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+			live.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicySingleStack)
+			live.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicyCluster)
+			live.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		})
+
+		It("no drift", func() {
+			reconcile()
+			Expect(driftedCondition()).Should(BeNil())
+		})
+
+		It("port drifted", func() {
+			live.Spec.Ports[0].Port = 3307
+			reconcile()
+			cond := driftedCondition()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).Should(Equal("Service/" + serviceName() + ": spec.ports[0].port"))
+		})
+
+		It("port drift adopted", func() {
+			transCtx.Component.Spec.DriftPolicy = appsv1.AdoptDriftPolicyType
+			live.Spec.Ports[0].Port = 3307
+			reconcile()
+			cond := driftedCondition()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(controllerutil.ReasonDriftAdopted))
+		})
+	})
 })
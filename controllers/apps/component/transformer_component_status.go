@@ -354,9 +354,33 @@ func (t *componentStatusTransformer) reconcileStatusCondition(transCtx *componen
 		t.reconcileProgressingCondition(transCtx),
 		t.reconcileHealthyCondition(transCtx),
 		t.reconcileRestoreCondition(transCtx),
+		t.reconcileUpdateDeferredCondition(transCtx),
 	)
 }
 
+// reconcileUpdateDeferredCondition reflects whether the updates of the workload are deferred by the maintenance window.
+func (t *componentStatusTransformer) reconcileUpdateDeferredCondition(transCtx *componentTransformContext) error {
+	var workloadCond *metav1.Condition
+	if t.runningITS != nil {
+		workloadCond = meta.FindStatusCondition(t.runningITS.Status.Conditions, string(workloads.InstanceUpdateDeferred))
+	}
+	if workloadCond == nil || workloadCond.Status != metav1.ConditionTrue {
+		meta.RemoveStatusCondition(&t.comp.Status.Conditions, appsv1.ConditionTypeUpdateDeferred)
+		return nil
+	}
+	cond := metav1.Condition{
+		Type:               appsv1.ConditionTypeUpdateDeferred,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: t.comp.Generation,
+		Reason:             workloadCond.Reason,
+		Message:            workloadCond.Message,
+	}
+	if meta.SetStatusCondition(&t.comp.Status.Conditions, cond) && transCtx.EventRecorder != nil {
+		transCtx.EventRecorder.Event(t.comp, corev1.EventTypeNormal, cond.Reason, cond.Message)
+	}
+	return nil
+}
+
 func (t *componentStatusTransformer) reconcileRestoreCondition(transCtx *componentTransformContext) error {
 	if transCtx.SynthesizeComponent == nil {
 		return nil
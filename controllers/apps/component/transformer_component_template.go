@@ -130,6 +130,7 @@ func (t *componentFileTemplateTransformer) handleTemplateObjectChanges(transCtx
 	}
 	for name := range toUpdate {
 		runningObj, protoObj := runningObjs[name], protoObjs[name]
+		transCtx.trackDrift(runningObj, protoObj)
 		if !reflect.DeepEqual(runningObj.Data, protoObj.Data) ||
 			!reflect.DeepEqual(runningObj.Labels, protoObj.Labels) ||
 			!reflect.DeepEqual(runningObj.Annotations, protoObj.Annotations) {
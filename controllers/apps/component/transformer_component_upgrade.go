@@ -0,0 +1,316 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// kbCompUpgradeKey records the upgrade in progress, it is set once the preUpgrade action succeeds
+	// and removed after the postUpgrade action succeeds.
+	kbCompUpgradeKey = "kubeblocks.io/upgrade"
+
+	// upgradeOperation is the operation that the outputs of the preUpgrade and postUpgrade actions are scoped to.
+	upgradeOperation = "upgrade"
+
+	reasonPreUpgradeFailed   = "PreUpgradeFailed"
+	reasonUpgrading          = "Upgrading"
+	reasonPostUpgradePending = "PostUpgradePending"
+	reasonPostUpgradeFailed  = "PostUpgradeFailed"
+	reasonUpgraded           = "Upgraded"
+)
+
+type componentUpgrade struct {
+	From lifecycle.ComponentVersion `json:"from"`
+	To   lifecycle.ComponentVersion `json:"to"`
+}
+
+// componentPreUpgradeTransformer runs the preUpgrade action before the workload is updated to a new
+// service version or component definition, the workload update is blocked until the action succeeds.
+type componentPreUpgradeTransformer struct{}
+
+var _ graph.Transformer = &componentPreUpgradeTransformer{}
+
+func (t *componentPreUpgradeTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) || !hasUpgradeActions(transCtx) || transCtx.RunningWorkload == nil {
+		return nil
+	}
+
+	from, to := runningCompVersion(transCtx.RunningWorkload), desiredCompVersion(transCtx)
+	if len(from.CompDef) == 0 || from == to {
+		return nil
+	}
+	comp := transCtx.Component
+	upgrade, err := getComponentUpgrade(comp)
+	if err != nil {
+		return err
+	}
+	if upgrade != nil && upgrade.To == to {
+		return nil // the preUpgrade action has been done for the target version
+	}
+
+	err = t.preUpgrade(transCtx, from, to)
+	setLifecycleActionFailedCondition(&comp.Status.Conditions, comp.Generation, "preUpgrade", lifecycle.IgnoreNotDefined(err))
+	if err != nil {
+		err = lifecycle.IgnoreNotDefined(err)
+		setUpgradingCondition(comp, reasonPreUpgradeFailed,
+			fmt.Sprintf("the preUpgrade action from %s to %s failed, the upgrade is blocked: %s", versionString(from), versionString(to), err.Error()))
+		// the delayed requeue error doesn't stop the transformer chain, the workload would be updated then,
+		// so the non-delayed one is used even if the precondition is not met.
+		if errors.Is(err, lifecycle.ErrPreconditionFailed) {
+			err = fmt.Errorf("%w: %w", intctrlutil.NewRequeueError(time.Second*10, "wait for lifecycle action precondition"), err)
+		} else {
+			err = fmt.Errorf("%w: %w", intctrlutil.NewRequeueError(time.Second*5, "pre-upgrade action failed"), err)
+		}
+		return err
+	}
+
+	// keep the original version if the target is changed during the upgrade
+	if upgrade != nil {
+		from = upgrade.From
+	}
+	setUpgradingCondition(comp, reasonUpgrading, fmt.Sprintf("upgrading from %s to %s", versionString(from), versionString(to)))
+	return t.markPreUpgradeDone(transCtx, dag, &componentUpgrade{From: from, To: to})
+}
+
+func (t *componentPreUpgradeTransformer) preUpgrade(transCtx *componentTransformContext, from, to lifecycle.ComponentVersion) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp.LifecycleActions.PreUpgrade == nil {
+		return nil
+	}
+	pods, err := component.ListOwnedInstances(transCtx.Context, transCtx.Client,
+		transCtx.Component, transCtx.RunningWorkload)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return nil // there is no running replica to check, e.g., the component is stopped
+	}
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions.ComponentLifecycleActions, synthesizedComp.TemplateVars, nil, pods)
+	if err != nil {
+		return err
+	}
+	opts := &lifecycle.Options{Outputs: getActionOutputs(transCtx.Component, upgradeOperation)}
+	if err = lfa.PreUpgrade(transCtx.Context, transCtx.Client, opts, from, to); err != nil {
+		return err
+	}
+	setActionOutputs(transCtx.Component, upgradeOperation, opts.Outputs)
+	return nil
+}
+
+func (t *componentPreUpgradeTransformer) markPreUpgradeDone(transCtx *componentTransformContext, dag *graph.DAG, upgrade *componentUpgrade) error {
+	out, err := json.Marshal(upgrade)
+	if err != nil {
+		return err
+	}
+	comp := transCtx.Component
+	compObj := comp.DeepCopy()
+	if comp.Annotations == nil {
+		comp.Annotations = make(map[string]string)
+	}
+	comp.Annotations[kbCompUpgradeKey] = string(out)
+
+	patchCompMetadata(transCtx, dag, compObj)
+	return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "requeue to waiting for upgrade annotation to be set")
+}
+
+// componentPostUpgradeTransformer runs the postUpgrade action after all replicas of the workload
+// have been upgraded and are ready.
+type componentPostUpgradeTransformer struct{}
+
+var _ graph.Transformer = &componentPostUpgradeTransformer{}
+
+func (t *componentPostUpgradeTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) || transCtx.SynthesizeComponent == nil {
+		return nil
+	}
+
+	comp := transCtx.Component
+	upgrade, err := getComponentUpgrade(comp)
+	if err != nil || upgrade == nil {
+		return err
+	}
+	if !t.isWorkloadUpgraded(transCtx, upgrade.To) {
+		return nil
+	}
+
+	pending, err := t.isPostUpgradePending(transCtx)
+	if err != nil {
+		return err
+	}
+	if pending {
+		// the component is stopped or scaled to zero, the postUpgrade action runs once the replicas are ready
+		setUpgradingCondition(comp, reasonPostUpgradePending,
+			fmt.Sprintf("the postUpgrade action from %s to %s is waiting for the replicas to be ready", versionString(upgrade.From), versionString(upgrade.To)))
+		return nil
+	}
+
+	err = t.postUpgrade(transCtx, upgrade)
+	setLifecycleActionFailedCondition(&comp.Status.Conditions, comp.Generation, "postUpgrade", lifecycle.IgnoreNotDefined(err))
+	if err != nil {
+		err = lifecycle.IgnoreNotDefined(err)
+		setUpgradingCondition(comp, reasonPostUpgradeFailed,
+			fmt.Sprintf("the postUpgrade action from %s to %s failed: %s", versionString(upgrade.From), versionString(upgrade.To), err.Error()))
+		if errors.Is(err, lifecycle.ErrPreconditionFailed) {
+			err = fmt.Errorf("%w: %w", intctrlutil.NewDelayedRequeueError(time.Second*10, "wait for lifecycle action precondition"), err)
+		} else {
+			err = fmt.Errorf("%w: %w", intctrlutil.NewRequeueError(time.Second*5, "post-upgrade action failed"), err)
+		}
+		return err
+	}
+
+	meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1.ComponentConditionUpgrading,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: comp.Generation,
+		Reason:             reasonUpgraded,
+		Message:            fmt.Sprintf("upgraded from %s to %s", versionString(upgrade.From), versionString(upgrade.To)),
+	})
+	return t.markPostUpgradeDone(transCtx, dag)
+}
+
+func (t *componentPostUpgradeTransformer) isWorkloadUpgraded(transCtx *componentTransformContext, to lifecycle.ComponentVersion) bool {
+	its := transCtx.RunningWorkload
+	if its == nil || its.Spec.Replicas == nil {
+		return false
+	}
+	if runningCompVersion(its) != to || desiredCompVersion(transCtx) != to {
+		return false
+	}
+	if its.Status.ObservedGeneration != its.Generation || its.Status.UpdatedReplicas != *its.Spec.Replicas {
+		return false
+	}
+	return its.IsInstanceSetReady()
+}
+
+// isPostUpgradePending checks whether the postUpgrade action is defined but there is no replica to run it.
+func (t *componentPostUpgradeTransformer) isPostUpgradePending(transCtx *componentTransformContext) (bool, error) {
+	if !hasPostUpgradeAction(transCtx) {
+		return false, nil
+	}
+	pods, err := component.ListOwnedInstances(transCtx.Context, transCtx.Client,
+		transCtx.Component, transCtx.RunningWorkload)
+	if err != nil {
+		return false, err
+	}
+	return len(pods) == 0, nil
+}
+
+func (t *componentPostUpgradeTransformer) postUpgrade(transCtx *componentTransformContext, upgrade *componentUpgrade) error {
+	if !hasPostUpgradeAction(transCtx) {
+		return nil
+	}
+	synthesizedComp := transCtx.SynthesizeComponent
+	pods, err := component.ListOwnedInstances(transCtx.Context, transCtx.Client,
+		transCtx.Component, transCtx.RunningWorkload)
+	if err != nil {
+		return err
+	}
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions.ComponentLifecycleActions, synthesizedComp.TemplateVars, nil, pods)
+	if err != nil {
+		return err
+	}
+	opts := &lifecycle.Options{Outputs: getActionOutputs(transCtx.Component, upgradeOperation)}
+	return lfa.PostUpgrade(transCtx.Context, transCtx.Client, opts, upgrade.From, upgrade.To)
+}
+
+func (t *componentPostUpgradeTransformer) markPostUpgradeDone(transCtx *componentTransformContext, dag *graph.DAG) error {
+	comp := transCtx.Component
+	setActionOutputs(comp, upgradeOperation, nil)
+	compObj := comp.DeepCopy()
+	delete(comp.Annotations, kbCompUpgradeKey)
+
+	patchCompMetadata(transCtx, dag, compObj)
+	return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "requeue to waiting for upgrade annotation to be removed")
+}
+
+func hasUpgradeActions(transCtx *componentTransformContext) bool {
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp == nil || synthesizedComp.LifecycleActions.ComponentLifecycleActions == nil {
+		return false
+	}
+	actions := synthesizedComp.LifecycleActions.ComponentLifecycleActions
+	return actions.PreUpgrade != nil || actions.PostUpgrade != nil
+}
+
+func hasPostUpgradeAction(transCtx *componentTransformContext) bool {
+	synthesizedComp := transCtx.SynthesizeComponent
+	return synthesizedComp.LifecycleActions.ComponentLifecycleActions != nil &&
+		synthesizedComp.LifecycleActions.PostUpgrade != nil
+}
+
+func runningCompVersion(its *workloads.InstanceSet) lifecycle.ComponentVersion {
+	return lifecycle.ComponentVersion{
+		CompDef:        its.Annotations[constant.AppComponentLabelKey],
+		ServiceVersion: its.Annotations[constant.KBAppServiceVersionKey],
+	}
+}
+
+func desiredCompVersion(transCtx *componentTransformContext) lifecycle.ComponentVersion {
+	return lifecycle.ComponentVersion{
+		CompDef:        transCtx.SynthesizeComponent.CompDefName,
+		ServiceVersion: transCtx.SynthesizeComponent.ServiceVersion,
+	}
+}
+
+func getComponentUpgrade(comp *appsv1.Component) (*componentUpgrade, error) {
+	val, ok := comp.Annotations[kbCompUpgradeKey]
+	if !ok {
+		return nil, nil
+	}
+	upgrade := &componentUpgrade{}
+	if err := json.Unmarshal([]byte(val), upgrade); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the upgrade annotation %s: %w", kbCompUpgradeKey, err)
+	}
+	return upgrade, nil
+}
+
+func setUpgradingCondition(comp *appsv1.Component, reason, message string) {
+	meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1.ComponentConditionUpgrading,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             reason,
+		Message:            intctrlutil.TruncateConditionMessage(message),
+	})
+}
+
+func versionString(v lifecycle.ComponentVersion) string {
+	return fmt.Sprintf("%s(%s)", v.CompDef, v.ServiceVersion)
+}
@@ -0,0 +1,420 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+// workloadUpdateTransformer stands for the workload transformer, it updates the running workload.
+type workloadUpdateTransformer struct{}
+
+func (t *workloadUpdateTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	its := transCtx.RunningWorkload.DeepCopy()
+	its.Annotations[constant.AppComponentLabelKey] = transCtx.SynthesizeComponent.CompDefName
+	its.Annotations[constant.KBAppServiceVersionKey] = transCtx.SynthesizeComponent.ServiceVersion
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Update(dag, transCtx.RunningWorkload, its)
+	return nil
+}
+
+var _ = Describe("upgrade transformer test", func() {
+	const (
+		compDefName = "test-compdef"
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+
+	var (
+		reader   *appsutil.MockReader
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+		compDef  *appsv1.ComponentDefinition
+		comp     *appsv1.Component
+		its      *workloads.InstanceSet
+		called   map[string]int
+		failed   map[string]bool
+		params   map[string]map[string]string
+		outputs  map[string]string
+	)
+
+	newDAG := func(graphCli model.GraphClient, comp *appsv1.Component) *graph.DAG {
+		d := graph.NewDAG()
+		graphCli.Root(d, comp, comp, model.ActionStatusPtr())
+		return d
+	}
+
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      fmt.Sprintf("%s-0", constant.GenerateWorkloadNamePattern(clusterName, compName)),
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+				},
+			},
+		}
+	}
+
+	rootVertex := func() *model.ObjectVertex {
+		return dag.Root().(*model.ObjectVertex)
+	}
+
+	// statusVertex returns the vertex that updates the status of the component besides the root vertex.
+	statusVertex := func() *appsv1.Component {
+		for _, v := range dag.Vertices() {
+			ov := v.(*model.ObjectVertex)
+			if ov != rootVertex() && *ov.Action == model.STATUS {
+				return ov.Obj.(*appsv1.Component)
+			}
+		}
+		return nil
+	}
+
+	itsUpdated := func() bool {
+		for _, v := range dag.Vertices() {
+			if _, ok := v.(*model.ObjectVertex).Obj.(*workloads.InstanceSet); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	BeforeEach(func() {
+		compDef = &appsv1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: compDefName,
+			},
+			Spec: appsv1.ComponentDefinitionSpec{
+				LifecycleActions: &appsv1.ComponentLifecycleActions{
+					PreUpgrade:  testapps.NewLifecycleAction("pre-upgrade"),
+					PostUpgrade: testapps.NewLifecycleAction("post-upgrade"),
+				},
+			},
+		}
+
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+				},
+				Annotations: map[string]string{
+					constant.KBAppClusterUIDKey: string(uuid.NewUUID()),
+				},
+			},
+			Spec: appsv1.ComponentSpec{
+				CompDef:        compDef.Name,
+				ServiceVersion: "2.0",
+				Replicas:       1,
+			},
+		}
+
+		its = &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateWorkloadNamePattern(clusterName, compName),
+				Annotations: map[string]string{
+					constant.AppComponentLabelKey:   compDefName,
+					constant.KBAppServiceVersionKey: "1.0",
+				},
+			},
+			Spec: workloads.InstanceSetSpec{
+				Replicas: ptr.To[int32](1),
+			},
+		}
+
+		reader = &appsutil.MockReader{
+			Objects: []client.Object{compDef, comp, newPod()},
+		}
+
+		graphCli := model.NewGraphClient(reader)
+		dag = newDAG(graphCli, comp)
+		synthesizeComponent, err := component.BuildSynthesizedComponent(ctx, reader, compDef, comp)
+		Expect(err).To(BeNil())
+
+		transCtx = &componentTransformContext{
+			Context:             ctx,
+			Client:              graphCli,
+			EventRecorder:       nil,
+			Logger:              logger,
+			Component:           comp,
+			ComponentOrig:       comp.DeepCopy(),
+			SynthesizeComponent: synthesizeComponent,
+			RunningWorkload:     its,
+		}
+
+		// drop the invocations left by the previous cases
+		lifecycle.ForgetInvocations(comp.Namespace, comp.Name, len(lifecycle.PendingInvocations(comp.Namespace, comp.Name)))
+
+		called = map[string]int{}
+		failed = map[string]bool{}
+		params = map[string]map[string]string{}
+		outputs = map[string]string{}
+		testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				called[req.Action]++
+				params[req.Action] = req.Parameters
+				if failed[req.Action] {
+					return kbagentproto.ActionResponse{
+						Error:   kbagentproto.Error2Type(kbagentproto.ErrFailed),
+						Message: "failed",
+					}, nil
+				}
+				return kbagentproto.ActionResponse{Output: []byte(outputs[req.Action])}, nil
+			}).AnyTimes()
+		})
+	})
+
+	Context("pre-upgrade", func() {
+		It("blocks the workload update while the action fails", func() {
+			failed["preUpgrade"] = true
+
+			chain := graph.TransformerChain{&componentPreUpgradeTransformer{}, &workloadUpdateTransformer{}}
+			err := chain.ApplyTo(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeFalse())
+			Expect(called["preUpgrade"]).Should(Equal(1))
+			Expect(itsUpdated()).Should(BeFalse())
+
+			Expect(*rootVertex().Action).Should(Equal(model.STATUS))
+			cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ComponentConditionUpgrading)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(reasonPreUpgradeFailed))
+			Expect(comp.Annotations).ShouldNot(HaveKey(kbCompUpgradeKey))
+		})
+
+		It("blocks the workload update while the precondition is not met", func() {
+			compDef.Spec.LifecycleActions.PreUpgrade.PreCondition = ptr.To(appsv1.ComponentReadyPreConditionType)
+			synthesizeComponent, err := component.BuildSynthesizedComponent(ctx, reader, compDef, comp)
+			Expect(err).To(BeNil())
+			transCtx.SynthesizeComponent = synthesizeComponent
+
+			chain := graph.TransformerChain{&componentPreUpgradeTransformer{}, &workloadUpdateTransformer{}}
+			err = chain.ApplyTo(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("wait for lifecycle action precondition"))
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeFalse())
+			Expect(called["preUpgrade"]).Should(Equal(0))
+			Expect(itsUpdated()).Should(BeFalse())
+		})
+
+		It("ok", func() {
+			comp.Status.Conditions = []metav1.Condition{
+				{
+					Type:   appsv1.ComponentConditionLifecycleActionFailed,
+					Status: metav1.ConditionTrue,
+					Reason: "preUpgrade",
+				},
+			}
+
+			transformer := &componentPreUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("requeue to waiting for upgrade annotation to be set"))
+			Expect(called["preUpgrade"]).Should(Equal(1))
+
+			By("check the annotation is patched and the status is kept")
+			Expect(*rootVertex().Action).Should(Equal(model.PATCH))
+			upgrade, err := getComponentUpgrade(rootVertex().Obj.(*appsv1.Component))
+			Expect(err).Should(BeNil())
+			Expect(upgrade).ShouldNot(BeNil())
+			Expect(upgrade.From.ServiceVersion).Should(Equal("1.0"))
+			Expect(upgrade.To.ServiceVersion).Should(Equal("2.0"))
+			status := statusVertex()
+			Expect(status).ShouldNot(BeNil())
+			Expect(meta.FindStatusCondition(status.Status.Conditions, appsv1.ComponentConditionLifecycleActionFailed)).Should(BeNil())
+			cond := meta.FindStatusCondition(status.Status.Conditions, appsv1.ComponentConditionUpgrading)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(reasonUpgrading))
+		})
+
+		It("outputs", func() {
+			compDef.Spec.LifecycleActions.PreUpgrade.Outputs = []appsv1.ActionOutput{{Name: "token", Required: true}}
+			synthesizeComponent, err := component.BuildSynthesizedComponent(ctx, reader, compDef, comp)
+			Expect(err).To(BeNil())
+			transCtx.SynthesizeComponent = synthesizeComponent
+			outputs["preUpgrade"] = `{"token": "abc"}`
+
+			transformer := &componentPreUpgradeTransformer{}
+			err = transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(called["preUpgrade"]).Should(Equal(1))
+
+			By("check the outputs are kept in the status")
+			Expect(*rootVertex().Action).Should(Equal(model.PATCH))
+			status := statusVertex()
+			Expect(status).ShouldNot(BeNil())
+			Expect(getActionOutputs(status, upgradeOperation)).Should(Equal(map[string]string{"preUpgrade.token": "abc"}))
+		})
+
+		It("no pods", func() {
+			reader.Objects = []client.Object{compDef, comp}
+
+			transformer := &componentPreUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("requeue to waiting for upgrade annotation to be set"))
+			Expect(called["preUpgrade"]).Should(Equal(0))
+			Expect(*rootVertex().Action).Should(Equal(model.PATCH))
+			Expect(rootVertex().Obj.GetAnnotations()).Should(HaveKey(kbCompUpgradeKey))
+		})
+	})
+
+	Context("post-upgrade", func() {
+		BeforeEach(func() {
+			its.Annotations[constant.KBAppServiceVersionKey] = "2.0"
+			its.Status = workloads.InstanceSetStatus{
+				Replicas:        1,
+				ReadyReplicas:   1,
+				UpdatedReplicas: 1,
+			}
+			comp.Annotations[kbCompUpgradeKey] = `{"from":{"compDef":"test-compdef","serviceVersion":"1.0"},"to":{"compDef":"test-compdef","serviceVersion":"2.0"}}`
+		})
+
+		It("fails", func() {
+			failed["postUpgrade"] = true
+
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("post-upgrade action failed"))
+			Expect(called["postUpgrade"]).Should(Equal(1))
+			Expect(*rootVertex().Action).Should(Equal(model.STATUS))
+			cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ComponentConditionUpgrading)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(reasonPostUpgradeFailed))
+			Expect(comp.Annotations).Should(HaveKey(kbCompUpgradeKey))
+		})
+
+		It("ok", func() {
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("requeue to waiting for upgrade annotation to be removed"))
+			Expect(called["postUpgrade"]).Should(Equal(1))
+
+			By("check the annotation is removed and the status is kept")
+			Expect(*rootVertex().Action).Should(Equal(model.PATCH))
+			Expect(rootVertex().Obj.GetAnnotations()).ShouldNot(HaveKey(kbCompUpgradeKey))
+			Expect(rootVertex().OriObj.GetAnnotations()).Should(HaveKey(kbCompUpgradeKey))
+			status := statusVertex()
+			Expect(status).ShouldNot(BeNil())
+			cond := meta.FindStatusCondition(status.Status.Conditions, appsv1.ComponentConditionUpgrading)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(reasonUpgraded))
+		})
+
+		It("outputs", func() {
+			setActionOutputs(comp, upgradeOperation, map[string]string{"preUpgrade.token": "abc"})
+
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(called["postUpgrade"]).Should(Equal(1))
+			Expect(params["postUpgrade"]).Should(HaveKeyWithValue("KB_OUTPUT_PREUPGRADE_TOKEN", "abc"))
+
+			By("check the outputs are cleared once the upgrade is done")
+			status := statusVertex()
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.Status.ActionOutputs).Should(BeEmpty())
+		})
+
+		It("saves the invocation history", func() {
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(called["postUpgrade"]).Should(Equal(1))
+
+			builder := &componentPlanBuilder{transCtx: transCtx}
+			Expect(builder.saveActionInvocations(dag)).Should(Equal(1))
+			status := statusVertex()
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.Status.ActionHistory).Should(HaveLen(1))
+			Expect(status.Status.ActionHistory[0].Action).Should(Equal("postUpgrade"))
+			Expect(status.Status.ActionHistory[0].Invocations).Should(HaveLen(1))
+			Expect(status.Status.ActionHistory[0].Invocations[0].Succeeded).Should(BeTrue())
+		})
+
+		It("keeps the invocation history if the status is not updated", func() {
+			failed["postUpgrade"] = true
+
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(called["postUpgrade"]).Should(Equal(1))
+
+			graphCli, _ := transCtx.Client.(model.GraphClient)
+			graphCli.Update(dag, comp.DeepCopy(), comp, &model.ReplaceIfExistingOption{})
+			builder := &componentPlanBuilder{transCtx: transCtx}
+			Expect(builder.saveActionInvocations(dag)).Should(Equal(0))
+			Expect(comp.Status.ActionHistory).Should(BeEmpty())
+			Expect(lifecycle.PendingInvocations(comp.Namespace, comp.Name)).Should(HaveLen(1))
+		})
+
+		It("waits for the replicas if there is no pod", func() {
+			reader.Objects = []client.Object{compDef, comp}
+			its.Spec.Replicas = ptr.To[int32](0)
+			its.Status = workloads.InstanceSetStatus{}
+
+			transformer := &componentPostUpgradeTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+			Expect(called["postUpgrade"]).Should(Equal(0))
+			Expect(*rootVertex().Action).Should(Equal(model.STATUS))
+			Expect(comp.Annotations).Should(HaveKey(kbCompUpgradeKey))
+			cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ComponentConditionUpgrading)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(reasonPostUpgradePending))
+		})
+	})
+})
@@ -129,31 +129,11 @@ func envConfigMapSource(clusterName, compName string) corev1.EnvFromSource {
 func createOrUpdateEnvConfigMap(transCtx *componentTransformContext, dag *graph.DAG, full, incremental map[string]string) error {
 	var (
 		synthesizedComp = transCtx.SynthesizeComponent
-		envKey          = types.NamespacedName{
-			Namespace: synthesizedComp.Namespace,
-			Name:      constant.GenerateClusterComponentEnvPattern(synthesizedComp.ClusterName, synthesizedComp.Name),
-		}
-		graphCli, _ = transCtx.Client.(model.GraphClient)
+		envKey          = envConfigMapKey(synthesizedComp)
+		graphCli, _     = transCtx.Client.(model.GraphClient)
 	)
 
-	envObj, envObjVertex, err := func() (*corev1.ConfigMap, graph.Vertex, error) {
-		// look up in graph first
-		if v := graphCli.FindMatchedVertex(dag, &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: envKey.Namespace,
-				Name:      envKey.Name,
-			},
-		}); v != nil {
-			return v.(*model.ObjectVertex).Obj.(*corev1.ConfigMap), v, nil
-		}
-
-		obj := &corev1.ConfigMap{}
-		err := transCtx.Client.Get(transCtx.Context, envKey, obj)
-		if err != nil {
-			return nil, nil, client.IgnoreNotFound(err)
-		}
-		return obj, nil, nil
-	}()
+	envObj, envObjVertex, err := getEnvConfigMap(transCtx, dag)
 	if err != nil {
 		return err
 	}
@@ -177,6 +157,10 @@ func createOrUpdateEnvConfigMap(transCtx *componentTransformContext, dag *graph.
 		data := maps.Clone(envObj.Data)
 		if full != nil {
 			data = maps.Clone(full) // override
+			// keep the tasks of the kb-agent worker, they are removed when finished
+			if tasks, err := component.KBAgentTaskEnv(envObj.Data); err == nil {
+				maps.Copy(data, tasks)
+			}
 		}
 		if incremental != nil {
 			maps.Copy(data, incremental) // merge
@@ -211,6 +195,35 @@ func createOrUpdateEnvConfigMap(transCtx *componentTransformContext, dag *graph.
 	return nil
 }
 
+func envConfigMapKey(synthesizedComp *component.SynthesizedComponent) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateClusterComponentEnvPattern(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+}
+
+// getEnvConfigMap returns the env CM of the component, and its vertex if it has been put in the graph.
+func getEnvConfigMap(transCtx *componentTransformContext, dag *graph.DAG) (*corev1.ConfigMap, graph.Vertex, error) {
+	envKey := envConfigMapKey(transCtx.SynthesizeComponent)
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	// look up in graph first
+	if v := graphCli.FindMatchedVertex(dag, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: envKey.Namespace,
+			Name:      envKey.Name,
+		},
+	}); v != nil {
+		return v.(*model.ObjectVertex).Obj.(*corev1.ConfigMap), v, nil
+	}
+
+	obj := &corev1.ConfigMap{}
+	err := transCtx.Client.Get(transCtx.Context, envKey, obj)
+	if err != nil {
+		return nil, nil, client.IgnoreNotFound(err)
+	}
+	return obj, nil, nil
+}
+
 type varsReader struct {
 	cli      client.Reader
 	graphCli model.GraphClient
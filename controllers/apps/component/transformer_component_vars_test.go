@@ -33,6 +33,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("vars transformer test", func() {
@@ -184,5 +186,27 @@ var _ = Describe("vars transformer test", func() {
 			Expect(err).Should(BeNil())
 			checkEnvCM(model.ActionUpdatePtr(), map[string]string{"foo": "bar", "task": "nil"})
 		})
+
+		It("keep the tasks of the worker", func() {
+			tasks, err := kbagent.BuildEnv4Worker([]proto.Task{{Instance: compName, Task: proto.TaskKindNewReplica, UID: "uid", Replicas: "pod-1"}})
+			Expect(err).Should(BeNil())
+			// mock the env CM object
+			reader.Objects = append(reader.Objects, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateClusterComponentEnvPattern(clusterName, compName),
+				},
+				Data: map[string]string{
+					"foo":      "bar",
+					tasks.Name: tasks.Value,
+				},
+			})
+
+			err = createOrUpdateEnvConfigMap(transCtx, dag, map[string]string{
+				"foo": "bingo",
+			}, nil)
+			Expect(err).Should(BeNil())
+			checkEnvCM(model.ActionUpdatePtr(), map[string]string{"foo": "bingo", tasks.Name: tasks.Value})
+		})
 	})
 })
@@ -26,7 +26,10 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,16 +79,85 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 				return err
 			}
 			graphCli.Create(dag, protoITS)
-			return nil
 		}
 	} else {
 		if protoITS == nil {
 			graphCli.Delete(dag, runningITS)
-		} else {
-			err = t.handleUpdate(transCtx, graphCli, dag, synthesizeComp, comp, runningITS, protoITS)
+		} else if err = t.handleUpdate(transCtx, graphCli, dag, synthesizeComp, comp, runningITS, protoITS); err != nil {
+			return err
 		}
 	}
-	return err
+
+	if err = t.reconcileReplicaTask(transCtx, dag); err != nil {
+		return err
+	}
+
+	return t.reconcilePodDisruptionBudget(transCtx, graphCli, dag)
+}
+
+// reconcileReplicaTask issues the replica task requested by the annotation of the component to the kb-agent worker,
+// the task is merged into the tasks kept in the env CM, so that the in-flight tasks of other kinds are not interrupted.
+func (t *componentWorkloadTransformer) reconcileReplicaTask(transCtx *componentTransformContext, dag *graph.DAG) error {
+	if _, ok := transCtx.Component.Annotations[constant.ReplicaTaskAnnotationKey]; !ok {
+		return nil
+	}
+	envObj, _, err := getEnvConfigMap(transCtx, dag)
+	if err != nil {
+		return err
+	}
+	var envData map[string]string
+	if envObj != nil {
+		envData = envObj.Data
+	}
+	parameters, err := component.ReplicaTask4Annotation(transCtx.Component, transCtx.SynthesizeComponent.FullCompName, envData)
+	if err != nil || parameters == nil {
+		return err
+	}
+	return createOrUpdateEnvConfigMap(transCtx, dag, nil, parameters)
+}
+
+// reconcilePodDisruptionBudget keeps the PodDisruptionBudget of the component in sync with its roles and replicas,
+// the PodDisruptionBudget is removed when the component is stopped or no budget is needed.
+func (t *componentWorkloadTransformer) reconcilePodDisruptionBudget(transCtx *componentTransformContext, cli model.GraphClient, dag *graph.DAG) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	protoPDB := component.BuildPodDisruptionBudget(synthesizedComp, transCtx.RunningWorkload)
+
+	key := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	runningPDB := &policyv1.PodDisruptionBudget{}
+	if err := transCtx.Client.Get(transCtx.Context, key, runningPDB); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		runningPDB = nil
+	}
+
+	switch {
+	case runningPDB == nil:
+		if protoPDB != nil {
+			if err := intctrlutil.SetOwnership(transCtx.Component, protoPDB, model.GetScheme(), ""); err != nil {
+				return err
+			}
+			cli.Create(dag, protoPDB)
+		}
+	case !model.IsOwnerOf(transCtx.Component, runningPDB):
+		// don't touch the PodDisruptionBudget not owned by the component, it may be created by the user
+		return nil
+	case protoPDB == nil:
+		cli.Delete(dag, runningPDB)
+	default:
+		pdbCopy := runningPDB.DeepCopy()
+		intctrlutil.MergeMetadataMapInplace(protoPDB.Labels, &pdbCopy.Labels)
+		intctrlutil.MergeMetadataMapInplace(protoPDB.Annotations, &pdbCopy.Annotations)
+		pdbCopy.Spec = protoPDB.Spec
+		transCtx.trackDrift(runningPDB, pdbCopy)
+		if !reflect.DeepEqual(runningPDB, pdbCopy) {
+			cli.Update(dag, runningPDB, pdbCopy)
+		}
+	}
+	return nil
 }
 
 func (t *componentWorkloadTransformer) reconcileWorkload(ctx context.Context, cli client.Reader,
@@ -164,6 +236,7 @@ func (t *componentWorkloadTransformer) handleUpdate(transCtx *componentTransform
 
 	objCopy := copyAndMergeITS(runningITS, protoITS, legacyConfigManagerRequired(comp))
 	if objCopy != nil {
+		transCtx.trackDrift(runningITS, objCopy)
 		cli.Update(dag, nil, objCopy, &model.ReplaceIfExistingOption{})
 		// make sure the workload is updated after the env CM
 		cli.DependOn(dag, &corev1.ConfigMap{
@@ -253,6 +326,7 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, legacyConfigManagerP
 	itsObjCopy.Spec.PodUpdatePolicy = itsProto.Spec.PodUpdatePolicy
 	itsObjCopy.Spec.PodUpgradePolicy = itsProto.Spec.PodUpgradePolicy
 	itsObjCopy.Spec.InstanceUpdateStrategy = itsProto.Spec.InstanceUpdateStrategy
+	itsObjCopy.Spec.MaintenanceWindow = itsProto.Spec.MaintenanceWindow
 	itsObjCopy.Spec.MemberUpdateStrategy = itsProto.Spec.MemberUpdateStrategy
 	itsObjCopy.Spec.Paused = itsProto.Spec.Paused
 	itsObjCopy.Spec.Stop = itsProto.Spec.Stop
@@ -34,6 +34,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -179,15 +180,23 @@ func (r *componentWorkloadOps) leaveMemberForPod(pod *corev1.Pod, pods []*corev1
 	var (
 		synthesizedComp  = r.synthesizeComp
 		lifecycleActions = synthesizedComp.LifecycleActions
+		// the outputs of switchover are passed to the member-leave that follows it
+		operation = "scaleIn/" + pod.Name
+		opts      = &lifecycle.Options{Outputs: getActionOutputs(r.component, operation)}
 	)
 
 	switchover := func(lfa lifecycle.Lifecycle, pod *corev1.Pod) error {
 		if lifecycleActions.Switchover == nil {
 			return nil
 		}
-		err := lfa.Switchover(r.transCtx.Context, r.cli, nil, "")
+		candidate := ""
+		if r.runningITS != nil {
+			// the candidate should not be scaled in
+			candidate = instanceset.SelectSwitchoverCandidate(r.runningITS, pod.Name, r.desiredCompPodNameSet.Has)
+		}
+		err := lfa.Switchover(r.transCtx.Context, r.cli, opts, candidate)
 		if err == nil {
-			r.transCtx.Logger.Info("succeed to call switchover action", "pod", pod.Name)
+			r.transCtx.Logger.Info("succeed to call switchover action", "pod", pod.Name, "candidate", candidate)
 		} else if !errors.Is(err, lifecycle.ErrActionNotDefined) {
 			r.transCtx.Logger.Info("failed to call switchover action, ignore it", "pod", pod.Name, "error", err)
 		}
@@ -198,7 +207,7 @@ func (r *componentWorkloadOps) leaveMemberForPod(pod *corev1.Pod, pods []*corev1
 		if lifecycleActions.MemberLeave == nil {
 			return nil
 		}
-		err := lfa.MemberLeave(r.transCtx.Context, r.cli, nil)
+		err := lfa.MemberLeave(r.transCtx.Context, r.cli, opts)
 		if err != nil {
 			if errors.Is(err, lifecycle.ErrActionNotDefined) {
 				return nil
@@ -224,8 +233,11 @@ func (r *componentWorkloadOps) leaveMemberForPod(pod *corev1.Pod, pods []*corev1
 		return err
 	}
 	if err = leaveMember(lfa, pod); err != nil {
+		// keep the outputs for the retry, the switchover may not report them again once the leader has moved
+		setActionOutputs(r.component, operation, opts.Outputs)
 		return err
 	}
+	setActionOutputs(r.component, operation, nil)
 	return nil
 }
 
@@ -266,18 +278,27 @@ func (r *componentWorkloadOps) buildDataReplicationTask() error {
 		return err
 	}
 
-	replicas := append(slices.Clone(newReplicas), provisioningReplicas...)
-	parameters, err := component.NewReplicaTask(r.synthesizeComp.FullCompName, r.synthesizeComp.Generation, source, replicas)
-	if err != nil {
-		return err
-	}
-	// apply the updated env to the env CM
 	transCtx := &componentTransformContext{
 		Context:             r.transCtx.Context,
 		Client:              model.NewGraphClient(r.cli),
 		SynthesizeComponent: r.synthesizeComp,
 		Component:           r.component,
 	}
+	envObj, _, err := getEnvConfigMap(transCtx, r.dag)
+	if err != nil {
+		return err
+	}
+	var envData map[string]string
+	if envObj != nil {
+		envData = envObj.Data
+	}
+
+	replicas := append(slices.Clone(newReplicas), provisioningReplicas...)
+	parameters, err := component.NewReplicaTask(envData, r.synthesizeComp.FullCompName, r.synthesizeComp.Generation, source, replicas, r.component.Annotations)
+	if err != nil {
+		return err
+	}
+	// apply the updated env to the env CM
 	return createOrUpdateEnvConfigMap(transCtx, r.dag, nil, parameters)
 }
 
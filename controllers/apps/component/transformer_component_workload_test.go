@@ -23,8 +23,12 @@ import (
 
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
@@ -1030,6 +1034,111 @@ var _ = Describe("Component Workload Operations Test", func() {
 		})
 	})
 
+	Context("PodDisruptionBudget", func() {
+		var (
+			transCtx *componentTransformContext
+		)
+
+		newTransCtx := func(objs ...client.Object) *componentTransformContext {
+			graphCli := model.NewGraphClient(&appsutil.MockReader{Objects: objs})
+			dag = newDAG(graphCli, comp)
+			return &componentTransformContext{
+				Context:             ctx,
+				Client:              graphCli,
+				Logger:              logger,
+				Component:           comp,
+				ComponentOrig:       comp.DeepCopy(),
+				SynthesizeComponent: synthesizeComp,
+			}
+		}
+
+		runningPDB := func(maxUnavailable int32) *policyv1.PodDisruptionBudget {
+			pdb := component.BuildPodDisruptionBudget(synthesizeComp, nil)
+			Expect(pdb).ShouldNot(BeNil())
+			pdb.Spec.MaxUnavailable = ptr.To(intstr.FromInt32(maxUnavailable))
+			Expect(intctrlutil.SetOwnership(comp, pdb, model.GetScheme(), "")).Should(Succeed())
+			return pdb
+		}
+
+		pdbAction := func() (*policyv1.PodDisruptionBudget, *model.Action) {
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &policyv1.PodDisruptionBudget{})
+			if len(objs) == 0 {
+				return nil, nil
+			}
+			Expect(objs).Should(HaveLen(1))
+			v := graphCli.FindMatchedVertex(dag, objs[0]).(*model.ObjectVertex)
+			return objs[0].(*policyv1.PodDisruptionBudget), v.Action
+		}
+
+		BeforeEach(func() {
+			comp.UID = "test-uid"
+			synthesizeComp.Roles = []appsv1.ReplicaRole{
+				{Name: "leader", ParticipatesInQuorum: true, IsExclusive: true},
+				{Name: "follower", ParticipatesInQuorum: true},
+			}
+			synthesizeComp.Replicas = 3
+		})
+
+		It("creates the PodDisruptionBudget", func() {
+			transCtx = newTransCtx()
+			transformer := &componentWorkloadTransformer{}
+			Expect(transformer.reconcilePodDisruptionBudget(transCtx, transCtx.Client.(model.GraphClient), dag)).Should(Succeed())
+
+			pdb, action := pdbAction()
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(*action).Should(Equal(model.CREATE))
+			Expect(pdb.Name).Should(Equal(constant.GenerateWorkloadNamePattern(clusterName, compName)))
+			Expect(pdb.Spec.MaxUnavailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+			Expect(pdb.Spec.Selector.MatchLabels).Should(Equal(constant.GetCompLabels(clusterName, compName)))
+			Expect(model.IsOwnerOf(comp, pdb)).Should(BeTrue())
+		})
+
+		It("updates the PodDisruptionBudget on horizontal scaling", func() {
+			transCtx = newTransCtx(runningPDB(1))
+			synthesizeComp.Replicas = 5
+			transformer := &componentWorkloadTransformer{}
+			Expect(transformer.reconcilePodDisruptionBudget(transCtx, transCtx.Client.(model.GraphClient), dag)).Should(Succeed())
+
+			pdb, action := pdbAction()
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(*action).Should(Equal(model.UPDATE))
+			Expect(pdb.Spec.MaxUnavailable).Should(Equal(ptr.To(intstr.FromInt32(2))))
+		})
+
+		It("does nothing if the PodDisruptionBudget is up to date", func() {
+			transCtx = newTransCtx(runningPDB(1))
+			transformer := &componentWorkloadTransformer{}
+			Expect(transformer.reconcilePodDisruptionBudget(transCtx, transCtx.Client.(model.GraphClient), dag)).Should(Succeed())
+
+			pdb, _ := pdbAction()
+			Expect(pdb).Should(BeNil())
+		})
+
+		It("deletes the PodDisruptionBudget when the component is stopped", func() {
+			transCtx = newTransCtx(runningPDB(1))
+			synthesizeComp.Stop = ptr.To(true)
+			transformer := &componentWorkloadTransformer{}
+			Expect(transformer.reconcilePodDisruptionBudget(transCtx, transCtx.Client.(model.GraphClient), dag)).Should(Succeed())
+
+			pdb, action := pdbAction()
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(*action).Should(Equal(model.DELETE))
+		})
+
+		It("doesn't touch the PodDisruptionBudget not owned by the component", func() {
+			pdb := runningPDB(1)
+			pdb.OwnerReferences = nil
+			transCtx = newTransCtx(pdb)
+			synthesizeComp.DisruptionBudget = &appsv1.DisruptionBudget{Disabled: true}
+			transformer := &componentWorkloadTransformer{}
+			Expect(transformer.reconcilePodDisruptionBudget(transCtx, transCtx.Client.(model.GraphClient), dag)).Should(Succeed())
+
+			obj, _ := pdbAction()
+			Expect(obj).Should(BeNil())
+		})
+	})
+
 	Context("workload helper edge cases", func() {
 		It("detects pod resource and metadata-only template changes", func() {
 			emptySpec := corev1.PodSpec{
@@ -21,12 +21,17 @@ package component
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -36,8 +41,24 @@ const (
 
 	// reasonPreCheckFailed preChecks failed for provisioning started
 	reasonPreCheckFailed = "PreCheckFailed"
+
+	// maxActionHistoryInvocations is the number of the latest invocations kept for each lifecycle action.
+	maxActionHistoryInvocations = 5
+
+	// maxActionInvocationMessageLength is the max length of the message of an invocation kept in the history.
+	maxActionInvocationMessageLength = 512
 )
 
+// patchCompMetadata patches the metadata changes of the component since the compObj, and keeps the status changes
+// made in the same reconciliation. The root vertex is turned to patch the metadata, and the status is updated in
+// a separate vertex before it. The merge patch carries no resource version, so it does not conflict with the status update.
+func patchCompMetadata(transCtx *componentTransformContext, dag *graph.DAG, compObj *appsv1.Component) {
+	comp := transCtx.Component
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Patch(dag, compObj, comp, &model.ReplaceIfExistingOption{})
+	graphCli.Do(dag, nil, comp.DeepCopy(), model.ActionStatusPtr(), nil)
+}
+
 func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
 	var condition metav1.Condition
 	if err == nil {
@@ -48,6 +69,90 @@ func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName
 	meta.SetStatusCondition(conditions, condition)
 }
 
+// setLifecycleActionFailedCondition records the audit summary of the failed lifecycle action in the conditions,
+// and removes the condition once the action succeeds.
+func setLifecycleActionFailedCondition(conditions *[]metav1.Condition, generation int64, action string, err error) {
+	if err == nil {
+		cond := meta.FindStatusCondition(*conditions, appsv1.ComponentConditionLifecycleActionFailed)
+		if cond != nil && cond.Reason == action {
+			meta.RemoveStatusCondition(conditions, appsv1.ComponentConditionLifecycleActionFailed)
+		}
+		return
+	}
+	summary := lifecycle.AuditSummary(err)
+	if len(summary) == 0 {
+		return // the audit log is not available
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               appsv1.ComponentConditionLifecycleActionFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             action,
+		Message:            intctrlutil.TruncateConditionMessage(summary),
+	})
+}
+
+// getActionOutputs returns a copy of the outputs of the lifecycle actions recorded for the operation.
+func getActionOutputs(comp *appsv1.Component, operation string) map[string]string {
+	outputs := map[string]string{}
+	for _, o := range comp.Status.ActionOutputs {
+		if o.Operation == operation {
+			maps.Copy(outputs, o.Outputs)
+		}
+	}
+	return outputs
+}
+
+// setActionOutputs records the outputs of the lifecycle actions for the operation, the record is removed if there is no output.
+func setActionOutputs(comp *appsv1.Component, operation string, outputs map[string]string) {
+	idx := slices.IndexFunc(comp.Status.ActionOutputs, func(o appsv1.ComponentActionOutputs) bool {
+		return o.Operation == operation
+	})
+	switch {
+	case len(outputs) == 0 && idx >= 0:
+		comp.Status.ActionOutputs = slices.Delete(comp.Status.ActionOutputs, idx, idx+1)
+	case len(outputs) == 0:
+		return
+	case idx >= 0:
+		comp.Status.ActionOutputs[idx].Outputs = outputs
+	default:
+		comp.Status.ActionOutputs = append(comp.Status.ActionOutputs, appsv1.ComponentActionOutputs{
+			Operation: operation,
+			Outputs:   outputs,
+		})
+	}
+}
+
+// recordActionInvocation appends the invocation of the lifecycle action to the history in the component status.
+func recordActionInvocation(comp *appsv1.Component, invocation lifecycle.Invocation) {
+	record := appsv1.ComponentActionInvocation{
+		Pod:       invocation.Pod,
+		StartTime: metav1.NewTime(invocation.StartTime),
+		EndTime:   metav1.NewTime(invocation.EndTime),
+		Succeeded: invocation.Error == nil,
+		ExitCode:  invocation.ExitCode,
+	}
+	if invocation.Error != nil {
+		record.Message = invocation.Error.Error()
+		if len(record.Message) > maxActionInvocationMessageLength {
+			record.Message = record.Message[:maxActionInvocationMessageLength-3] + "..."
+		}
+	}
+
+	idx := slices.IndexFunc(comp.Status.ActionHistory, func(h appsv1.ComponentActionHistory) bool {
+		return h.Action == invocation.Action
+	})
+	if idx < 0 {
+		comp.Status.ActionHistory = append(comp.Status.ActionHistory, appsv1.ComponentActionHistory{Action: invocation.Action})
+		idx = len(comp.Status.ActionHistory) - 1
+	}
+	history := &comp.Status.ActionHistory[idx]
+	history.Invocations = append(history.Invocations, record)
+	if len(history.Invocations) > maxActionHistoryInvocations {
+		history.Invocations = slices.Clone(history.Invocations[len(history.Invocations)-maxActionHistoryInvocations:])
+	}
+}
+
 // newProvisioningStartedCondition creates the provisioning started condition in cluster conditions.
 func newProvisioningStartedCondition(clusterName string, clusterGeneration int64) metav1.Condition {
 	return metav1.Condition{
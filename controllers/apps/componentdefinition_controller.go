@@ -516,6 +516,17 @@ func (r *ComponentDefinitionReconciler) validatePolicyRules(cli client.Client, r
 
 func (r *ComponentDefinitionReconciler) validateLifecycleActions(cli client.Client, reqCtx intctrlutil.RequestCtx,
 	cmpd *appsv1.ComponentDefinition) error {
+	actions := cmpd.Spec.LifecycleActions
+	if actions == nil {
+		return nil
+	}
+	// the stdout and stdin of the data actions carry the data transferred, there is no room for the outputs
+	if actions.DataDump != nil && len(actions.DataDump.Outputs) > 0 {
+		return fmt.Errorf("outputs are not supported by the dataDump action")
+	}
+	if actions.DataLoad != nil && len(actions.DataLoad.Outputs) > 0 {
+		return fmt.Errorf("outputs are not supported by the dataLoad action")
+	}
 	return nil
 }
 
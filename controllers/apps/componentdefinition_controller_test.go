@@ -483,6 +483,22 @@ var _ = Describe("ComponentDefinition Controller", func() {
 		})
 	})
 
+	Context("lifecycle actions", func() {
+		It("outputs of the data actions", func() {
+			By("create a ComponentDefinition obj")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				SetLifecycleAction("dataDump", &kbappsv1.Action{
+					Exec:    &kbappsv1.ExecAction{Command: []string{"dump"}},
+					Outputs: []kbappsv1.ActionOutput{{Name: "position"}},
+				}).
+				Create(&testCtx).
+				GetObject()
+
+			checkObjectStatus(componentDefObj, kbappsv1.UnavailablePhase)
+		})
+	})
+
 	Context("replica roles", func() {
 		It("ok", func() {
 			By("create a ComponentDefinition obj")
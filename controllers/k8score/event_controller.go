@@ -83,7 +83,7 @@ func (r *EventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 
 	handled := false
-	for _, handler := range r.handlers() {
+	for _, handler := range newEventHandlers(r.AppsEnabled, r.WorkloadsEnabled) {
 		ok, err := handler.Handle(r.Client, reqCtx, r.Recorder, event)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return intctrlutil.RequeueWithError(err, reqCtx.Log, "handleEventError")
@@ -111,15 +111,15 @@ func (r *EventReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func (r *EventReconciler) handlers() []eventHandler {
+func newEventHandlers(appsEnabled, workloadsEnabled bool) []eventHandler {
 	handlers := make([]eventHandler, 0, 3)
-	if r.AppsEnabled {
+	if appsEnabled {
 		handlers = append(handlers,
 			&component.AvailableEventHandler{},
 			&component.KBAgentTaskEventHandler{},
 		)
 	}
-	if r.WorkloadsEnabled {
+	if workloadsEnabled {
 		handlers = append(handlers, &workloads.RoleEventHandler{})
 	}
 	return handlers
@@ -0,0 +1,178 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package k8score
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// KBAgentStatusReconciler reconciles the status objects that kb-agent reports the probe and task results to.
+// Each record in the status object is handled by the same handlers as the events, and it is removed once handled.
+// The removal is guarded by the resource version, so a newer record reported in the meantime is never dropped.
+type KBAgentStatusReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	Recorder         record.EventRecorder
+	AppsEnabled      bool
+	WorkloadsEnabled bool
+}
+
+func (r *KBAgentStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx: ctx,
+		Req: req,
+		Log: log.FromContext(ctx).WithValues("kbagentStatus", req.NamespacedName),
+	}
+
+	reqCtx.Log.V(1).Info("kb-agent status watcher")
+
+	lease := &coordinationv1.Lease{}
+	if err := r.Client.Get(ctx, req.NamespacedName, lease); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "getStatusObjectError")
+	}
+
+	handled, err := r.handleRecords(reqCtx, lease)
+	if len(handled) > 0 {
+		patch := client.MergeFromWithOptions(lease.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		for _, key := range handled {
+			delete(lease.Annotations, key)
+		}
+		if patchErr := r.Client.Patch(ctx, lease, patch); patchErr != nil {
+			if apierrors.IsConflict(patchErr) {
+				// new records are reported, handle them again
+				return intctrlutil.Requeue(reqCtx.Log, "statusObjectConflict")
+			}
+			return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "removeHandledRecordsError")
+		}
+	}
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "handleRecordError")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// handleRecords handles the records in order, it returns the keys of the records handled before any error.
+func (r *KBAgentStatusReconciler) handleRecords(reqCtx intctrlutil.RequestCtx, lease *coordinationv1.Lease) ([]string, error) {
+	keys := make([]string, 0)
+	for key := range lease.Annotations {
+		if strings.HasPrefix(key, proto.StatusRecordAnnotationPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	handlers := newEventHandlers(r.AppsEnabled, r.WorkloadsEnabled)
+	handledKeys := make([]string, 0)
+	for _, key := range keys {
+		event, err := statusRecordToEvent(lease, key)
+		if err != nil {
+			reqCtx.Log.Error(err, "invalid status record, drop it", "key", key)
+			handledKeys = append(handledKeys, key)
+			continue
+		}
+		handled := false
+		for _, handler := range handlers {
+			ok, err := handler.Handle(r.Client, reqCtx, r.Recorder, event)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return handledKeys, err
+			}
+			handled = handled || ok
+		}
+		if handled {
+			handledKeys = append(handledKeys, key)
+		}
+	}
+	return handledKeys, nil
+}
+
+// statusRecordToEvent converts the record to the event as kb-agent sends, the time of the record is used as the event time.
+func statusRecordToEvent(lease *coordinationv1.Lease, key string) (*corev1.Event, error) {
+	statusRecord := &proto.StatusRecord{}
+	if err := json.Unmarshal([]byte(lease.Annotations[key]), statusRecord); err != nil {
+		return nil, err
+	}
+	involvedObject := corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  lease.Namespace,
+		Name:       lease.Labels[proto.StatusObjectLabelKey],
+		FieldPath:  proto.ProbeEventFieldPath,
+	}
+	for _, owner := range lease.OwnerReferences {
+		if owner.Kind == "Pod" && owner.Name == involvedObject.Name {
+			involvedObject.UID = owner.UID
+		}
+	}
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: lease.Namespace,
+			Name:      lease.Name,
+			UID:       lease.UID,
+		},
+		InvolvedObject: involvedObject,
+		Reason:         statusRecord.Reason,
+		Message:        statusRecord.Message,
+		Source: corev1.EventSource{
+			Component: proto.ProbeEventSourceComponent,
+		},
+		FirstTimestamp:      metav1.NewTime(statusRecord.Time),
+		LastTimestamp:       metav1.NewTime(statusRecord.Time),
+		EventTime:           metav1.NewMicroTime(statusRecord.Time),
+		ReportingController: proto.ProbeEventReportingController,
+		ReportingInstance:   involvedObject.Name,
+		Action:              statusRecord.Reason,
+		Type:                corev1.EventTypeNormal,
+		Count:               1,
+	}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KBAgentStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		For(&coordinationv1.Lease{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, ok := obj.GetLabels()[proto.StatusObjectLabelKey]
+			return ok
+		}))).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: viper.GetInt(constant.CfgKBReconcileWorkers) / 4,
+		}).
+		Complete(r)
+}
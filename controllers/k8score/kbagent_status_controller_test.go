@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package k8score
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("KBAgent Status Controller", func() {
+	It("converts the status record to event", func() {
+		now := time.Now()
+		record, err := json.Marshal(proto.StatusRecord{
+			Reason:  "roleProbe",
+			Message: `{"probe":"roleProbe","code":0}`,
+			Time:    now,
+		})
+		Expect(err).Should(BeNil())
+		key := proto.StatusRecordAnnotationPrefix + "roleProbe"
+		lease := &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      proto.StatusObjectName("pod-0"),
+				Labels: map[string]string{
+					proto.StatusObjectLabelKey: "pod-0",
+				},
+				Annotations: map[string]string{
+					key:            string(record),
+					"invalid-json": "{",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Name:       "pod-0",
+						UID:        "pod-uid",
+					},
+				},
+			},
+		}
+
+		event, err := statusRecordToEvent(lease, key)
+		Expect(err).Should(BeNil())
+		Expect(event.Reason).Should(Equal("roleProbe"))
+		Expect(event.Message).Should(Equal(`{"probe":"roleProbe","code":0}`))
+		Expect(event.ReportingController).Should(Equal(proto.ProbeEventReportingController))
+		Expect(event.InvolvedObject.Name).Should(Equal("pod-0"))
+		Expect(string(event.InvolvedObject.UID)).Should(Equal("pod-uid"))
+		Expect(event.InvolvedObject.FieldPath).Should(Equal(proto.ProbeEventFieldPath))
+		Expect(event.EventTime.UnixMicro()).Should(Equal(now.UnixMicro()))
+
+		_, err = statusRecordToEvent(lease, "invalid-json")
+		Expect(err).ShouldNot(BeNil())
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/operations"
+)
+
+const (
+	// hibernationStartingDeadline is the deadline to issue a scheduled transition,
+	// the transition is skipped if it is missed for longer than the deadline, e.g. the controller is down.
+	hibernationStartingDeadline = 30 * time.Minute
+
+	// hibernationOpsTTLSecondsAfterSucceed is the TTL of the succeeded OpsRequests issued by the hibernation schedule.
+	hibernationOpsTTLSecondsAfterSucceed = 24 * 60 * 60
+
+	invalidHibernationScheduleMessagePrefix = "invalid hibernation schedule"
+)
+
+// ClusterHibernationReconciler issues the Stop and Start OpsRequests of the Cluster according to its hibernation schedule.
+type ClusterHibernationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
+func (r *ClusterHibernationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if cluster.IsDeleting() {
+		return intctrlutil.Reconciled()
+	}
+
+	oldStatus := cluster.Status.Hibernation.DeepCopy()
+	patch := client.MergeFrom(cluster.DeepCopy())
+	requeueAfter, err := r.reconcileHibernation(reqCtx, cluster, time.Now())
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !reflect.DeepEqual(oldStatus, cluster.Status.Hibernation) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, cluster, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// reconcileHibernation issues the scheduled transition if it is due, and records the next transition in the status.
+// It returns the duration to wait for the next transition.
+func (r *ClusterHibernationReconciler) reconcileHibernation(reqCtx intctrlutil.RequestCtx, cluster *appsv1.Cluster, now time.Time) (time.Duration, error) {
+	if cluster.Spec.Hibernation == nil {
+		cluster.Status.Hibernation = nil
+		return 0, nil
+	}
+	if cluster.Status.Hibernation == nil {
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{}
+	}
+	status := cluster.Status.Hibernation
+
+	nextTransition, nextTransitionTime, err := nextHibernationTransition(cluster.Spec.Hibernation, now)
+	if err != nil {
+		status.NextTransition = ""
+		status.NextTransitionTime = nil
+		status.Message = fmt.Sprintf("%s: %s", invalidHibernationScheduleMessagePrefix, err.Error())
+		return 0, nil
+	}
+	if strings.HasPrefix(status.Message, invalidHibernationScheduleMessagePrefix) {
+		status.Message = ""
+	}
+	if status.NextTransitionTime != nil && !now.Before(status.NextTransitionTime.Time) {
+		if err = r.transit(reqCtx, cluster, status.NextTransition, status.NextTransitionTime.Time, now); err != nil {
+			return 0, err
+		}
+	}
+	status.NextTransition = nextTransition
+	status.NextTransitionTime = &metav1.Time{Time: nextTransitionTime}
+	return nextTransitionTime.Sub(now), nil
+}
+
+// transit issues the OpsRequest of the scheduled transition, the transition is skipped if
+// other OpsRequests are running or the Cluster is not in a proper phase.
+func (r *ClusterHibernationReconciler) transit(reqCtx intctrlutil.RequestCtx, cluster *appsv1.Cluster,
+	transition appsv1.HibernationTransition, scheduledTime, now time.Time) error {
+	status := cluster.Status.Hibernation
+	status.LastTransition = transition
+	status.LastTransitionTime = &metav1.Time{Time: scheduledTime}
+	status.LastOpsRequest = ""
+	status.Message = ""
+
+	opsName := fmt.Sprintf("%s-%s-%s", cluster.Name, strings.ToLower(string(transition)), scheduledTime.UTC().Format("200601021504"))
+	opsRequest := &opsv1alpha1.OpsRequest{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: cluster.Namespace}, opsRequest); err == nil {
+		// the OpsRequest has been issued for the scheduled transition.
+		status.LastOpsRequest = opsName
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	skip := func(format string, args ...any) {
+		status.Message = fmt.Sprintf("skip the %s transition scheduled at %s: %s", transition,
+			scheduledTime.UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, reasonHibernationSkipped, status.Message)
+	}
+	if now.Sub(scheduledTime) > hibernationStartingDeadline {
+		skip("missed the starting deadline %s", hibernationStartingDeadline)
+		return nil
+	}
+	runningOpsRequests, err := operations.GetRunningOpsRequestsInCluster(cluster)
+	if err != nil {
+		return err
+	}
+	if len(runningOpsRequests) > 0 {
+		skip("OpsRequest %s is running", runningOpsRequests[0].Name)
+		return nil
+	}
+	var opsType opsv1alpha1.OpsType
+	switch transition {
+	case appsv1.HibernationStop:
+		opsType = opsv1alpha1.StopType
+		fromClusterPhases := operations.GetOpsManager().OpsMap[opsType].FromClusterPhases
+		if !slices.Contains(fromClusterPhases, cluster.Status.Phase) {
+			skip("the cluster is %s", cluster.Status.Phase)
+			return nil
+		}
+	case appsv1.HibernationStart:
+		opsType = opsv1alpha1.StartType
+		if cluster.Status.Phase != appsv1.StoppedClusterPhase {
+			skip("the cluster is %s", cluster.Status.Phase)
+			return nil
+		}
+	default:
+		skip("unknown transition")
+		return nil
+	}
+
+	opsRequest = &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsName,
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.OpsRequestTypeLabelKey: string(opsType),
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName:            cluster.Name,
+			Type:                   opsType,
+			TTLSecondsAfterSucceed: hibernationOpsTTLSecondsAfterSucceed,
+		},
+	}
+	if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	status.LastOpsRequest = opsName
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonHibernationTransition,
+		"OpsRequest %s is created for the %s transition scheduled at %s", opsName, transition, scheduledTime.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// nextHibernationTransition returns the earliest transition scheduled after now, Stop takes precedence if both are scheduled at the same time.
+func nextHibernationTransition(hibernation *appsv1.ClusterHibernation, now time.Time) (appsv1.HibernationTransition, time.Time, error) {
+	location := time.UTC
+	if hibernation.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(hibernation.TimeZone); err != nil {
+			return "", time.Time{}, fmt.Errorf("invalid time zone %q: %w", hibernation.TimeZone, err)
+		}
+	}
+	stopSchedule, err := common.ParseCronSchedule(hibernation.StopSchedule, location)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	startSchedule, err := common.ParseCronSchedule(hibernation.StartSchedule, location)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	nextStop, nextStart := stopSchedule.Next(now), startSchedule.Next(now)
+	switch {
+	case nextStop.IsZero() && nextStart.IsZero():
+		return "", time.Time{}, fmt.Errorf("no time is matched by the schedules")
+	case nextStart.IsZero(), !nextStop.IsZero() && !nextStart.Before(nextStop):
+		return appsv1.HibernationStop, nextStop, nil
+	default:
+		return appsv1.HibernationStart, nextStart, nil
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterHibernationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("cluster-hibernation").
+		For(&appsv1.Cluster{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			cluster, ok := obj.(*appsv1.Cluster)
+			return ok && (cluster.Spec.Hibernation != nil || cluster.Status.Hibernation != nil)
+		}))).
+		Complete(r)
+}
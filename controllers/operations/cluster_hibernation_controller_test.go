@@ -0,0 +1,217 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("Cluster Hibernation Controller", func() {
+	const clusterName = "hibernation-cluster"
+
+	var (
+		reqCtx intctrlutil.RequestCtx
+		// 2026-05-04 is a Monday
+		stopTime  = time.Date(2026, 5, 4, 20, 0, 0, 0, time.UTC)
+		startTime = time.Date(2026, 5, 5, 8, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+	})
+
+	newCluster := func(phase appsv1.ClusterPhase) *appsv1.Cluster {
+		return &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1.ClusterSpec{
+				Hibernation: &appsv1.ClusterHibernation{
+					StopSchedule:  "0 20 * * 1-5",
+					StartSchedule: "0 8 * * 1-5",
+				},
+			},
+			Status: appsv1.ClusterStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	newReconciler := func(objects ...client.Object) *ClusterHibernationReconciler {
+		scheme := runtime.NewScheme()
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		return &ClusterHibernationReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+	}
+
+	It("records the next transition", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		r := newReconciler(cluster)
+
+		requeueAfter, err := r.reconcileHibernation(reqCtx, cluster, stopTime.Add(-time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requeueAfter).Should(Equal(time.Hour))
+		Expect(cluster.Status.Hibernation.NextTransition).Should(Equal(appsv1.HibernationStop))
+		Expect(cluster.Status.Hibernation.NextTransitionTime.Time).Should(BeTemporally("==", stopTime))
+		Expect(cluster.Status.Hibernation.LastTransitionTime).Should(BeNil())
+	})
+
+	It("evaluates the schedule in the time zone", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		cluster.Spec.Hibernation.TimeZone = "Asia/Shanghai"
+		r := newReconciler(cluster)
+
+		_, err := r.reconcileHibernation(reqCtx, cluster, stopTime.Add(-time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		if cluster.Status.Hibernation.NextTransitionTime == nil {
+			// the time zone database is not available
+			Skip(cluster.Status.Hibernation.Message)
+		}
+		Expect(cluster.Status.Hibernation.NextTransition).Should(Equal(appsv1.HibernationStart))
+		Expect(cluster.Status.Hibernation.NextTransitionTime.Time).Should(BeTemporally("==", time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("issues the Stop OpsRequest when the transition is due", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{
+			NextTransition:     appsv1.HibernationStop,
+			NextTransitionTime: &metav1.Time{Time: stopTime},
+		}
+		r := newReconciler(cluster)
+
+		requeueAfter, err := r.reconcileHibernation(reqCtx, cluster, stopTime.Add(time.Second))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requeueAfter).Should(Equal(startTime.Sub(stopTime) - time.Second))
+		status := cluster.Status.Hibernation
+		Expect(status.LastTransition).Should(Equal(appsv1.HibernationStop))
+		Expect(status.LastOpsRequest).ShouldNot(BeEmpty())
+		Expect(status.NextTransition).Should(Equal(appsv1.HibernationStart))
+		Expect(status.NextTransitionTime.Time).Should(BeTemporally("==", startTime))
+
+		opsRequest := &opsv1alpha1.OpsRequest{}
+		Expect(r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: status.LastOpsRequest, Namespace: cluster.Namespace}, opsRequest)).Should(Succeed())
+		Expect(opsRequest.Spec.Type).Should(Equal(opsv1alpha1.StopType))
+		Expect(opsRequest.Spec.ClusterName).Should(Equal(clusterName))
+		Expect(opsRequest.Labels).Should(HaveKeyWithValue(constant.AppInstanceLabelKey, clusterName))
+
+		By("reconciling the same window again")
+		cluster.Status.Hibernation.NextTransition = appsv1.HibernationStop
+		cluster.Status.Hibernation.NextTransitionTime = &metav1.Time{Time: stopTime}
+		_, err = r.reconcileHibernation(reqCtx, cluster, stopTime.Add(2*time.Second))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.Status.Hibernation.LastOpsRequest).Should(Equal(opsRequest.Name))
+		Expect(cluster.Status.Hibernation.Message).Should(BeEmpty())
+	})
+
+	It("issues the Start OpsRequest for the stopped cluster", func() {
+		cluster := newCluster(appsv1.StoppedClusterPhase)
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{
+			NextTransition:     appsv1.HibernationStart,
+			NextTransitionTime: &metav1.Time{Time: startTime},
+		}
+		r := newReconciler(cluster)
+
+		_, err := r.reconcileHibernation(reqCtx, cluster, startTime)
+		Expect(err).ShouldNot(HaveOccurred())
+		opsRequest := &opsv1alpha1.OpsRequest{}
+		Expect(r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: cluster.Status.Hibernation.LastOpsRequest, Namespace: cluster.Namespace}, opsRequest)).Should(Succeed())
+		Expect(opsRequest.Spec.Type).Should(Equal(opsv1alpha1.StartType))
+	})
+
+	It("skips the window when other OpsRequests are running", func() {
+		cluster := newCluster(appsv1.UpdatingClusterPhase)
+		opsRecorders, _ := json.Marshal([]opsv1alpha1.OpsRecorder{{Name: "running-ops", Type: opsv1alpha1.VerticalScalingType}})
+		cluster.Annotations = map[string]string{constant.OpsRequestAnnotationKey: string(opsRecorders)}
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{
+			NextTransition:     appsv1.HibernationStop,
+			NextTransitionTime: &metav1.Time{Time: stopTime},
+		}
+		r := newReconciler(cluster)
+
+		_, err := r.reconcileHibernation(reqCtx, cluster, stopTime)
+		Expect(err).ShouldNot(HaveOccurred())
+		status := cluster.Status.Hibernation
+		Expect(status.LastTransition).Should(Equal(appsv1.HibernationStop))
+		Expect(status.LastOpsRequest).Should(BeEmpty())
+		Expect(status.Message).Should(ContainSubstring("running-ops"))
+		Expect(status.NextTransition).Should(Equal(appsv1.HibernationStart))
+
+		opsList := &opsv1alpha1.OpsRequestList{}
+		Expect(r.Client.List(reqCtx.Ctx, opsList)).Should(Succeed())
+		Expect(opsList.Items).Should(BeEmpty())
+	})
+
+	It("skips the window when it is missed for longer than the deadline", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{
+			NextTransition:     appsv1.HibernationStop,
+			NextTransitionTime: &metav1.Time{Time: stopTime},
+		}
+		r := newReconciler(cluster)
+
+		_, err := r.reconcileHibernation(reqCtx, cluster, stopTime.Add(time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.Status.Hibernation.LastOpsRequest).Should(BeEmpty())
+		Expect(cluster.Status.Hibernation.Message).Should(ContainSubstring("deadline"))
+	})
+
+	It("reports the invalid schedule", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		cluster.Spec.Hibernation.StopSchedule = "0 25 * * *"
+		r := newReconciler(cluster)
+
+		requeueAfter, err := r.reconcileHibernation(reqCtx, cluster, stopTime)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requeueAfter).Should(BeZero())
+		Expect(cluster.Status.Hibernation.NextTransitionTime).Should(BeNil())
+		Expect(cluster.Status.Hibernation.Message).Should(HavePrefix(invalidHibernationScheduleMessagePrefix))
+	})
+
+	It("clears the status when the hibernation schedule is removed", func() {
+		cluster := newCluster(appsv1.RunningClusterPhase)
+		cluster.Spec.Hibernation = nil
+		cluster.Status.Hibernation = &appsv1.ClusterHibernationStatus{NextTransition: appsv1.HibernationStop}
+		r := newReconciler(cluster)
+
+		_, err := r.reconcileHibernation(reqCtx, cluster, stopTime)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.Status.Hibernation).Should(BeNil())
+	})
+})
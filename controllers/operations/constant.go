@@ -30,3 +30,8 @@ const (
 	reasonOpsReconcileStatusFailed    = "ReconcileStatusFailed"
 	reasonOpsDoActionFailed           = "DoActionFailed"
 )
+
+const (
+	reasonHibernationTransition = "HibernationTransition"
+	reasonHibernationSkipped    = "HibernationSkipped"
+)
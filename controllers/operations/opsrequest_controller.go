@@ -66,6 +66,7 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
 // +kubebuilder:rbac:groups=workloads.kubeblocks.io,resources=instances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
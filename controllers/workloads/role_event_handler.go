@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package workloads
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -59,6 +60,23 @@ type roleProbeOutput struct {
 	role                    string
 	authoritativeVersion    uint64
 	hasAuthoritativeVersion bool
+	replication             *workloads.ReplicationStatus
+}
+
+// structuredRoleProbeOutput is the structured form of the roleProbe stdout, a JSON object such as:
+//
+//	{"role": "secondary", "version": 12, "term": 5, "lag": 1024, "leader": "mysql-0"}
+//
+// The version has the same semantics as the one in the versioned form, and the
+// term, lag and leader are reported as the replication status of the replica.
+// Since kbagent reports an event whenever the output changes, the probe script
+// should report the lag at a coarse granularity.
+type structuredRoleProbeOutput struct {
+	Role    string  `json:"role"`
+	Version *uint64 `json:"version,omitempty"`
+	Term    *int64  `json:"term,omitempty"`
+	Lag     *int64  `json:"lag,omitempty"`
+	Leader  string  `json:"leader,omitempty"`
 }
 
 type roleEventResult struct {
@@ -299,10 +317,16 @@ func isRoleProbeEvent(event *corev1.Event) bool {
 // A probe script that emits a non-uint64 second token or three or more tokens
 // is a parse error. Falling back to EventTime would let a typo bypass the
 // authoritative-version ordering the probe script meant to use.
+//
+// The stdout starting with '{' is parsed as the structured form, see
+// structuredRoleProbeOutput. A structured output without a role is a parse error.
 func parseRoleProbeOutput(stdout []byte) (roleProbeOutput, error) {
 	if len(stdout) == 0 {
 		return roleProbeOutput{}, nil
 	}
+	if trimmed := bytes.TrimSpace(stdout); len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseStructuredRoleProbeOutput(trimmed)
+	}
 	tokens := strings.Fields(string(stdout))
 	switch len(tokens) {
 	case 0:
@@ -330,6 +354,30 @@ func parseRoleProbeOutput(stdout []byte) (roleProbeOutput, error) {
 	}
 }
 
+func parseStructuredRoleProbeOutput(stdout []byte) (roleProbeOutput, error) {
+	structured := structuredRoleProbeOutput{}
+	if err := json.Unmarshal(stdout, &structured); err != nil {
+		return roleProbeOutput{}, fmt.Errorf("invalid structured role probe output: %w", err)
+	}
+	role := strings.ToLower(strings.TrimSpace(structured.Role))
+	if role == "" {
+		return roleProbeOutput{}, fmt.Errorf("invalid structured role probe output: role is required")
+	}
+	output := roleProbeOutput{
+		role: role,
+		replication: &workloads.ReplicationStatus{
+			Term:   structured.Term,
+			Lag:    structured.Lag,
+			Leader: structured.Leader,
+		},
+	}
+	if structured.Version != nil {
+		output.authoritativeVersion = *structured.Version
+		output.hasAuthoritativeVersion = true
+	}
+	return output, nil
+}
+
 // acceptRoleProbeEvent decides whether to accept a parsed roleProbe event for
 // a particular Pod. Each output form is gated by its own staleness anchor:
 //
@@ -407,6 +455,8 @@ func resolveRoleEventBranchByControllerRef(pod *corev1.Pod) (roleEventBranch, st
 // LastRoleAuthoritativeVersionAnnotationKey only; single-token results stamp
 // LastRoleEventVersionAnnotationKey only. The other key is left untouched so
 // that a migration window does not silently downgrade either stream's anchor.
+// The replication status of structured results is recorded in
+// RoleReplicationStatusAnnotationKey, and it is removed by the plain results.
 func updatePodRoleLabel(ctx context.Context, cli client.Client, pod *corev1.Pod, roleName string, roleDefined bool, eventVersion string, parsed roleProbeOutput) error {
 	newPod := pod.DeepCopy()
 	if newPod.Labels == nil {
@@ -425,6 +475,15 @@ func updatePodRoleLabel(ctx context.Context, cli client.Client, pod *corev1.Pod,
 	} else {
 		newPod.Annotations[constant.LastRoleEventVersionAnnotationKey] = eventVersion
 	}
+	if parsed.replication != nil {
+		replication, err := json.Marshal(parsed.replication)
+		if err != nil {
+			return err
+		}
+		newPod.Annotations[constant.RoleReplicationStatusAnnotationKey] = string(replication)
+	} else {
+		delete(newPod.Annotations, constant.RoleReplicationStatusAnnotationKey)
+	}
 	if reflect.DeepEqual(newPod.Labels, pod.Labels) && reflect.DeepEqual(newPod.Annotations, pod.Annotations) {
 		return nil
 	}
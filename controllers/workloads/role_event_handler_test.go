@@ -118,6 +118,38 @@ func TestParseRoleProbeOutputWhitespaceOnly(t *testing.T) {
 	}
 }
 
+func TestParseRoleProbeOutputStructured(t *testing.T) {
+	out, err := parseRoleProbeOutput([]byte(`  {"role": "Secondary", "version": 12, "term": 5, "lag": 1024, "leader": "mysql-0", "extra": true}` + "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.role != "secondary" || !out.hasAuthoritativeVersion || out.authoritativeVersion != 12 {
+		t.Fatalf("got %+v, want role=secondary authoritativeVersion=12", out)
+	}
+	replication := out.replication
+	if replication == nil || *replication.Term != 5 || *replication.Lag != 1024 || replication.Leader != "mysql-0" {
+		t.Fatalf("got replication %+v, want term=5 lag=1024 leader=mysql-0", replication)
+	}
+}
+
+func TestParseRoleProbeOutputStructuredWithoutVersion(t *testing.T) {
+	out, err := parseRoleProbeOutput([]byte(`{"role": "primary", "lag": 0}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.role != "primary" || out.hasAuthoritativeVersion || out.replication == nil || out.replication.Term != nil {
+		t.Fatalf("got %+v, want role=primary without authoritative version", out)
+	}
+}
+
+func TestParseRoleProbeOutputStructuredMalformed(t *testing.T) {
+	for _, output := range []string{`{"role": "primary"`, `{"lag": 10}`, `{"role": "primary", "version": -1}`} {
+		if out, err := parseRoleProbeOutput([]byte(output)); err == nil {
+			t.Fatalf("got nil error for %q: %+v", output, out)
+		}
+	}
+}
+
 // --- gate tests: each path consults only its own annotation key ---
 
 func TestAcceptRoleProbeEventVersionedRejectsOlderVersion(t *testing.T) {
@@ -557,6 +589,47 @@ func TestRoleEventHandlerHandlesInstanceRoleWithoutExclusiveCleanup(t *testing.T
 	assertPodRole(t, ctx, cli, pod, "leader", fmt.Sprintf("%d", event.EventTime.UnixMicro()))
 }
 
+func TestRoleEventHandlerRecordsReplicationStatusOfStructuredOutput(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	its := &workloads.InstanceSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"},
+		Spec:       workloads.InstanceSetSpec{Roles: []workloads.ReplicaRole{{Name: "leader", IsExclusive: true}, {Name: "follower"}}},
+	}
+	pod := roleEventPod("default", "mysql-1", "uid-1", map[string]string{
+		instanceset.WorkloadsInstanceLabelKey: "mysql",
+	})
+	event := roleProbeEventWithOutput("default", "event-1", pod, `{"role": "follower", "version": 3, "term": 2, "lag": 64, "leader": "mysql-0"}`, now)
+	plainEvent := roleProbeEventWithOutput("default", "event-2", pod, "follower 4", now.Add(time.Second))
+	cli := roleEventFakeClient(t, its, pod, event, plainEvent)
+
+	if handled := handleRoleEvent(t, ctx, cli, event); !handled {
+		t.Fatalf("expected event to be handled")
+	}
+	assertPodLastRoleAuthoritativeVersion(t, ctx, cli, pod, "3")
+	got := &corev1.Pod{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(pod), got); err != nil {
+		t.Fatalf("get pod: %v", err)
+	}
+	replication := intctrlutil.GetPodReplicationStatus(got)
+	if got.Labels[constant.RoleLabelKey] != "follower" || replication == nil ||
+		*replication.Term != 2 || *replication.Lag != 64 || replication.Leader != "mysql-0" {
+		t.Fatalf("got role %q and replication %+v", got.Labels[constant.RoleLabelKey], replication)
+	}
+
+	// the plain output removes the replication status reported before
+	if handled := handleRoleEvent(t, ctx, cli, plainEvent); !handled {
+		t.Fatalf("expected event to be handled")
+	}
+	assertPodLastRoleAuthoritativeVersion(t, ctx, cli, pod, "4")
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(pod), got); err != nil {
+		t.Fatalf("get pod: %v", err)
+	}
+	if _, ok := got.Annotations[constant.RoleReplicationStatusAnnotationKey]; ok {
+		t.Fatalf("expected the replication status removed, got %v", got.Annotations)
+	}
+}
+
 func TestRoleEventHandlerDeletesUndefinedInstanceSetRole(t *testing.T) {
 	ctx := context.Background()
 	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -0,0 +1,199 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression in the standard five fields format:
+// minute, hour, day of month, month and day of week.
+// See https://en.wikipedia.org/wiki/Cron.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar indicate whether the day of month and the day of week fields are "*",
+	// a day matches if both fields match when either of them is "*", or either field matches otherwise.
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+type cronField struct {
+	name   string
+	min    int
+	max    int
+	values map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, values: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as Sunday as well.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, values: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseCronSchedule parses the cron expression, the schedule is evaluated in the location, UTC is used if it is nil.
+func ParseCronSchedule(expr string, location *time.Location) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	s := &CronSchedule{
+		domStar:  fields[2] == "*" || fields[2] == "?",
+		dowStar:  fields[4] == "*" || fields[4] == "?",
+		location: location,
+	}
+	var err error
+	for _, f := range []struct {
+		bits  *uint64
+		field cronField
+		expr  string
+	}{
+		{&s.minute, cronMinute, fields[0]},
+		{&s.hour, cronHour, fields[1]},
+		{&s.dom, cronDom, fields[2]},
+		{&s.month, cronMonth, fields[3]},
+		{&s.dow, cronDow, fields[4]},
+	} {
+		if *f.bits, err = f.field.parse(f.expr); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	// fold Sunday as 7 into 0
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func (f cronField) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangeExpr = item[:i]
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q of the %s field", item[i+1:], f.name)
+			}
+		}
+		start, end := f.min, f.max
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q of the %s field", rangeExpr, f.name)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangeExpr); err != nil {
+				return 0, err
+			}
+			// "a/n" means from a to the max value with step n
+			if step == 1 {
+				end = start
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(expr string) (int, error) {
+	if v, ok := f.values[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of the %s field", expr, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d of the %s field is out of range [%d, %d]", v, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the earliest time matching the schedule after t, or the zero time if there is none in the next five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.location)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, s.location).Add(time.Minute)
+	yearLimit := t.Year() + 5
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location).Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
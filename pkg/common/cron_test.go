@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, expr := range []string{
+		"* * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 * *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCronSchedule(expr, nil); err == nil {
+			t.Errorf("expected error for cron expression %q", expr)
+		}
+	}
+	for _, expr := range []string{
+		"0 20 * * 1-5",
+		"*/15 8-18 * * MON-FRI",
+		"0 0 1,15 jan-jun *",
+		"30 7 * * 7",
+		"5/10 * ? * *",
+	} {
+		if _, err := ParseCronSchedule(expr, nil); err != nil {
+			t.Errorf("unexpected error for cron expression %q: %s", expr, err)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+	cases := []struct {
+		expr     string
+		location *time.Location
+		from     string
+		expected string
+	}{
+		{"0 20 * * 1-5", nil, "2026-05-04T19:59:30Z", "2026-05-04T20:00:00Z"},
+		{"0 20 * * 1-5", nil, "2026-05-04T20:00:00Z", "2026-05-05T20:00:00Z"},
+		// Friday to Monday
+		{"0 8 * * MON-FRI", nil, "2026-05-08T09:00:00Z", "2026-05-11T08:00:00Z"},
+		{"*/15 * * * *", nil, "2026-05-04T10:07:00Z", "2026-05-04T10:15:00Z"},
+		{"0 0 1 * *", nil, "2026-12-15T00:00:00Z", "2027-01-01T00:00:00Z"},
+		{"0 0 29 2 *", nil, "2026-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		// Sunday as 7
+		{"30 7 * * 7", nil, "2026-05-04T00:00:00Z", "2026-05-10T07:30:00Z"},
+		// either the day of month or the day of week matches
+		{"0 0 15 * 1", nil, "2026-05-05T00:00:00Z", "2026-05-11T00:00:00Z"},
+		{"5/20 * * * *", nil, "2026-05-04T10:30:00Z", "2026-05-04T10:45:00Z"},
+		// evaluated in the location
+		{"0 20 * * *", shanghai, "2026-05-04T11:00:00Z", "2026-05-04T12:00:00Z"},
+	}
+	for _, c := range cases {
+		schedule, err := ParseCronSchedule(c.expr, c.location)
+		if err != nil {
+			t.Fatalf("unexpected error for cron expression %q: %s", c.expr, err)
+		}
+		from, _ := time.Parse(time.RFC3339, c.from)
+		expected, _ := time.Parse(time.RFC3339, c.expected)
+		if next := schedule.Next(from); !next.Equal(expected) {
+			t.Errorf("cron expression %q from %s: expected %s, got %s", c.expr, c.from, c.expected, next.UTC().Format(time.RFC3339))
+		}
+	}
+}
@@ -44,6 +44,10 @@ const (
 	LastRoleAuthoritativeVersionAnnotationKey = "apps.kubeblocks.io/last-role-authoritative-version"
 	ComponentScaleInAnnotationKey             = "apps.kubeblocks.io/component-scale-in" // ComponentScaleInAnnotationKey specifies whether the component is scaled in
 
+	// RoleReplicationStatusAnnotationKey records the replication status, in JSON, from the most recent structured
+	// roleProbe result the controller accepted on a Pod. It is removed once a plain roleProbe result is accepted.
+	RoleReplicationStatusAnnotationKey = "apps.kubeblocks.io/role-replication-status"
+
 	// SystemAccountProvisionedAnnotationKey marks a system account secret whose account has already been prepared externally.
 	SystemAccountProvisionedAnnotationKey = "apps.kubeblocks.io/system-account-provisioned"
 
@@ -54,11 +58,31 @@ const (
 	// The mutation check is only applied to the fields that are declared as immutable.
 	SkipImmutableCheckAnnotationKey = "apps.kubeblocks.io/skip-immutable-check"
 
+	// MaintenanceWindowAnnotationKey specifies the maintenance window, in JSON, for all clusters in the namespace.
+	// It is set on the namespace, and the Cluster's spec.maintenanceWindow takes precedence over it.
+	// An invalid window annotated is ignored with a warning event, rather than blocking the clusters in the namespace.
+	MaintenanceWindowAnnotationKey = "apps.kubeblocks.io/maintenance-window"
+
+	// BypassMaintenanceWindowAnnotationKey specifies to bypass the maintenance window for the spec changes of a cluster.
+	// It is set to "true" by users for urgent changes, or to the name of the OpsRequest that is allowed to start.
+	BypassMaintenanceWindowAnnotationKey = "apps.kubeblocks.io/bypass-maintenance-window"
+
 	// NodeSelectorOnceAnnotationKey adds nodeSelector in podSpec for one pod exactly once
 	NodeSelectorOnceAnnotationKey = "workloads.kubeblocks.io/node-selector-once"
 
 	PVCNamePrefixAnnotationKey = "apps.kubeblocks.io/pvc-name-prefix"
 
+	// annotations to tune the data transfer of new replicas, they are specified on the component.
+	NewReplicaTimeoutSecondsAnnotationKey = "apps.kubeblocks.io/new-replica-timeout-seconds" // the timeout of the whole transfer
+	NewReplicaRateLimitAnnotationKey      = "apps.kubeblocks.io/new-replica-rate-limit"      // the bandwidth limit in bytes per second, e.g. 100Mi
+	NewReplicaCompressionAnnotationKey    = "apps.kubeblocks.io/new-replica-compression"     // the compression on the wire: gzip or zstd
+	NewReplicaResumableAnnotationKey      = "apps.kubeblocks.io/new-replica-resumable"       // whether the transfer can be resumed from the offset
+
+	// ReplicaTaskAnnotationKey requests a replica task, in JSON, on the component. The kind, the target replicas and
+	// the payload of the kind are specified, e.g. {"task":"compactStorage","replicas":"pod-0","compactStorage":{"action":"compact"}}.
+	// The task is run by the kb-agent worker when the target replicas are started, and the request is issued only once.
+	ReplicaTaskAnnotationKey = "apps.kubeblocks.io/replica-task"
+
 	RestoreSourceAPIGroupAnnotationKey  = "apps.kubeblocks.io/restore-source-api-group"
 	RestoreSourceKindAnnotationKey      = "apps.kubeblocks.io/restore-source-kind"
 	RestoreSourceNameAnnotationKey      = "apps.kubeblocks.io/restore-source-name"
@@ -34,4 +34,6 @@ const (
 	ReasonRunTaskFailed = "RunTaskFailed"
 	// ReasonDeleteFailed delete failed
 	ReasonDeleteFailed = "DeleteFailed"
+	// ReasonInvalidMaintenanceWindow the maintenance window is invalid
+	ReasonInvalidMaintenanceWindow = "InvalidMaintenanceWindow"
 )
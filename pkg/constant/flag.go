@@ -39,4 +39,23 @@ const (
 	// FeatureGateInPlacePodVerticalScaling specifies to enable in-place pod vertical scaling
 	// NOTE: This feature depends on the InPlacePodVerticalScaling feature of the K8s cluster in which the KubeBlocks runs.
 	FeatureGateInPlacePodVerticalScaling = "IN_PLACE_POD_VERTICAL_SCALING"
+
+	// FeatureGateKBAgentAuthentication specifies to secure the kb-agent servers with a per-component token and mTLS certificate.
+	FeatureGateKBAgentAuthentication = "KBAGENT_AUTHENTICATION"
+
+	// FeatureGateKBAgentTaskJournal specifies to journal the state of kb-agent tasks, to resume or fail them cleanly after restarts.
+	FeatureGateKBAgentTaskJournal = "KBAGENT_TASK_JOURNAL"
+
+	// FeatureGateKBAgentStatusReport specifies to report the kb-agent probe and task results through the per-pod
+	// status object instead of events, so that the results are neither aggregated nor lost.
+	FeatureGateKBAgentStatusReport = "KBAGENT_STATUS_REPORT"
+
+	// FeatureGateKBAgentAuditLog specifies to keep the audit log of kb-agent action invocations on a pod-scoped volume,
+	// and to attach the audit summary of the failed lifecycle actions to the Component conditions.
+	FeatureGateKBAgentAuditLog = "KBAGENT_AUDIT_LOG"
+
+	// FeatureGateKBAgentFileService specifies to allow the kb-agent file service to operate the files under the writable
+	// volumes shared with the engine containers, so that files can be shipped without waiting for the volume propagation.
+	// It requires the FeatureGateKBAgentAuthentication to be enabled.
+	FeatureGateKBAgentFileService = "KBAGENT_FILE_SERVICE"
 )
@@ -56,6 +56,13 @@ const (
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
 
+	// kb-agent client config keys
+	CfgKBAgentClientFailureThreshold   = "KBAGENT_CLIENT_FAILURE_THRESHOLD"
+	CfgKBAgentClientCircuitOpenSeconds = "KBAGENT_CLIENT_CIRCUIT_OPEN_SECONDS"
+	CfgKBAgentClientMaxRetries         = "KBAGENT_CLIENT_MAX_RETRIES"
+	CfgKBAgentClientRetryBackoffMS     = "KBAGENT_CLIENT_RETRY_BACKOFF_MS"
+	CfgKBAgentClientIdempotentActions  = "KBAGENT_CLIENT_IDEMPOTENT_ACTIONS"
+
 	CfgRegistries     = "registries"
 	I18nResourcesName = "I18N_RESOURCES_NAME"
 )
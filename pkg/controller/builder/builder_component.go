@@ -106,6 +106,11 @@ func (builder *ComponentBuilder) SetInstanceUpdateStrategy(strategy *appsv1.Inst
 	return builder
 }
 
+func (builder *ComponentBuilder) SetDisruptionBudget(budget *appsv1.DisruptionBudget) *ComponentBuilder {
+	builder.get().Spec.DisruptionBudget = budget
+	return builder
+}
+
 func (builder *ComponentBuilder) SetResources(resources corev1.ResourceRequirements) *ComponentBuilder {
 	builder.get().Spec.Resources = resources
 	return builder
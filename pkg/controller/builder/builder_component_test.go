@@ -39,6 +39,9 @@ var _ = Describe("component builder", func() {
 			Type: appsv1.OnDeleteStrategyType,
 		}
 		concurrency := intstr.FromString("50%")
+		disruptionBudget := &appsv1.DisruptionBudget{
+			MaxUnavailable: ptr.To(intstr.FromInt32(2)),
+		}
 		issuer := &appsv1.Issuer{Name: appsv1.IssuerKubeBlocks}
 		disableExporter := true
 		stop := true
@@ -76,6 +79,7 @@ var _ = Describe("component builder", func() {
 			SetPodUpdatePolicy(&podUpdatePolicy).
 			SetPodUpgradePolicy(&podUpgradePolicy).
 			SetInstanceUpdateStrategy(instanceUpdateStrategy).
+			SetDisruptionBudget(disruptionBudget).
 			SetResources(resources).
 			SetDisableExporter(&disableExporter).
 			SetTLSConfig(true, issuer).
@@ -119,6 +123,7 @@ var _ = Describe("component builder", func() {
 		Expect(obj.Spec.PodUpdatePolicy).Should(Equal(&podUpdatePolicy))
 		Expect(obj.Spec.PodUpgradePolicy).Should(Equal(&podUpgradePolicy))
 		Expect(obj.Spec.InstanceUpdateStrategy).Should(Equal(instanceUpdateStrategy))
+		Expect(obj.Spec.DisruptionBudget).Should(Equal(disruptionBudget))
 		Expect(obj.Spec.Resources).Should(Equal(resources))
 		Expect(obj.Spec.DisableExporter).Should(Equal(&disableExporter))
 		Expect(obj.Spec.TLSConfig).Should(Equal(&appsv1.TLSConfig{Enable: true, Issuer: issuer}))
@@ -120,6 +120,11 @@ func (builder *InstanceSetBuilder) SetInstanceUpdateStrategy(strategy *workloads
 	return builder
 }
 
+func (builder *InstanceSetBuilder) SetMaintenanceWindow(window *workloads.MaintenanceWindow) *InstanceSetBuilder {
+	builder.get().Spec.MaintenanceWindow = window
+	return builder
+}
+
 func (builder *InstanceSetBuilder) SetMemberUpdateStrategy(strategy *workloads.MemberUpdateStrategy) *InstanceSetBuilder {
 	builder.get().Spec.MemberUpdateStrategy = strategy
 	return builder
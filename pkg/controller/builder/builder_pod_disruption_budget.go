@@ -0,0 +1,55 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type PodDisruptionBudgetBuilder struct {
+	BaseBuilder[policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudget, PodDisruptionBudgetBuilder]
+}
+
+func NewPodDisruptionBudgetBuilder(namespace, name string) *PodDisruptionBudgetBuilder {
+	builder := &PodDisruptionBudgetBuilder{}
+	builder.init(namespace, name, &policyv1.PodDisruptionBudget{}, builder)
+	return builder
+}
+
+func (builder *PodDisruptionBudgetBuilder) SetSelectorMatchLabel(labels map[string]string) *PodDisruptionBudgetBuilder {
+	matchLabels := make(map[string]string, len(labels))
+	for k, v := range labels {
+		matchLabels[k] = v
+	}
+	builder.get().Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	return builder
+}
+
+func (builder *PodDisruptionBudgetBuilder) SetMaxUnavailable(maxUnavailable *intstr.IntOrString) *PodDisruptionBudgetBuilder {
+	builder.get().Spec.MaxUnavailable = maxUnavailable
+	return builder
+}
+
+func (builder *PodDisruptionBudgetBuilder) SetMinAvailable(minAvailable *intstr.IntOrString) *PodDisruptionBudgetBuilder {
+	builder.get().Spec.MinAvailable = minAvailable
+	return builder
+}
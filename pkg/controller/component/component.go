@@ -81,6 +81,7 @@ func BuildComponent(cluster *appsv1.Cluster, compSpec *appsv1.ClusterComponentSp
 		SetPodUpdatePolicy(compSpec.PodUpdatePolicy).
 		SetPodUpgradePolicy(compSpec.PodUpgradePolicy).
 		SetInstanceUpdateStrategy(compSpec.InstanceUpdateStrategy).
+		SetDisruptionBudget(compSpec.DisruptionBudget).
 		SetVolumeClaimTemplates(compSpec.VolumeClaimTemplates).
 		SetPVCRetentionPolicy(compSpec.PersistentVolumeClaimRetentionPolicy).
 		SetVolumes(compSpec.Volumes).
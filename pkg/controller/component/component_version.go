@@ -291,6 +291,8 @@ func actionsToResolveImage(compDef *appsv1.ComponentDefinition) map[string]*apps
 		normalize("dataLoad"):         compDef.Spec.LifecycleActions.DataLoad,
 		normalize("reconfigure"):      compDef.Spec.LifecycleActions.Reconfigure,
 		normalize("accountProvision"): compDef.Spec.LifecycleActions.AccountProvision,
+		normalize("preUpgrade"):       compDef.Spec.LifecycleActions.PreUpgrade,
+		normalize("postUpgrade"):      compDef.Spec.LifecycleActions.PostUpgrade,
 	}
 	if compDef.Spec.LifecycleActions.RoleProbe != nil {
 		actions[normalize("roleProbe")] = &compDef.Spec.LifecycleActions.RoleProbe.Action
@@ -23,11 +23,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -64,10 +67,15 @@ func IsKBAgentContainer(c *corev1.Container) bool {
 	return c.Name == kbagent.ContainerName || c.Name == kbagent.ContainerName4Worker || c.Name == kbagent.InitContainerName
 }
 
-func UpdateKBAgentContainer4HostNetwork(synthesizedComp *SynthesizedComponent) {
+func UpdateKBAgentContainer4HostNetwork(synthesizedComp *SynthesizedComponent) error {
 	idx, c := intctrlutil.GetContainerByName(synthesizedComp.PodSpec.Containers, kbagent.ContainerName)
 	if c == nil {
-		return
+		return nil
+	}
+
+	// the named ports referenced by actions may have been allocated with new host ports
+	if err := updateKBAgentStartupEnvs(synthesizedComp); err != nil {
+		return err
 	}
 
 	port := func(name string) int {
@@ -80,7 +88,7 @@ func UpdateKBAgentContainer4HostNetwork(synthesizedComp *SynthesizedComponent) {
 	}
 	httpPort := port(kbagent.DefaultHTTPPortName)
 	if httpPort == 0 {
-		return
+		return nil
 	}
 
 	updatePortInArgs := func(arg string, port int) {
@@ -93,6 +101,7 @@ func UpdateKBAgentContainer4HostNetwork(synthesizedComp *SynthesizedComponent) {
 	}
 	updatePortInArgs("--port", httpPort)
 	updatePortInArgs("--streaming-port", port(kbagent.DefaultStreamingPortName))
+	updatePortInArgs("--metrics-port", port(kbagent.DefaultMetricsPortName))
 
 	// update startup probe
 	if c.StartupProbe != nil && c.StartupProbe.TCPSocket != nil {
@@ -100,10 +109,37 @@ func UpdateKBAgentContainer4HostNetwork(synthesizedComp *SynthesizedComponent) {
 	}
 
 	synthesizedComp.PodSpec.Containers[idx] = *c
+	return nil
+}
+
+// updateKBAgentStartupEnvs rebuilds the startup envs of the kb-agent containers from the current pod spec.
+func updateKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) error {
+	envVars, err := buildKBAgentStartupEnvs(synthesizedComp)
+	if err != nil {
+		return err
+	}
+	update := func(containers []corev1.Container) {
+		for i := range containers {
+			if !IsKBAgentContainer(&containers[i]) {
+				continue
+			}
+			for j, env := range containers[i].Env {
+				for _, newEnv := range envVars {
+					if env.Name == newEnv.Name {
+						containers[i].Env[j] = newEnv
+					}
+				}
+			}
+		}
+	}
+	update(synthesizedComp.PodSpec.Containers)
+	update(synthesizedComp.PodSpec.InitContainers)
+	return nil
 }
 
-func buildKBAgentTaskEnv(task proto.Task) (map[string]string, error) {
-	envVar, err := kbagent.BuildEnv4Worker([]proto.Task{task})
+// mergeKBAgentTaskEnv merges the task into the tasks kept in the env, the in-flight tasks of other kinds are kept.
+func mergeKBAgentTaskEnv(envVars map[string]string, task proto.Task) (map[string]string, error) {
+	envVar, err := kbagent.MergeEnv4Worker(envVars, task)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +148,11 @@ func buildKBAgentTaskEnv(task proto.Task) (map[string]string, error) {
 	}, nil
 }
 
+// KBAgentTaskEnv returns the env of the tasks kept in the env vars, it returns nil if there is no task.
+func KBAgentTaskEnv(envVars map[string]string) (map[string]string, error) {
+	return updateKBAgentTaskEnv(envVars, nil)
+}
+
 func updateKBAgentTaskEnv(envVars map[string]string, f func(proto.Task) *proto.Task) (map[string]string, error) {
 	envVar, err := kbagent.UpdateEnv4Worker(envVars, f)
 	if err != nil {
@@ -154,8 +195,11 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 	}
 
 	container, err := newContainer(kbagent.ContainerName, func(b *builder.ContainerBuilder) error {
-		ports, err1 := getAvailablePorts(synthesizedComp.PodSpec.Containers,
-			[]int32{int32(kbagent.DefaultHTTPPort), int32(kbagent.DefaultStreamingPort)})
+		defaultPorts := []int32{int32(kbagent.DefaultHTTPPort), int32(kbagent.DefaultStreamingPort)}
+		if KBAgentAuthEnabled() {
+			defaultPorts = append(defaultPorts, int32(kbagent.DefaultMetricsPort))
+		}
+		ports, err1 := getAvailablePorts(synthesizedComp.PodSpec.Containers, defaultPorts)
 		if err1 != nil {
 			return err1
 		}
@@ -177,6 +221,17 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 				ProbeHandler: corev1.ProbeHandler{
 					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(httpPort)},
 				}})
+		// the HTTP server requires the client certificate once the auth is enabled,
+		// serve the metrics on a dedicated plain port to keep them scrapable
+		if len(ports) > 2 {
+			metricsPort := int(ports[2])
+			b.AddArgs("--metrics-port", strconv.Itoa(metricsPort)).
+				AddPorts(corev1.ContainerPort{
+					ContainerPort: int32(metricsPort),
+					Name:          kbagent.DefaultMetricsPortName,
+					Protocol:      corev1.ProtocolTCP,
+				})
+		}
 		return nil
 	})
 	if err != nil {
@@ -199,6 +254,24 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 		return err
 	}
 
+	if err = mountKBAgentAuthSecret(synthesizedComp, container, workerContainer); err != nil {
+		return err
+	}
+
+	if err = mountKBAgentTaskJournal(synthesizedComp, workerContainer); err != nil {
+		return err
+	}
+
+	if err = mountKBAgentAuditLog(synthesizedComp, container); err != nil {
+		return err
+	}
+
+	setKBAgentReportChannel(container, workerContainer)
+
+	if err = setKBAgentFileAllowedPaths(synthesizedComp, container); err != nil {
+		return err
+	}
+
 	// set kb-agent container ports to host network
 	if synthesizedComp.HostNetwork != nil {
 		if synthesizedComp.HostNetwork.ContainerPorts == nil {
@@ -216,6 +289,15 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 					Ports:     []string{kbagent.DefaultStreamingPortName},
 				},
 			}...)
+		if slices.ContainsFunc(container.Ports, func(p corev1.ContainerPort) bool {
+			return p.Name == kbagent.DefaultMetricsPortName
+		}) {
+			synthesizedComp.HostNetwork.ContainerPorts = append(synthesizedComp.HostNetwork.ContainerPorts,
+				appsv1.HostNetworkContainerPort{
+					Container: container.Name,
+					Ports:     []string{kbagent.DefaultMetricsPortName},
+				})
+		}
 	}
 
 	synthesizedComp.PodSpec.Containers = append(synthesizedComp.PodSpec.Containers, *container)
@@ -266,6 +348,131 @@ func mountPodRoleLabelFile(synthesizedComp *SynthesizedComponent, container *cor
 	return nil
 }
 
+// KBAgentAuthEnabled checks whether the kb-agent servers should be secured with the per-component credential.
+func KBAgentAuthEnabled() bool {
+	return viper.GetBool(constant.FeatureGateKBAgentAuthentication)
+}
+
+func mountKBAgentAuthSecret(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) error {
+	if !KBAgentAuthEnabled() {
+		return nil
+	}
+	for _, v := range synthesizedComp.PodSpec.Volumes {
+		if v.Name == kbagent.AuthVolumeName {
+			return fmt.Errorf("volume %s conflicts with kbagent auth volume", kbagent.AuthVolumeName)
+		}
+	}
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbagent.AuthVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  kbagent.AuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+				Items:       kbagent.AuthSecretItems(),
+				DefaultMode: ptr.To(int32(0444)),
+			},
+		},
+	})
+	for _, c := range containers {
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      kbagent.AuthVolumeName,
+			MountPath: kbagent.AuthMountPath,
+			ReadOnly:  true,
+		})
+		c.Args = append(c.Args, kbagent.AuthArgs()...)
+	}
+	return nil
+}
+
+// mountKBAgentTaskJournal mounts a pod-scoped volume for the worker to journal tasks, so that the tasks
+// interrupted by the restart of the worker can be resumed or failed explicitly, instead of being re-run blindly.
+func mountKBAgentTaskJournal(synthesizedComp *SynthesizedComponent, container *corev1.Container) error {
+	if !viper.GetBool(constant.FeatureGateKBAgentTaskJournal) {
+		return nil
+	}
+	for _, v := range synthesizedComp.PodSpec.Volumes {
+		if v.Name == kbagent.TaskJournalVolumeName {
+			return fmt.Errorf("volume %s conflicts with kbagent task journal volume", kbagent.TaskJournalVolumeName)
+		}
+	}
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbagent.TaskJournalVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      kbagent.TaskJournalVolumeName,
+		MountPath: kbagent.TaskJournalMountPath,
+	})
+	container.Args = append(container.Args, "--task-journal-dir", kbagent.TaskJournalMountPath)
+	return nil
+}
+
+// mountKBAgentAuditLog mounts a pod-scoped volume for the server to keep the audit log of action invocations,
+// so that the history survives the restarts of the kb-agent container.
+func mountKBAgentAuditLog(synthesizedComp *SynthesizedComponent, container *corev1.Container) error {
+	if !viper.GetBool(constant.FeatureGateKBAgentAuditLog) {
+		return nil
+	}
+	for _, v := range synthesizedComp.PodSpec.Volumes {
+		if v.Name == kbagent.AuditLogVolumeName {
+			return fmt.Errorf("volume %s conflicts with kbagent audit log volume", kbagent.AuditLogVolumeName)
+		}
+	}
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbagent.AuditLogVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      kbagent.AuditLogVolumeName,
+		MountPath: kbagent.AuditLogMountPath,
+	})
+	container.Args = append(container.Args, "--audit-log-dir", kbagent.AuditLogMountPath)
+	return nil
+}
+
+// setKBAgentReportChannel makes kb-agent report the probe and task results through the status object if enabled.
+func setKBAgentReportChannel(containers ...*corev1.Container) {
+	if !viper.GetBool(constant.FeatureGateKBAgentStatusReport) {
+		return
+	}
+	for _, c := range containers {
+		c.Args = append(c.Args, "--report-channel", proto.ReportChannelStatus)
+	}
+}
+
+// setKBAgentFileAllowedPaths allows the kb-agent file service to operate the files under the writable volumes mounted,
+// the volumes managed by the kubelet, such as ConfigMap and Secret volumes, are read-only and excluded.
+// The file service requires the kb-agent servers to be authenticated, since it can write the files the engine runs with.
+func setKBAgentFileAllowedPaths(synthesizedComp *SynthesizedComponent, container *corev1.Container) error {
+	if !viper.GetBool(constant.FeatureGateKBAgentFileService) {
+		return nil
+	}
+	if !KBAgentAuthEnabled() {
+		return fmt.Errorf("the kb-agent file service requires the feature gate %s to be enabled", constant.FeatureGateKBAgentAuthentication)
+	}
+	// the volumes of the kb-agent itself are excluded too, including the shared volume holding the kb-agent binary
+	excludedVolumes := sets.New(sharedVolumeMount.Name, kbagent.AuditLogVolumeName, kbagent.TaskJournalVolumeName)
+	for _, v := range synthesizedComp.PodSpec.Volumes {
+		if v.ConfigMap != nil || v.Secret != nil || v.DownwardAPI != nil || v.Projected != nil {
+			excludedVolumes.Insert(v.Name)
+		}
+	}
+	paths := make([]string, 0)
+	for _, mount := range container.VolumeMounts {
+		if mount.ReadOnly || excludedVolumes.Has(mount.Name) || len(mount.SubPath) > 0 {
+			continue
+		}
+		paths = append(paths, mount.MountPath)
+	}
+	if len(paths) > 0 {
+		container.Args = append(container.Args, "--file-allowed-paths", strings.Join(paths, ","))
+	}
+	return nil
+}
+
 func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 	envSet := sets.New[string]()
@@ -294,6 +501,8 @@ func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.Env
 			synthesizedComp.LifecycleActions.DataLoad,
 			synthesizedComp.LifecycleActions.Reconfigure,
 			synthesizedComp.LifecycleActions.AccountProvision,
+			synthesizedComp.LifecycleActions.PreUpgrade,
+			synthesizedComp.LifecycleActions.PostUpgrade,
 		} {
 			checkedAppend(action)
 		}
@@ -352,6 +561,12 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.AccountProvision, "accountProvision"); a != nil {
 			actions = append(actions, *a)
 		}
+		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.PreUpgrade, "preUpgrade"); a != nil {
+			actions = append(actions, *a)
+		}
+		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.PostUpgrade, "postUpgrade"); a != nil {
+			actions = append(actions, *a)
+		}
 
 		if a, p := buildProbe4KBAgent(synthesizedComp.LifecycleActions.RoleProbe, "roleProbe", synthesizedComp.FullCompName); a != nil && p != nil {
 			p.ReportOnFileChange = []string{podMetadataMountPath}
@@ -373,9 +588,45 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		}
 	})
 
+	if err := resolveNamedPorts4KBAgent(synthesizedComp, actions); err != nil {
+		return nil, err
+	}
+
 	return kbagent.BuildEnv4Server(actions, probes, streaming)
 }
 
+// resolveNamedPorts4KBAgent resolves the named ports of TCP actions to the numeric ports defined in the containers,
+// kb-agent has no knowledge of the container spec.
+func resolveNamedPorts4KBAgent(synthesizedComp *SynthesizedComponent, actions []proto.Action) error {
+	for i := range actions {
+		if actions[i].TCP == nil {
+			continue
+		}
+		port, err := resolveNamedPort4KBAgent(synthesizedComp, actions[i].TCP.Port)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the port of action %s: %s", actions[i].Name, err.Error())
+		}
+		actions[i].TCP.Port = port
+	}
+	return nil
+}
+
+func resolveNamedPort4KBAgent(synthesizedComp *SynthesizedComponent, port string) (string, error) {
+	if _, err := strconv.Atoi(port); err == nil {
+		return port, nil
+	}
+	if synthesizedComp.PodSpec != nil {
+		for _, c := range synthesizedComp.PodSpec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == port {
+					return strconv.Itoa(int(p.ContainerPort)), nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("the named port %s is not found in the containers", port)
+}
+
 func probeReportPeriodSeconds(periodSeconds int32) int32 {
 	if periodSeconds <= 0 {
 		return defaultProbeReportPeriodSeconds
@@ -393,6 +644,7 @@ func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
 	a := &proto.Action{
 		Name:           name,
 		TimeoutSeconds: action.TimeoutSeconds,
+		SupportDryRun:  action.SupportDryRun,
 	}
 	if action.Exec != nil {
 		a.Exec = &proto.ExecAction{
@@ -427,9 +679,37 @@ func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
 			},
 		}
 	}
+	if action.TCP != nil {
+		a.TCP = &proto.TCPAction{
+			Port:    action.TCP.Port,
+			Host:    action.TCP.Host,
+			Payload: action.TCP.Payload,
+			Expect:  action.TCP.Expect,
+		}
+		if action.TCP.TLS != nil {
+			a.TCP.TLS = &proto.TCPTLSConfig{
+				ServerName:         action.TCP.TLS.ServerName,
+				InsecureSkipVerify: action.TCP.TLS.InsecureSkipVerify,
+			}
+		}
+	}
+	if action.Script != nil {
+		a.Script = &proto.ScriptAction{
+			Expression:  action.Script.Expression,
+			CostLimit:   action.Script.CostLimit,
+			MemoryLimit: action.Script.MemoryLimit,
+		}
+	}
 	if action.RetryPolicy != nil {
 		a.RetryPolicy = lifecycle.BuildKBAgentRetryPolicy(action.RetryPolicy)
 	}
+	if action.Lock != nil {
+		a.Lock = &proto.ActionLock{
+			Group:               action.Lock.Group,
+			Policy:              proto.ActionConflictPolicy(action.Lock.Policy),
+			QueueTimeoutSeconds: action.Lock.QueueTimeoutSeconds,
+		}
+	}
 	return a
 }
 
@@ -444,6 +724,9 @@ func buildProbe4KBAgent(probe *appsv1.Probe, name, instance string) (*proto.Acti
 		PeriodSeconds:       probe.PeriodSeconds,
 		SuccessThreshold:    probe.SuccessThreshold,
 		FailureThreshold:    probe.FailureThreshold,
+		JitterPercent:       probe.JitterPercent,
+		MaxBackoffSeconds:   probe.MaxBackoffSeconds,
+		MaxEventsPerMinute:  probe.MaxEventsPerMinute,
 		Instance:            instance,
 	}
 	return a, p
@@ -501,6 +784,8 @@ func customExecActionImageNContainer(synthesizedComp *SynthesizedComponent) (str
 			synthesizedComp.LifecycleActions.DataLoad,
 			synthesizedComp.LifecycleActions.Reconfigure,
 			synthesizedComp.LifecycleActions.AccountProvision,
+			synthesizedComp.LifecycleActions.PreUpgrade,
+			synthesizedComp.LifecycleActions.PostUpgrade,
 		}...)
 		if synthesizedComp.LifecycleActions.RoleProbe != nil && synthesizedComp.LifecycleActions.RoleProbe.Defined() {
 			actions = append(actions, &synthesizedComp.LifecycleActions.RoleProbe.Action)
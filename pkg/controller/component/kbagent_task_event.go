@@ -20,17 +20,57 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package component
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
+const (
+	// replicaTaskMessageKeyPrefix is the prefix of the keys in the Component status message,
+	// that keeps the progress of the replica tasks of each kind.
+	replicaTaskMessageKeyPrefix = "Task/"
+
+	ReplicaTaskPhaseRunning   = "Running"
+	ReplicaTaskPhaseSucceeded = "Succeeded"
+	ReplicaTaskPhaseFailed    = "Failed"
+
+	defaultReplicaTaskReportPeriodSeconds = 60
+)
+
+var (
+	// replicaTaskKinds are the kinds of the replica tasks that report the progress in the Component status.
+	replicaTaskKinds = sets.New(
+		proto.TaskKindResyncReplica,
+		proto.TaskKindRebuildReplica,
+		proto.TaskKindVerifyChecksum,
+		proto.TaskKindCompactStorage,
+	)
+)
+
+// ReplicaTaskProgress is the progress of a replica task on a replica.
+type ReplicaTaskProgress struct {
+	Replica   string     `json:"replica"`
+	UID       string     `json:"UID"`
+	Phase     string     `json:"phase"`
+	Message   string     `json:"message,omitempty"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
 type KBAgentTaskEventHandler struct{}
 
 func (h *KBAgentTaskEventHandler) Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) (bool, error) {
@@ -55,5 +95,149 @@ func (h *KBAgentTaskEventHandler) handleEvent(reqCtx intctrlutil.RequestCtx, cli
 	if event.Task == newReplicaTask {
 		return handleNewReplicaTaskEvent(reqCtx.Log, reqCtx.Ctx, cli, namespace, event)
 	}
+	if replicaTaskKinds.Has(event.Task) {
+		return handleReplicaTaskEvent(reqCtx.Ctx, cli, namespace, event)
+	}
 	return fmt.Errorf("unsupported kind of task event: %s", event.Task)
 }
+
+// ReplicaTask4Annotation builds the env of the replica task requested by the annotation of the Component,
+// it returns nil if there is no request, or the requested task has been issued.
+func ReplicaTask4Annotation(comp *appsv1.Component, compName string, envVars map[string]string) (map[string]string, error) {
+	v, ok := comp.Annotations[constant.ReplicaTaskAnnotationKey]
+	if !ok || len(v) == 0 {
+		return nil, nil
+	}
+	task := proto.Task{}
+	if err := json.Unmarshal([]byte(v), &task); err != nil {
+		return nil, fmt.Errorf("invalid value of annotation %s: %s", constant.ReplicaTaskAnnotationKey, err.Error())
+	}
+	if len(task.Replicas) == 0 {
+		return nil, fmt.Errorf("invalid value of annotation %s: the replicas are required", constant.ReplicaTaskAnnotationKey)
+	}
+	// the same request is issued only once, change the request to run it again
+	uid, err := intctrlutil.ComputeHash(task)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := GetReplicaTaskProgress(comp, task.Task)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(progress, func(p ReplicaTaskProgress) bool { return p.UID == uid }) {
+		return nil, nil
+	}
+	return ReplicaTask(envVars, compName, uid, strings.Split(task.Replicas, ","), task)
+}
+
+// ReplicaTask builds the env of a replica task, the payload of the kind should be set in the task.
+// The task is merged into the tasks kept in the env vars, and the previous task of the same kind is replaced.
+func ReplicaTask(envVars map[string]string, compName, uid string, replicas []string, task proto.Task) (map[string]string, error) {
+	if !replicaTaskKinds.Has(task.Task) {
+		return nil, fmt.Errorf("unsupported kind of replica task: %s", task.Task)
+	}
+	task.Instance = compName
+	task.UID = uid
+	task.Replicas = strings.Join(replicas, ",")
+	task.NotifyAtFinish = true
+	if task.ReportPeriodSeconds == 0 {
+		task.ReportPeriodSeconds = defaultReplicaTaskReportPeriodSeconds
+	}
+	return mergeKBAgentTaskEnv(envVars, task)
+}
+
+// GetReplicaTaskProgress returns the progress of the replica tasks of the kind kept in the Component status.
+func GetReplicaTaskProgress(comp *appsv1.Component, kind string) ([]ReplicaTaskProgress, error) {
+	message, ok := comp.Status.Message[replicaTaskMessageKeyPrefix+kind]
+	if !ok {
+		return nil, nil
+	}
+	progress := make([]ReplicaTaskProgress, 0)
+	if err := json.Unmarshal([]byte(message), &progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func setReplicaTaskProgress(comp *appsv1.Component, kind string, progress []ReplicaTaskProgress) error {
+	out, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	if comp.Status.Message == nil {
+		comp.Status.Message = make(map[string]string)
+	}
+	comp.Status.Message[replicaTaskMessageKeyPrefix+kind] = string(out)
+	return nil
+}
+
+func handleReplicaTaskEvent(ctx context.Context, cli client.Client, namespace string, event proto.TaskEvent) error {
+	compKey := types.NamespacedName{
+		Namespace: namespace,
+		Name:      event.Instance,
+	}
+	comp := &appsv1.Component{}
+	if err := cli.Get(ctx, compKey, comp); err != nil {
+		return err
+	}
+	compCopy := comp.DeepCopy()
+
+	progress, err := GetReplicaTaskProgress(comp, event.Task)
+	if err != nil {
+		return err
+	}
+	progress, changed := updateReplicaTaskProgress(progress, event)
+	if !changed {
+		return nil
+	}
+	if err = setReplicaTaskProgress(comp, event.Task, progress); err != nil {
+		return err
+	}
+	if err = cli.Status().Patch(ctx, comp, client.MergeFrom(compCopy)); err != nil {
+		return err
+	}
+	if event.EndTime.IsZero() {
+		return nil
+	}
+	// the progress is kept before the task is removed from the replica, so that the task will not be issued again
+	return removeKBAgentTaskReplica(ctx, cli, namespace, event.Instance, event.Task, event.Replica)
+}
+
+// updateReplicaTaskProgress updates the progress of the replica by the event, the progress of the previous task
+// on the replica is replaced, and the late progress events of a finished task are ignored.
+func updateReplicaTaskProgress(progress []ReplicaTaskProgress, event proto.TaskEvent) ([]ReplicaTaskProgress, bool) {
+	p := ReplicaTaskProgress{
+		Replica:   event.Replica,
+		UID:       event.UID,
+		Phase:     ReplicaTaskPhaseRunning,
+		Message:   event.Message,
+		StartTime: event.StartTime,
+	}
+	if !event.EndTime.IsZero() {
+		p.EndTime = &event.EndTime
+		p.Phase = ReplicaTaskPhaseSucceeded
+		if event.Code != 0 {
+			p.Phase = ReplicaTaskPhaseFailed
+		}
+	}
+
+	idx := slices.IndexFunc(progress, func(p ReplicaTaskProgress) bool {
+		return p.Replica == event.Replica
+	})
+	if idx < 0 {
+		progress = append(progress, p)
+		slices.SortFunc(progress, func(a, b ReplicaTaskProgress) int {
+			return strings.Compare(a.Replica, b.Replica)
+		})
+		return progress, true
+	}
+	current := progress[idx]
+	if current.UID == p.UID && current.EndTime != nil && p.EndTime == nil {
+		return progress, false
+	}
+	if current.UID == p.UID && current.Phase == p.Phase && current.Message == p.Message {
+		return progress, false
+	}
+	progress[idx] = p
+	return progress, true
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("kb-agent task event", func() {
+	Context("replica task", func() {
+		It("builds the env of the replica task", func() {
+			_, err := ReplicaTask(nil, "comp", "uid", []string{"pod-0"}, proto.Task{Task: proto.TaskKindNewReplica})
+			Expect(err).ShouldNot(BeNil())
+
+			env, err := ReplicaTask(nil, "comp", "uid", []string{"pod-0", "pod-1"}, proto.Task{
+				Task:           proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{ReplicaActionTask: proto.ReplicaActionTask{Action: "compact"}},
+			})
+			Expect(err).Should(BeNil())
+			Expect(env).Should(HaveLen(1))
+			for _, v := range env {
+				Expect(v).Should(ContainSubstring(`"replicas":"pod-0,pod-1"`))
+				Expect(v).Should(ContainSubstring(`"compactStorage":{"action":"compact"}`))
+			}
+		})
+
+		It("merges the replica task with the in-flight tasks", func() {
+			env, err := mergeKBAgentTaskEnv(nil, proto.Task{Instance: "comp", Task: proto.TaskKindNewReplica, UID: "uid-0", Replicas: "pod-2"})
+			Expect(err).Should(BeNil())
+
+			env, err = ReplicaTask(env, "comp", "uid-1", []string{"pod-0"}, proto.Task{
+				Task:           proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{ReplicaActionTask: proto.ReplicaActionTask{Action: "compact"}},
+			})
+			Expect(err).Should(BeNil())
+			env, err = ReplicaTask(env, "comp", "uid-2", []string{"pod-1"}, proto.Task{
+				Task:           proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{ReplicaActionTask: proto.ReplicaActionTask{Action: "compact"}},
+			})
+			Expect(err).Should(BeNil())
+
+			uids := make([]string, 0)
+			_, err = updateKBAgentTaskEnv(env, func(task proto.Task) *proto.Task {
+				uids = append(uids, task.UID)
+				return &task
+			})
+			Expect(err).Should(BeNil())
+			Expect(uids).Should(Equal([]string{"uid-0", "uid-2"}))
+		})
+
+		It("builds the replica task requested by the annotation", func() {
+			comp := &appsv1.Component{}
+			env, err := ReplicaTask4Annotation(comp, "comp", nil)
+			Expect(err).Should(BeNil())
+			Expect(env).Should(BeNil())
+
+			comp.Annotations = map[string]string{constant.ReplicaTaskAnnotationKey: `{"task":"compactStorage"}`}
+			_, err = ReplicaTask4Annotation(comp, "comp", nil)
+			Expect(err).ShouldNot(BeNil())
+
+			comp.Annotations[constant.ReplicaTaskAnnotationKey] = `{"task":"compactStorage","replicas":"pod-0","compactStorage":{"action":"compact"}}`
+			env, err = ReplicaTask4Annotation(comp, "comp", nil)
+			Expect(err).Should(BeNil())
+			var uid string
+			_, err = updateKBAgentTaskEnv(env, func(task proto.Task) *proto.Task {
+				Expect(task.Instance).Should(Equal("comp"))
+				Expect(task.Replicas).Should(Equal("pod-0"))
+				Expect(task.NotifyAtFinish).Should(BeTrue())
+				uid = task.UID
+				return &task
+			})
+			Expect(err).Should(BeNil())
+			Expect(uid).ShouldNot(BeEmpty())
+
+			// the request is issued only once
+			Expect(setReplicaTaskProgress(comp, proto.TaskKindCompactStorage, []ReplicaTaskProgress{
+				{Replica: "pod-0", UID: uid, Phase: ReplicaTaskPhaseSucceeded},
+			})).Should(Succeed())
+			env, err = ReplicaTask4Annotation(comp, "comp", nil)
+			Expect(err).Should(BeNil())
+			Expect(env).Should(BeNil())
+		})
+
+		It("updates the progress of the replicas", func() {
+			start := time.Now().Truncate(time.Second)
+			event := proto.TaskEvent{
+				Instance:  "comp",
+				Task:      proto.TaskKindVerifyChecksum,
+				UID:       "uid-1",
+				Replica:   "pod-1",
+				StartTime: start,
+				Message:   "running",
+			}
+
+			progress, changed := updateReplicaTaskProgress(nil, event)
+			Expect(changed).Should(BeTrue())
+			Expect(progress).Should(HaveLen(1))
+			Expect(progress[0].Phase).Should(Equal(ReplicaTaskPhaseRunning))
+
+			event0 := event
+			event0.Replica = "pod-0"
+			progress, changed = updateReplicaTaskProgress(progress, event0)
+			Expect(changed).Should(BeTrue())
+			Expect(progress[0].Replica).Should(Equal("pod-0"))
+			Expect(progress[1].Replica).Should(Equal("pod-1"))
+
+			// no change
+			_, changed = updateReplicaTaskProgress(progress, event)
+			Expect(changed).Should(BeFalse())
+
+			failed := event
+			failed.EndTime = start.Add(time.Minute)
+			failed.Code = -1
+			failed.Message = "the checksum mismatched"
+			progress, changed = updateReplicaTaskProgress(progress, failed)
+			Expect(changed).Should(BeTrue())
+			Expect(progress[1].Phase).Should(Equal(ReplicaTaskPhaseFailed))
+			Expect(progress[1].EndTime).ShouldNot(BeNil())
+
+			// the late progress event is ignored
+			_, changed = updateReplicaTaskProgress(progress, event)
+			Expect(changed).Should(BeFalse())
+
+			// the new task replaces the previous one
+			next := event
+			next.UID = "uid-2"
+			progress, changed = updateReplicaTaskProgress(progress, next)
+			Expect(changed).Should(BeTrue())
+			Expect(progress[1].UID).Should(Equal("uid-2"))
+			Expect(progress[1].Phase).Should(Equal(ReplicaTaskPhaseRunning))
+		})
+
+		It("keeps the progress in the component status", func() {
+			comp := &appsv1.Component{}
+			progress, err := GetReplicaTaskProgress(comp, proto.TaskKindCompactStorage)
+			Expect(err).Should(BeNil())
+			Expect(progress).Should(BeEmpty())
+
+			Expect(setReplicaTaskProgress(comp, proto.TaskKindCompactStorage, []ReplicaTaskProgress{
+				{Replica: "pod-0", UID: "uid", Phase: ReplicaTaskPhaseSucceeded},
+			})).Should(Succeed())
+			Expect(comp.Status.Message).Should(HaveKey("Task/compactStorage"))
+
+			progress, err = GetReplicaTaskProgress(comp, proto.TaskKindCompactStorage)
+			Expect(err).Should(BeNil())
+			Expect(progress).Should(HaveLen(1))
+			Expect(progress[0].Phase).Should(Equal(ReplicaTaskPhaseSucceeded))
+		})
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -35,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	kbautil "github.com/apecloud/kubeblocks/pkg/kbagent/util"
 	"github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -266,6 +268,32 @@ var _ = Describe("kb-agent", func() {
 			}))
 		})
 
+		It("role probe schedule", func() {
+			synthesizedComp.LifecycleActions.RoleProbe.JitterPercent = 10
+			synthesizedComp.LifecycleActions.RoleProbe.MaxBackoffSeconds = 30
+			synthesizedComp.LifecycleActions.RoleProbe.MaxEventsPerMinute = 12
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			var val string
+			for _, e := range c.Env {
+				if e.Name == "KB_AGENT_PROBE" {
+					val = e.Value
+				}
+			}
+			probes := make([]proto.Probe, 0)
+			Expect(json.Unmarshal([]byte(val), &probes)).Should(Succeed())
+			Expect(probes).Should(ContainElement(SatisfyAll(
+				HaveField("Action", "roleProbe"),
+				HaveField("JitterPercent", int32(10)),
+				HaveField("MaxBackoffSeconds", int32(30)),
+				HaveField("MaxEventsPerMinute", int32(12)),
+			)))
+		})
+
 		It("action env", func() {
 			env := []corev1.EnvVar{
 				{
@@ -370,6 +398,64 @@ var _ = Describe("kb-agent", func() {
 			Expect(c.VolumeMounts[1]).Should(Equal(roleLabelVolumeMount))
 		})
 
+		It("file service", func() {
+			viperx.Set(constant.FeatureGateKBAgentFileService, true)
+			defer viperx.Set(constant.FeatureGateKBAgentFileService, false)
+
+			image := "custom-image"
+			synthesizedComp.LifecycleActions.PostProvision.Exec.Image = image
+			synthesizedComp.LifecycleActions.PostProvision.Exec.Container = synthesizedComp.PodSpec.Containers[0].Name
+			synthesizedComp.PodSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+				},
+			}
+
+			By("requires the authentication")
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring(constant.FeatureGateKBAgentAuthentication))
+
+			By("the shared volume of the kb-agent binary is not allowed")
+			viperx.Set(constant.FeatureGateKBAgentAuthentication, true)
+			defer viperx.Set(constant.FeatureGateKBAgentAuthentication, false)
+			synthesizedComp.PodSpec.InitContainers = nil
+			err = buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.VolumeMounts).Should(ContainElement(sharedVolumeMount))
+			Expect(c.Args).Should(ContainElements("--file-allowed-paths", "/data"))
+		})
+
+		It("authentication", func() {
+			viperx.Set(constant.FeatureGateKBAgentAuthentication, true)
+			defer viperx.Set(constant.FeatureGateKBAgentAuthentication, false)
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.Args).Should(ContainElements("--metrics-port", strconv.Itoa(kbagent.DefaultMetricsPort)))
+			Expect(c.Ports).Should(ContainElement(corev1.ContainerPort{
+				ContainerPort: int32(kbagent.DefaultMetricsPort),
+				Name:          kbagent.DefaultMetricsPortName,
+				Protocol:      corev1.ProtocolTCP,
+			}))
+
+			var authVolume *corev1.Volume
+			for i, v := range synthesizedComp.PodSpec.Volumes {
+				if v.Name == kbagent.AuthVolumeName {
+					authVolume = &synthesizedComp.PodSpec.Volumes[i]
+				}
+			}
+			Expect(authVolume).ShouldNot(BeNil())
+			Expect(authVolume.Secret.Items).ShouldNot(ContainElement(HaveField("Key", kbautil.AuthCAKeyKey)))
+		})
+
 		It("custom container - two same containers", func() {
 			container := synthesizedComp.PodSpec.Containers[0]
 			synthesizedComp.LifecycleActions.PostProvision.Exec.Container = container.Name
@@ -570,5 +656,117 @@ var _ = Describe("kb-agent", func() {
 				Value: "/var/run/server.conf",
 			}))
 		})
+
+		It("tcp action", func() {
+			action := buildAction4KBAgent(&appsv1.Action{
+				TCP: &appsv1.TCPAction{
+					Port:    "6379",
+					Payload: "PING\r\n",
+					Expect:  "^\\+PONG",
+					TLS: &appsv1.TCPTLSConfig{
+						ServerName: "redis",
+					},
+				},
+				TimeoutSeconds: 5,
+			}, "roleProbe")
+			Expect(action).Should(Equal(&proto.Action{
+				Name: "roleProbe",
+				TCP: &proto.TCPAction{
+					Port:    "6379",
+					Payload: "PING\r\n",
+					Expect:  "^\\+PONG",
+					TLS: &proto.TCPTLSConfig{
+						ServerName: "redis",
+					},
+				},
+				TimeoutSeconds: 5,
+			}))
+		})
+
+		It("tcp action - named port", func() {
+			synthesizedComp.PodSpec.Containers[0].Ports = []corev1.ContainerPort{
+				{
+					Name:          "redis",
+					ContainerPort: 6379,
+				},
+			}
+			synthesizedComp.LifecycleActions.ComponentLifecycleActions.RoleProbe.Action = appsv1.Action{
+				TCP: &appsv1.TCPAction{
+					Port:    "redis",
+					Payload: "PING\r\n",
+				},
+			}
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			tcpPort := func() string {
+				c := kbAgentContainer()
+				Expect(c).ShouldNot(BeNil())
+				var actions []proto.Action
+				for _, e := range c.Env {
+					if e.Name == "KB_AGENT_ACTION" {
+						Expect(json.Unmarshal([]byte(e.Value), &actions)).Should(Succeed())
+					}
+				}
+				for _, a := range actions {
+					if a.Name == "roleProbe" {
+						Expect(a.TCP).ShouldNot(BeNil())
+						return a.TCP.Port
+					}
+				}
+				return ""
+			}
+			Expect(tcpPort()).Should(Equal("6379"))
+
+			By("allocate a new port for host network")
+			synthesizedComp.PodSpec.Containers[0].Ports[0].ContainerPort = 16379
+			Expect(UpdateKBAgentContainer4HostNetwork(synthesizedComp)).Should(Succeed())
+			Expect(tcpPort()).Should(Equal("16379"))
+		})
+
+		It("tcp action - named port not found", func() {
+			synthesizedComp.LifecycleActions.ComponentLifecycleActions.RoleProbe.Action = appsv1.Action{
+				TCP: &appsv1.TCPAction{
+					Port: "redis",
+				},
+			}
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("redis"))
+		})
+
+		It("script action", func() {
+			action := buildAction4KBAgent(&appsv1.Action{
+				Script: &appsv1.ScriptAction{
+					Expression: `parseJSON(httpGet("http://127.0.0.1:8008/patroni").body).role`,
+					CostLimit:  ptr.To(int64(1000)),
+				},
+			}, "roleProbe")
+			Expect(action).Should(Equal(&proto.Action{
+				Name: "roleProbe",
+				Script: &proto.ScriptAction{
+					Expression: `parseJSON(httpGet("http://127.0.0.1:8008/patroni").body).role`,
+					CostLimit:  ptr.To(int64(1000)),
+				},
+			}))
+		})
+
+		It("action lock", func() {
+			action := buildAction4KBAgent(&appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "backup.sh"},
+				},
+				Lock: &appsv1.ActionLock{
+					Group:               "data",
+					Policy:              appsv1.QueueOnConflict,
+					QueueTimeoutSeconds: 30,
+				},
+			}, "dataDump")
+			Expect(action.Lock).Should(Equal(&proto.ActionLock{
+				Group:               "data",
+				Policy:              proto.ActionConflictPolicyQueue,
+				QueueTimeoutSeconds: 30,
+			}))
+		})
 	})
 })
@@ -24,11 +24,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,7 +46,7 @@ const (
 	replicaStatusAnnotationKey = "apps.kubeblocks.io/replicas-status"
 
 	// new replicas task & event
-	newReplicaTask                           = "newReplica"
+	newReplicaTask                           = proto.TaskKindNewReplica
 	defaultNewReplicaTaskReportPeriodSeconds = 60
 )
 
@@ -209,11 +211,22 @@ func GetReplicasStatusFunc(its *workloads.InstanceSet, f func(ReplicaStatus) boo
 	return replicas, nil
 }
 
-func NewReplicaTask(compName, uid string, source *corev1.Pod, replicas []string) (map[string]string, error) {
+// NewReplicaTask builds the env of the task to provision new replicas from the source,
+// the data transfer is tuned by the new-replica annotations of the component.
+// The task is merged into the tasks kept in the env vars, and the in-flight tasks of other kinds are kept.
+func NewReplicaTask(envVars map[string]string, compName, uid string, source *corev1.Pod, replicas []string, annotations map[string]string) (map[string]string, error) {
 	port, err := intctrlutil.GetPortByName(*source, kbagent.ContainerName, kbagent.DefaultStreamingPortName)
 	if err != nil {
 		return nil, err
 	}
+	newReplica := &proto.NewReplicaTask{
+		Remote:   intctrlutil.PodFQDN(source.Namespace, compName, source.Name),
+		Port:     port,
+		Replicas: strings.Join(replicas, ","),
+	}
+	if err = newReplicaTransferOptions(newReplica, annotations); err != nil {
+		return nil, err
+	}
 	task := proto.Task{
 		Instance:            compName,
 		Task:                newReplicaTask,
@@ -221,13 +234,42 @@ func NewReplicaTask(compName, uid string, source *corev1.Pod, replicas []string)
 		Replicas:            strings.Join(replicas, ","),
 		NotifyAtFinish:      true,
 		ReportPeriodSeconds: defaultNewReplicaTaskReportPeriodSeconds,
-		NewReplica: &proto.NewReplicaTask{
-			Remote:   intctrlutil.PodFQDN(source.Namespace, compName, source.Name),
-			Port:     port,
-			Replicas: strings.Join(replicas, ","),
-		},
+		NewReplica:          newReplica,
+	}
+	return mergeKBAgentTaskEnv(envVars, task)
+}
+
+func newReplicaTransferOptions(task *proto.NewReplicaTask, annotations map[string]string) error {
+	if v, ok := annotations[constant.NewReplicaTimeoutSecondsAnnotationKey]; ok {
+		timeout, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid value of annotation %s: %s", constant.NewReplicaTimeoutSecondsAnnotationKey, v)
+		}
+		task.TimeoutSeconds = ptr.To(int32(timeout))
+	}
+	if v, ok := annotations[constant.NewReplicaRateLimitAnnotationKey]; ok {
+		limit, err := resource.ParseQuantity(v)
+		if err != nil || limit.Sign() <= 0 {
+			return fmt.Errorf("invalid value of annotation %s: %s", constant.NewReplicaRateLimitAnnotationKey, v)
+		}
+		task.RateLimitBytesPerSecond = limit.Value()
+	}
+	if v, ok := annotations[constant.NewReplicaCompressionAnnotationKey]; ok {
+		switch compression := proto.StreamingCompression(strings.ToLower(v)); compression {
+		case proto.StreamingCompressionNone, proto.StreamingCompressionGzip, proto.StreamingCompressionZstd:
+			task.Compression = compression
+		default:
+			return fmt.Errorf("invalid value of annotation %s: %s", constant.NewReplicaCompressionAnnotationKey, v)
+		}
+	}
+	if v, ok := annotations[constant.NewReplicaResumableAnnotationKey]; ok {
+		resumable, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid value of annotation %s: %s", constant.NewReplicaResumableAnnotationKey, v)
+		}
+		task.Resumable = resumable
 	}
-	return buildKBAgentTaskEnv(task)
+	return nil
 }
 
 func compGenerationFromITS(its *workloads.InstanceSet) string {
@@ -311,48 +353,7 @@ func handleNewReplicaTaskEvent(logger logr.Logger, ctx context.Context, cli clie
 }
 
 func handleNewReplicaTaskEvent4Finished(ctx context.Context, cli client.Client, its *workloads.InstanceSet, event proto.TaskEvent) error {
-	if err := func() error {
-		envKey := types.NamespacedName{
-			Namespace: its.Namespace,
-			Name:      constant.GetCompEnvCMName(its.Name),
-		}
-		obj := &corev1.ConfigMap{}
-		err := cli.Get(ctx, envKey, obj)
-		if err != nil {
-			return err
-		}
-
-		parameters, err := updateKBAgentTaskEnv(obj.Data, func(task proto.Task) *proto.Task {
-			if task.Task == newReplicaTask {
-				replicas := strings.Split(task.Replicas, ",")
-				replicas = slices.DeleteFunc(replicas, func(r string) bool {
-					return r == event.Replica
-				})
-				if len(replicas) == 0 {
-					return nil
-				}
-				task.Replicas = strings.Join(replicas, ",")
-				if task.NewReplica != nil {
-					task.NewReplica.Replicas = task.Replicas
-				}
-			}
-			return &task
-		})
-		if err != nil {
-			return err
-		}
-		if parameters == nil {
-			return nil // do nothing
-		}
-
-		if obj.Data == nil {
-			obj.Data = make(map[string]string)
-		}
-		for k, v := range parameters {
-			obj.Data[k] = v
-		}
-		return cli.Update(ctx, obj)
-	}(); err != nil {
+	if err := removeKBAgentTaskReplica(ctx, cli, its.Namespace, its.Name, newReplicaTask, event.Replica); err != nil {
 		return err
 	}
 	return updateReplicaStatusFunc(ctx, cli, its, event.Replica, func(status *ReplicaStatus) error {
@@ -363,6 +364,51 @@ func handleNewReplicaTaskEvent4Finished(ctx context.Context, cli client.Client,
 	})
 }
 
+// removeKBAgentTaskReplica removes the replica from the task of the kind kept in the env CM,
+// and the task is removed once there is no replica left.
+func removeKBAgentTaskReplica(ctx context.Context, cli client.Client, namespace, compName, kind, replica string) error {
+	envKey := types.NamespacedName{
+		Namespace: namespace,
+		Name:      constant.GetCompEnvCMName(compName),
+	}
+	obj := &corev1.ConfigMap{}
+	err := cli.Get(ctx, envKey, obj)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := updateKBAgentTaskEnv(obj.Data, func(task proto.Task) *proto.Task {
+		if task.Task == kind {
+			replicas := strings.Split(task.Replicas, ",")
+			replicas = slices.DeleteFunc(replicas, func(r string) bool {
+				return r == replica
+			})
+			if len(replicas) == 0 {
+				return nil
+			}
+			task.Replicas = strings.Join(replicas, ",")
+			if task.NewReplica != nil {
+				task.NewReplica.Replicas = task.Replicas
+			}
+		}
+		return &task
+	})
+	if err != nil {
+		return err
+	}
+	if parameters == nil {
+		return nil // do nothing
+	}
+
+	if obj.Data == nil {
+		obj.Data = make(map[string]string)
+	}
+	for k, v := range parameters {
+		obj.Data[k] = v
+	}
+	return cli.Update(ctx, obj)
+}
+
 func handleNewReplicaTaskEvent4Unfinished(ctx context.Context, cli client.Client, its *workloads.InstanceSet, event proto.TaskEvent) error {
 	return updateReplicaStatusFunc(ctx, cli, its, event.Replica, func(status *ReplicaStatus) error {
 		status.Message = event.Message
@@ -30,6 +30,7 @@ import (
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("replicas", func() {
@@ -290,4 +291,30 @@ var _ = Describe("replicas", func() {
 		//	}
 		// })
 	})
+
+	Context("new replica task", func() {
+		It("transfer options", func() {
+			task := &proto.NewReplicaTask{}
+			Expect(newReplicaTransferOptions(task, map[string]string{
+				constant.NewReplicaTimeoutSecondsAnnotationKey: "3600",
+				constant.NewReplicaRateLimitAnnotationKey:      "100Mi",
+				constant.NewReplicaCompressionAnnotationKey:    "zstd",
+				constant.NewReplicaResumableAnnotationKey:      "true",
+			})).Should(Succeed())
+			Expect(task.TimeoutSeconds).Should(Equal(ptr.To[int32](3600)))
+			Expect(task.RateLimitBytesPerSecond).Should(Equal(int64(100 * 1024 * 1024)))
+			Expect(task.Compression).Should(Equal(proto.StreamingCompressionZstd))
+			Expect(task.Resumable).Should(BeTrue())
+
+			for key, value := range map[string]string{
+				constant.NewReplicaTimeoutSecondsAnnotationKey: "0",
+				constant.NewReplicaRateLimitAnnotationKey:      "fast",
+				constant.NewReplicaCompressionAnnotationKey:    "lz4",
+				constant.NewReplicaResumableAnnotationKey:      "yes-please",
+			} {
+				Expect(newReplicaTransferOptions(&proto.NewReplicaTask{}, map[string]string{key: value})).
+					Should(MatchError(ContainSubstring(key)))
+			}
+		})
+	})
 })
@@ -121,6 +121,8 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 		PodUpgradePolicy:                 getPodUpgradePolicy(comp, compDef),
 		UpdateStrategy:                   compDef.Spec.UpdateStrategy,
 		InstanceUpdateStrategy:           comp.Spec.InstanceUpdateStrategy,
+		MaintenanceWindow:                comp.Spec.MaintenanceWindow,
+		DisruptionBudget:                 comp.Spec.DisruptionBudget,
 		EnableInstanceAPI:                comp.Spec.EnableInstanceAPI,
 		LifecycleActions: SynthesizedLifecycleActions{
 			ComponentLifecycleActions: compDefObj.Spec.LifecycleActions,
@@ -74,6 +74,8 @@ type SynthesizedComponent struct {
 	PodUpgradePolicy                 kbappsv1.PodUpdatePolicyType
 	UpdateStrategy                   *kbappsv1.UpdateStrategy         `json:"updateStrategy,omitempty"`
 	InstanceUpdateStrategy           *kbappsv1.InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
+	MaintenanceWindow                *kbappsv1.MaintenanceWindow      `json:"maintenanceWindow,omitempty"`
+	DisruptionBudget                 *kbappsv1.DisruptionBudget       `json:"disruptionBudget,omitempty"`
 	PolicyRules                      []rbacv1.PolicyRule              `json:"policyRules,omitempty"`
 	LifecycleActions                 SynthesizedLifecycleActions      `json:"lifecycleActions,omitempty"`
 	SystemAccounts                   []kbappsv1.SystemAccount         `json:"systemAccounts,omitempty"`
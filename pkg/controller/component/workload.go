@@ -27,8 +27,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -80,6 +82,7 @@ func BuildInstanceSet(synthesizedComp *SynthesizedComponent, compDef *kbappsv1.C
 		SetPodUpdatePolicy(synthesizedComp.PodUpdatePolicy).
 		SetPodUpgradePolicy(synthesizedComp.PodUpgradePolicy).
 		SetInstanceUpdateStrategy(getInstanceUpdateStrategy(synthesizedComp)).
+		SetMaintenanceWindow(synthesizedComp.MaintenanceWindow).
 		SetMemberUpdateStrategy(getMemberUpdateStrategy(synthesizedComp)).
 		SetLifecycleActions(synthesizedComp.LifecycleActions.ComponentLifecycleActions, synthesizedComp.TemplateVars).
 		// SetStop(synthesizedComp.Stop).  # check handleWorkloadStartNStop
@@ -104,6 +107,103 @@ func BuildInstanceSet(synthesizedComp *SynthesizedComponent, compDef *kbappsv1.C
 	return itsObj, nil
 }
 
+// BuildPodDisruptionBudget builds the PodDisruptionBudget to limit the voluntary disruptions of the component pods,
+// such as node drains and cluster-autoscaler scale-downs. It returns nil if no PodDisruptionBudget is needed.
+//
+// The running workload is used to count the quorum members from the roles observed, it can be nil.
+func BuildPodDisruptionBudget(synthesizedComp *SynthesizedComponent, runningITS *workloads.InstanceSet) *policyv1.PodDisruptionBudget {
+	if ptr.Deref(synthesizedComp.Stop, false) || synthesizedComp.Replicas == 0 {
+		return nil
+	}
+	budget := synthesizedComp.DisruptionBudget
+	if budget != nil && budget.Disabled {
+		return nil
+	}
+
+	var maxUnavailable, minAvailable *intstr.IntOrString
+	if budget != nil && (budget.MaxUnavailable != nil || budget.MinAvailable != nil) {
+		maxUnavailable, minAvailable = budget.MaxUnavailable, budget.MinAvailable
+	} else {
+		maxUnavailable = getDefaultMaxUnavailable(synthesizedComp, runningITS)
+	}
+	if maxUnavailable == nil && minAvailable == nil {
+		return nil
+	}
+
+	var (
+		clusterName = synthesizedComp.ClusterName
+		compName    = synthesizedComp.Name
+	)
+	return builder.NewPodDisruptionBudgetBuilder(synthesizedComp.Namespace, constant.GenerateWorkloadNamePattern(clusterName, compName)).
+		AddLabelsInMap(synthesizedComp.StaticLabels).
+		AddLabelsInMap(constant.GetCompLabels(clusterName, compName)).
+		AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+		SetSelectorMatchLabel(constant.GetCompLabels(clusterName, compName)).
+		SetMaxUnavailable(maxUnavailable).
+		SetMinAvailable(minAvailable).
+		GetObject()
+}
+
+// getDefaultMaxUnavailable derives the max unavailable pods from the roles and replicas of the component:
+//   - if any role participates in quorum, at most (n-1)/2 pods can be unavailable to keep the quorum, n is the number
+//     of quorum members. The pods of the other roles are counted in the budget too, since the evictions can't be
+//     told apart by roles.
+//   - otherwise, e.g. the roles are exclusive (primary/secondary) or no role is defined, at most one pod can be
+//     unavailable, to keep a candidate for the failover of the exclusive role.
+//
+// A quorum of less than three members can't tolerate any unavailable member, it can't be protected without blocking
+// the node drains, so one pod is allowed to be unavailable. For the same reason, no budget is applied to a single replica.
+func getDefaultMaxUnavailable(synthesizedComp *SynthesizedComponent, runningITS *workloads.InstanceSet) *intstr.IntOrString {
+	replicas := synthesizedComp.Replicas
+	if replicas < 2 {
+		return nil
+	}
+	members := getQuorumMembers(synthesizedComp, runningITS)
+	if members < 3 {
+		return ptr.To(intstr.FromInt32(1))
+	}
+	return ptr.To(intstr.FromInt32((members - 1) / 2))
+}
+
+// getQuorumMembers returns the number of pods participating in quorum, 0 if no role participates in quorum.
+//
+// All replicas are quorum members if every role participates in quorum, and an exclusive role is held by one pod only.
+// Otherwise, the members are counted from the roles observed by the running workload, and all replicas are regarded
+// as members if no role is observed yet.
+func getQuorumMembers(synthesizedComp *SynthesizedComponent, runningITS *workloads.InstanceSet) int32 {
+	var (
+		replicas    = synthesizedComp.Replicas
+		quorumRoles = sets.New[string]()
+		exclusive   = true
+	)
+	for _, role := range synthesizedComp.Roles {
+		if role.ParticipatesInQuorum {
+			quorumRoles.Insert(role.Name)
+			exclusive = exclusive && role.IsExclusive
+		}
+	}
+	switch {
+	case quorumRoles.Len() == 0:
+		return 0
+	case quorumRoles.Len() == len(synthesizedComp.Roles):
+		return replicas
+	case exclusive:
+		return min(int32(quorumRoles.Len()), replicas)
+	}
+	if runningITS != nil {
+		var members int32
+		for _, inst := range runningITS.Status.InstanceStatus {
+			if quorumRoles.Has(inst.Role) {
+				members++
+			}
+		}
+		if members > 0 {
+			return min(members, replicas)
+		}
+	}
+	return replicas
+}
+
 func getPodTemplate(synthesizedComp *SynthesizedComponent) corev1.PodTemplateSpec {
 	podBuilder := builder.NewPodBuilder("", "").
 		// priority: static < dynamic < built-in
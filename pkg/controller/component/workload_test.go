@@ -21,11 +21,14 @@ package component
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -320,3 +323,132 @@ var _ = Describe("workload resource defaults", func() {
 		Expect(setDefaultResourceLimits(its)).ShouldNot(Succeed())
 	})
 })
+
+var _ = Describe("workload pod disruption budget", func() {
+	var synthesizedComp *SynthesizedComponent
+
+	BeforeEach(func() {
+		synthesizedComp = &SynthesizedComponent{
+			Namespace:   "default",
+			ClusterName: "test-cluster",
+			Name:        "test-comp",
+			Replicas:    3,
+		}
+	})
+
+	maxUnavailable := func() *intstr.IntOrString {
+		pdb := BuildPodDisruptionBudget(synthesizedComp, nil)
+		Expect(pdb).ShouldNot(BeNil())
+		Expect(pdb.Spec.MinAvailable).Should(BeNil())
+		return pdb.Spec.MaxUnavailable
+	}
+
+	It("keeps the quorum available", func() {
+		synthesizedComp.Roles = []appsv1.ReplicaRole{
+			{Name: "leader", ParticipatesInQuorum: true, IsExclusive: true},
+			{Name: "follower", ParticipatesInQuorum: true},
+		}
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(1))))
+
+		synthesizedComp.Replicas = 5
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(2))))
+
+		synthesizedComp.Replicas = 7
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(3))))
+	})
+
+	It("allows one pod unavailable with less than three quorum members", func() {
+		synthesizedComp.Roles = []appsv1.ReplicaRole{
+			{Name: "leader", ParticipatesInQuorum: true, IsExclusive: true},
+			{Name: "follower", ParticipatesInQuorum: true},
+		}
+		synthesizedComp.Replicas = 2
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(1))))
+
+		By("the exclusive role is the only quorum member")
+		synthesizedComp.Roles = []appsv1.ReplicaRole{
+			{Name: "leader", ParticipatesInQuorum: true, IsExclusive: true},
+			{Name: "learner"},
+		}
+		synthesizedComp.Replicas = 5
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(1))))
+	})
+
+	It("counts the quorum members from the observed roles", func() {
+		synthesizedComp.Roles = []appsv1.ReplicaRole{
+			{Name: "leader", ParticipatesInQuorum: true, IsExclusive: true},
+			{Name: "follower", ParticipatesInQuorum: true},
+			{Name: "learner"},
+		}
+		synthesizedComp.Replicas = 7
+
+		By("all replicas are regarded as members if no role is observed")
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(3))))
+
+		By("3 members and 4 learners")
+		its := &workloads.InstanceSet{}
+		for i, role := range []string{"leader", "follower", "follower", "learner", "learner", "learner", "learner"} {
+			its.Status.InstanceStatus = append(its.Status.InstanceStatus, workloads.InstanceStatus{
+				PodName: fmt.Sprintf("test-cluster-test-comp-%d", i),
+				Role:    role,
+			})
+		}
+		pdb := BuildPodDisruptionBudget(synthesizedComp, its)
+		Expect(pdb).ShouldNot(BeNil())
+		Expect(pdb.Spec.MaxUnavailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+
+		By("5 members and 2 learners")
+		its.Status.InstanceStatus[3].Role = "follower"
+		its.Status.InstanceStatus[4].Role = "follower"
+		pdb = BuildPodDisruptionBudget(synthesizedComp, its)
+		Expect(pdb).ShouldNot(BeNil())
+		Expect(pdb.Spec.MaxUnavailable).Should(Equal(ptr.To(intstr.FromInt32(2))))
+	})
+
+	It("allows one pod unavailable without quorum", func() {
+		synthesizedComp.Roles = []appsv1.ReplicaRole{
+			{Name: "primary", IsExclusive: true},
+			{Name: "secondary"},
+		}
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(1))))
+
+		synthesizedComp.Roles = nil
+		synthesizedComp.Replicas = 5
+		Expect(maxUnavailable()).Should(Equal(ptr.To(intstr.FromInt32(1))))
+	})
+
+	It("builds the selector of the component pods", func() {
+		pdb := BuildPodDisruptionBudget(synthesizedComp, nil)
+		Expect(pdb).ShouldNot(BeNil())
+		Expect(pdb.Name).Should(Equal("test-cluster-test-comp"))
+		Expect(pdb.Namespace).Should(Equal("default"))
+		Expect(pdb.Spec.Selector.MatchLabels).Should(Equal(constant.GetCompLabels("test-cluster", "test-comp")))
+	})
+
+	It("doesn't build the budget if not needed", func() {
+		synthesizedComp.Replicas = 1
+		Expect(BuildPodDisruptionBudget(synthesizedComp, nil)).Should(BeNil())
+
+		synthesizedComp.Replicas = 3
+		synthesizedComp.Stop = ptr.To(true)
+		Expect(BuildPodDisruptionBudget(synthesizedComp, nil)).Should(BeNil())
+
+		synthesizedComp.Stop = nil
+		synthesizedComp.DisruptionBudget = &appsv1.DisruptionBudget{Disabled: true}
+		Expect(BuildPodDisruptionBudget(synthesizedComp, nil)).Should(BeNil())
+	})
+
+	It("overrides the budget", func() {
+		synthesizedComp.Replicas = 1
+		synthesizedComp.DisruptionBudget = &appsv1.DisruptionBudget{
+			MinAvailable: ptr.To(intstr.FromString("50%")),
+		}
+		pdb := BuildPodDisruptionBudget(synthesizedComp, nil)
+		Expect(pdb).ShouldNot(BeNil())
+		Expect(pdb.Spec.MaxUnavailable).Should(BeNil())
+		Expect(pdb.Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromString("50%"))))
+
+		synthesizedComp.Replicas = 0
+		Expect(BuildPodDisruptionBudget(synthesizedComp, nil)).Should(BeNil())
+	})
+})
@@ -105,6 +105,7 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	inst.Status.Ready = ready
 	inst.Status.Available = available
 	inst.Status.Role = r.observedRoleOfPod(inst, pod)
+	inst.Status.Replication = r.observedReplicationOfPod(inst, pod)
 	inst.Status.VolumeExpansion = r.hasRunningVolumeExpansion(tree, inst)
 	configs, err := r.observedConfigsOfPod(pod)
 	if err != nil {
@@ -186,6 +187,13 @@ func (r *statusReconciler) observedRoleOfPod(inst *workloads.Instance, pod *core
 	return ""
 }
 
+func (r *statusReconciler) observedReplicationOfPod(inst *workloads.Instance, pod *corev1.Pod) *workloads.ReplicationStatus {
+	if len(inst.Status.Role) == 0 {
+		return nil
+	}
+	return intctrlutil.GetPodReplicationStatus(pod)
+}
+
 func (r *statusReconciler) hasRunningVolumeExpansion(tree *kubebuilderx.ObjectTree, inst *workloads.Instance) bool {
 	pvcs := tree.List(&corev1.PersistentVolumeClaim{})
 	var pvcList []*corev1.PersistentVolumeClaim
@@ -480,6 +480,7 @@ func syncMemberStatus(its *workloads.InstanceSet, instanceStatus []workloads.Ins
 			for i, inst := range instanceStatus {
 				if inst.PodName == pod.Name {
 					instanceStatus[i].Role = role.Name
+					instanceStatus[i].Replication = intctrlutil.GetPodReplicationStatus(pod)
 					break
 				}
 			}
@@ -115,6 +115,12 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		return kubebuilderx.Continue, err
 	}
 
+	// the updates of instances are deferred until the maintenance window opens
+	windowOpen, windowOpensAt, err := intctrlutil.CheckMaintenanceWindow(its.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+
 	priorities := ComposeRolePriorityMap(its.Spec.Roles)
 	sortObjects(oldPodList, priorities, false)
 
@@ -140,6 +146,7 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	updatedPods := 0
 	updatingPods := 0
 	isBlocked := false
+	isDeferred := false
 	needRetry := false
 	for _, pod := range oldPodList {
 		if updatedPods >= rollingUpdateQuota {
@@ -174,6 +181,10 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			updatePolicy = recreatePolicy
 			recreateReason = "the API required"
 		}
+		if updatePolicy != noOpsPolicy && !windowOpen {
+			isDeferred = true
+			break
+		}
 
 		// Always call reconfigure to execute reconfigure actions
 		allUpdated, err1 := r.reconfigure(tree, its, pod)
@@ -230,6 +241,15 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	if !isBlocked {
 		meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceUpdateRestricted))
 	}
+	if isDeferred {
+		message := fmt.Sprintf("InstanceSet %s/%s defers the update until the maintenance window opens at %s",
+			its.Namespace, its.Name, windowOpensAt.UTC().Format(time.RFC3339))
+		if meta.SetStatusCondition(&its.Status.Conditions, *buildDeferredCondition(its, message)) && tree.EventRecorder != nil {
+			tree.EventRecorder.Event(its, corev1.EventTypeNormal, workloads.ReasonMaintenanceWindowClosed, message)
+		}
+		return kubebuilderx.RetryAfter(time.Until(windowOpensAt)), nil
+	}
+	meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceUpdateDeferred))
 	if needRetry {
 		return kubebuilderx.RetryAfter(time.Second * time.Duration(its.Spec.MinReadySeconds)), nil
 	}
@@ -297,9 +317,10 @@ func (r *updateReconciler) switchover(tree *kubebuilderx.ObjectTree, its *worklo
 		return err
 	}
 
-	err = lfa.Switchover(tree.Context, nil, nil, "")
+	candidate := SelectSwitchoverCandidate(its, pod.Name, nil)
+	err = lfa.Switchover(tree.Context, nil, nil, candidate)
 	if err == nil {
-		tree.Logger.Info("succeed to call switchover action", "pod", pod.Name)
+		tree.Logger.Info("succeed to call switchover action", "pod", pod.Name, "candidate", candidate)
 	} else if !errors.Is(err, lifecycle.ErrActionNotDefined) {
 		tree.Logger.Info("failed to call switchover action, ignore it", "pod", pod.Name, "error", err)
 	}
@@ -380,6 +401,16 @@ func buildBlockedCondition(its *workloads.InstanceSet, message string) *metav1.C
 	}
 }
 
+func buildDeferredCondition(its *workloads.InstanceSet, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               string(workloads.InstanceUpdateDeferred),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: its.Generation,
+		Reason:             workloads.ReasonMaintenanceWindowClosed,
+		Message:            message,
+	}
+}
+
 func parseReplicasNMaxUnavailable(updateStrategy *workloads.InstanceUpdateStrategy, totalReplicas int) (int, int, error) {
 	replicas := totalReplicas
 	maxUnavailable := 1
@@ -658,6 +658,18 @@ func (s *lifecycleCallSpy) AccountProvision(_ context.Context, _ client.Reader,
 	return nil
 }
 
+func (s *lifecycleCallSpy) PreUpgrade(_ context.Context, _ client.Reader, _ *lifecycle.Options, _, _ lifecycle.ComponentVersion) error {
+	return nil
+}
+
+func (s *lifecycleCallSpy) PostUpgrade(_ context.Context, _ client.Reader, _ *lifecycle.Options, _, _ lifecycle.ComponentVersion) error {
+	return nil
+}
+
 func (s *lifecycleCallSpy) UserDefined(_ context.Context, _ client.Reader, _ *lifecycle.Options, _ string, _ *kbappsv1.Action, _ map[string]string) error {
 	return nil
 }
+
+func (s *lifecycleCallSpy) Abort(_ context.Context, _ client.Reader, _ *lifecycle.Options, _ string) error {
+	return nil
+}
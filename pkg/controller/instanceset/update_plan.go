@@ -22,6 +22,7 @@ package instanceset
 import (
 	"errors"
 	"math"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -134,6 +135,7 @@ func (p *realUpdatePlan) build() {
 
 	rolePriorityMap := ComposeRolePriorityMap(p.its.Spec.Roles)
 	SortPods(p.pods, rolePriorityMap, false)
+	sortPodsByReplicationLag(p.pods, &p.its, rolePriorityMap)
 
 	// generate plan by memberUpdateStrategy
 	switch memberUpdateStrategy {
@@ -146,6 +148,35 @@ func (p *realUpdatePlan) build() {
 	}
 }
 
+// sortPodsByReplicationLag orders the pods of the same role by the replication lag reported by the role probe,
+// the most lagging ones first, and the ones without lag reported go before them. So the most up-to-date replica
+// is updated last, and it stays available as the switchover candidate during the update.
+func sortPodsByReplicationLag(pods []corev1.Pod, its *workloads.InstanceSet, rolePriorityMap map[string]int) {
+	lags := make(map[string]int64)
+	for _, status := range its.Status.InstanceStatus {
+		if status.Replication != nil && status.Replication.Lag != nil {
+			lags[status.PodName] = *status.Replication.Lag
+		}
+	}
+	if len(lags) == 0 {
+		return
+	}
+	getLag := func(pod *corev1.Pod) int64 {
+		if lag, ok := lags[pod.Name]; ok {
+			return lag
+		}
+		return math.MaxInt64
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		rolePriI := getRolePriority(rolePriorityMap, getRoleName(&pods[i]))
+		rolePriJ := getRolePriority(rolePriorityMap, getRoleName(&pods[j]))
+		if rolePriI != rolePriJ {
+			return rolePriI < rolePriJ
+		}
+		return getLag(&pods[i]) > getLag(&pods[j])
+	})
+}
+
 // unknown & empty & roles that do not participate in quorum & 1/2 followers -> 1/2 followers -> leader
 func (p *realUpdatePlan) buildBestEffortParallelUpdatePlan(rolePriorityMap map[string]int) {
 	currentVertex, _ := model.FindRootVertex(p.dag)
@@ -190,6 +190,36 @@ var _ = Describe("update plan test.", func() {
 			checkPlan(expectedPlan, true)
 		})
 
+		It("should update the most up-to-date replica of the same role last", func() {
+			its.Spec.MemberUpdateStrategy = ptr.To(workloads.SerialUpdateStrategy)
+			its.Spec.Roles = []workloads.ReplicaRole{
+				{Name: "follower", ParticipatesInQuorum: true, UpdatePriority: 1},
+				{Name: "leader", ParticipatesInQuorum: true, UpdatePriority: 2},
+			}
+			for _, pod := range []*corev1.Pod{pod0, pod3, pod6} {
+				pod.Labels[RoleLabelKey] = "follower"
+			}
+			lags := map[string]int64{pod0.Name: 0, pod3.Name: 100, pod6.Name: 10}
+			for podName, lag := range lags {
+				its.Status.InstanceStatus = append(its.Status.InstanceStatus, workloads.InstanceStatus{
+					PodName:     podName,
+					Role:        "follower",
+					Replication: &workloads.ReplicationStatus{Lag: ptr.To(lag)},
+				})
+			}
+
+			expectedPlan := [][]*corev1.Pod{
+				{pod4},
+				{pod2},
+				{pod1},
+				{pod3},
+				{pod6},
+				{pod0},
+				{pod5},
+			}
+			checkPlan(expectedPlan, true)
+		})
+
 		It("should work well with role-less and heterogeneous pods", func() {
 			By("build a serial plan with role-less and heterogeneous pods")
 			its.Spec.MemberUpdateStrategy = ptr.To(workloads.SerialUpdateStrategy)
@@ -22,6 +22,7 @@ package instanceset
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -121,6 +122,59 @@ func composeRoleMap(its workloads.InstanceSet) map[string]workloads.ReplicaRole
 	return roleMap
 }
 
+// SelectSwitchoverCandidate selects the replica to take over the role of the leader in the switchover, from the
+// replicas that report the replication status by the role probe. The candidate should follow the leader in a term
+// not older than the leader's, and be the most up-to-date one, the roles that do not participate in quorum are not
+// considered if any role does. The eligible func filters the candidates further, it can be nil.
+// Empty is returned if there is no such candidate, then the switchover action will choose one itself.
+func SelectSwitchoverCandidate(its *workloads.InstanceSet, leader string, eligible func(string) bool) string {
+	var leaderStatus *workloads.InstanceStatus
+	for i, status := range its.Status.InstanceStatus {
+		if status.PodName == leader {
+			leaderStatus = &its.Status.InstanceStatus[i]
+			break
+		}
+	}
+	if leaderStatus == nil {
+		return ""
+	}
+
+	roleMap := composeRoleMap(*its)
+	hasQuorum := slices.ContainsFunc(its.Spec.Roles, func(role workloads.ReplicaRole) bool {
+		return role.ParticipatesInQuorum
+	})
+	var (
+		candidate string
+		minLag    int64
+	)
+	for _, status := range its.Status.InstanceStatus {
+		if status.PodName == leader || len(status.Role) == 0 || strings.EqualFold(status.Role, leaderStatus.Role) {
+			continue
+		}
+		if role, ok := roleMap[strings.ToLower(status.Role)]; !ok || (hasQuorum && !role.ParticipatesInQuorum) {
+			continue
+		}
+		replication := status.Replication
+		if replication == nil || replication.Lag == nil {
+			continue
+		}
+		if len(replication.Leader) > 0 && replication.Leader != leader {
+			continue // it follows another leader
+		}
+		if leaderStatus.Replication != nil && leaderStatus.Replication.Term != nil &&
+			replication.Term != nil && *replication.Term < *leaderStatus.Replication.Term {
+			continue // it is in a stale term
+		}
+		if eligible != nil && !eligible(status.PodName) {
+			continue
+		}
+		if len(candidate) == 0 || *replication.Lag < minLag {
+			candidate, minLag = status.PodName, *replication.Lag
+		}
+	}
+	return candidate
+}
+
 // mergeMap merge src to dst, dst is modified in place
 // Items in src will overwrite items in dst, if possible.
 func mergeMap[K comparable, V any](src, dst *map[K]V) {
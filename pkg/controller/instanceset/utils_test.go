@@ -27,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
@@ -90,6 +91,28 @@ var _ = Describe("utils test", func() {
 		})
 	})
 
+	Context("SelectSwitchoverCandidate function", func() {
+		It("should work well", func() {
+			its := builder.NewInstanceSetBuilder(namespace, name).SetRoles(roles).GetObject()
+			replication := func(term, lag int64, leader string) *workloads.ReplicationStatus {
+				return &workloads.ReplicationStatus{Term: ptr.To(term), Lag: ptr.To(lag), Leader: leader}
+			}
+			its.Status.InstanceStatus = []workloads.InstanceStatus{
+				{PodName: "pod-0", Role: "leader", Replication: replication(5, 0, "pod-0")},
+				{PodName: "pod-1", Role: "follower", Replication: replication(5, 100, "pod-0")},
+				{PodName: "pod-2", Role: "follower", Replication: replication(5, 10, "pod-0")},
+				{PodName: "pod-3", Role: "follower", Replication: replication(4, 1, "pod-0")},
+				{PodName: "pod-4", Role: "follower", Replication: replication(5, 1, "pod-9")},
+				{PodName: "pod-5", Role: "learner", Replication: replication(5, 0, "pod-0")},
+				{PodName: "pod-6", Role: "follower"},
+			}
+			Expect(SelectSwitchoverCandidate(its, "pod-0", nil)).Should(Equal("pod-2"))
+			Expect(SelectSwitchoverCandidate(its, "pod-0", func(name string) bool { return name != "pod-2" })).Should(Equal("pod-1"))
+			Expect(SelectSwitchoverCandidate(its, "pod-0", func(string) bool { return false })).Should(BeEmpty())
+			Expect(SelectSwitchoverCandidate(its, "pod-7", nil)).Should(BeEmpty())
+		})
+	})
+
 	Context("getRoleName function", func() {
 		It("should work well", func() {
 			pod := builder.NewPodBuilder(namespace, name).AddLabels(RoleLabelKey, "LEADER").GetObject()
@@ -306,6 +306,7 @@ func syncMemberStatus(its *workloads.InstanceSet, instanceStatus []workloads.Ins
 			for i, status := range instanceStatus {
 				if status.PodName == inst.Name {
 					instanceStatus[i].Role = role.Name
+					instanceStatus[i].Replication = inst.Status.Replication
 					break
 				}
 			}
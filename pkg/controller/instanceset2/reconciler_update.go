@@ -21,7 +21,10 @@ package instanceset2
 
 import (
 	"fmt"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -141,6 +144,13 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		return true
 	}
 
+	// the updates of instances are deferred until the maintenance window opens
+	windowOpen, windowOpensAt, err := intctrlutil.CheckMaintenanceWindow(its.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+
+	isDeferred := false
 	for _, inst := range oldInstanceList {
 		if updatedInstances >= replicas {
 			break
@@ -158,6 +168,10 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			return kubebuilderx.Continue, err
 		}
 		mergedInst := copyAndMergeInstance(inst, newInst)
+		if mergedInst != nil && !windowOpen {
+			isDeferred = true
+			break
+		}
 		if mergedInst != nil {
 			err = tree.Update(mergedInst)
 			if err != nil {
@@ -167,6 +181,19 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		}
 		updatedInstances++
 	}
+
+	if isDeferred {
+		meta.SetStatusCondition(&its.Status.Conditions, metav1.Condition{
+			Type:               string(workloads.InstanceUpdateDeferred),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: its.Generation,
+			Reason:             workloads.ReasonMaintenanceWindowClosed,
+			Message: fmt.Sprintf("InstanceSet %s/%s defers the update until the maintenance window opens at %s",
+				its.Namespace, its.Name, windowOpensAt.UTC().Format(time.RFC3339)),
+		})
+		return kubebuilderx.RetryAfter(time.Until(windowOpensAt)), nil
+	}
+	meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceUpdateDeferred))
 	return kubebuilderx.Continue, nil
 }
 
@@ -22,6 +22,8 @@ package instanceset2
 import (
 	"errors"
 	"math"
+	"slices"
+	"sort"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
@@ -115,6 +117,31 @@ func (p *realUpdatePlan) planWalkFunc(vertex graph.Vertex) error {
 	return ErrStop
 }
 
+// sortInstancesByReplicationLag orders the instances of the same role by the replication lag reported by the role probe,
+// the most lagging ones first, and the ones without lag reported go before them. So the most up-to-date replica
+// is updated last, and it stays available as the switchover candidate during the update.
+func sortInstancesByReplicationLag(instances []workloads.Instance, rolePriorityMap map[string]int) {
+	getLag := func(inst *workloads.Instance) int64 {
+		if inst.Status.Replication != nil && inst.Status.Replication.Lag != nil {
+			return *inst.Status.Replication.Lag
+		}
+		return math.MaxInt64
+	}
+	if !slices.ContainsFunc(instances, func(inst workloads.Instance) bool {
+		return inst.Status.Replication != nil && inst.Status.Replication.Lag != nil
+	}) {
+		return
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		rolePriI := getRolePriority(rolePriorityMap, getInstanceRoleName(&instances[i]))
+		rolePriJ := getRolePriority(rolePriorityMap, getInstanceRoleName(&instances[j]))
+		if rolePriI != rolePriJ {
+			return rolePriI < rolePriJ
+		}
+		return getLag(&instances[i]) > getLag(&instances[j])
+	})
+}
+
 func (p *realUpdatePlan) build() {
 	// make a root vertex with nil Obj
 	root := &model.ObjectVertex{}
@@ -124,6 +151,7 @@ func (p *realUpdatePlan) build() {
 
 	rolePriorityMap := composeRolePriorityMap(p.its.Spec.Roles)
 	sortInstances(p.instances, rolePriorityMap, false)
+	sortInstancesByReplicationLag(p.instances, rolePriorityMap)
 
 	// generate plan by memberUpdateStrategy
 	switch memberUpdateStrategy {
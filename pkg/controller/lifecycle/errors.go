@@ -21,6 +21,7 @@ package lifecycle
 
 import (
 	"errors"
+	"strings"
 )
 
 var (
@@ -72,3 +73,43 @@ func (e *actionAggregateError) Is(target error) bool {
 	}
 	return false
 }
+
+// actionAuditError attaches the audit summary of the failed action invocation to the error.
+type actionAuditError struct {
+	err     error
+	summary string
+}
+
+func withAuditSummary(err error, summary string) error {
+	if err == nil || len(summary) == 0 {
+		return err
+	}
+	return &actionAuditError{err: err, summary: summary}
+}
+
+func (e *actionAuditError) Error() string {
+	return e.err.Error()
+}
+
+func (e *actionAuditError) Unwrap() error {
+	return e.err
+}
+
+// AuditSummary returns the audit summaries of the failed action invocations carried by the error, if any.
+func AuditSummary(err error) string {
+	var aggErr *actionAggregateError
+	if errors.As(err, &aggErr) {
+		summaries := make([]string, 0)
+		for _, e := range aggErr.errs {
+			if summary := AuditSummary(e); len(summary) > 0 {
+				summaries = append(summaries, summary)
+			}
+		}
+		return strings.Join(summaries, "; ")
+	}
+	var auditErr *actionAuditError
+	if errors.As(err, &auditErr) {
+		return auditErr.summary
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// Invocation describes an invocation of a lifecycle action on a pod.
+type Invocation struct {
+	Action    string
+	Pod       string
+	StartTime time.Time
+	EndTime   time.Time
+	// ExitCode is the exit code of the action, it is known only for the exec action.
+	ExitCode *int32
+	// Error is the error of the invocation, nil if the action succeeded.
+	Error error
+}
+
+// maxPendingInvocations is the max number of invocations kept for a component before they are saved,
+// the oldest ones are dropped if exceeded.
+const maxPendingInvocations = 64
+
+var exitCodeRegex = regexp.MustCompile(`exit code: (-?\d+)`)
+
+// pendingInvocations holds the invocations of lifecycle actions that have not been saved to the component status yet,
+// keyed by the namespaced name of the component. The actions are called by the controllers of the same process,
+// e.g., the component, InstanceSet and OpsRequest controllers, and the invocations are saved by the component controller.
+var pendingInvocations = &invocationStore{
+	invocations: map[types.NamespacedName][]Invocation{},
+}
+
+type invocationStore struct {
+	sync.Mutex
+	invocations map[types.NamespacedName][]Invocation
+}
+
+// PendingInvocations returns the invocations of lifecycle actions of the component that have not been saved yet,
+// ordered from the oldest to the latest.
+func PendingInvocations(namespace, name string) []Invocation {
+	pendingInvocations.Lock()
+	defer pendingInvocations.Unlock()
+	return append([]Invocation(nil), pendingInvocations.invocations[types.NamespacedName{Namespace: namespace, Name: name}]...)
+}
+
+// ForgetInvocations removes the oldest n invocations of the component once they are saved.
+func ForgetInvocations(namespace, name string, n int) {
+	pendingInvocations.Lock()
+	defer pendingInvocations.Unlock()
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	invocations := pendingInvocations.invocations[key]
+	if n >= len(invocations) {
+		delete(pendingInvocations.invocations, key)
+		return
+	}
+	pendingInvocations.invocations[key] = append([]Invocation(nil), invocations[n:]...)
+}
+
+func recordInvocation(namespace, clusterName, compName string, invocation Invocation) {
+	// the polling of the in-progress action and the action not defined are not real invocations
+	if errors.Is(invocation.Error, ErrActionInProgress) || errors.Is(invocation.Error, ErrActionNotDefined) {
+		return
+	}
+	pendingInvocations.Lock()
+	defer pendingInvocations.Unlock()
+	key := types.NamespacedName{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+	invocations := append(pendingInvocations.invocations[key], invocation)
+	if len(invocations) > maxPendingInvocations {
+		invocations = invocations[len(invocations)-maxPendingInvocations:]
+	}
+	pendingInvocations.invocations[key] = invocations
+}
+
+// exitCodeOf returns the exit code of the exec action from the message of the failed invocation.
+func exitCodeOf(message string) *int32 {
+	matches := exitCodeRegex.FindStringSubmatch(message)
+	if len(matches) != 2 {
+		return nil
+	}
+	code, err := strconv.ParseInt(matches[1], 10, 32)
+	if err != nil {
+		return nil
+	}
+	exitCode := int32(code)
+	return &exitCode
+}
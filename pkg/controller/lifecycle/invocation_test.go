@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	tests := map[string]*int32{
+		"exit code: 1, stderr: init schema failed: failed": ptr.To[int32](1),
+		"exit code: -1":  ptr.To[int32](-1),
+		"timed out":      nil,
+		"exit code: abc": nil,
+	}
+	for message, expected := range tests {
+		got := exitCodeOf(message)
+		if (got == nil) != (expected == nil) || (got != nil && *got != *expected) {
+			t.Fatalf("exitCodeOf(%q) mismatch, got %v, want %v", message, got, expected)
+		}
+	}
+}
+
+func TestRecordInvocation(t *testing.T) {
+	recordInvocation("default", "cluster", "comp", Invocation{Action: "postProvision", Pod: "pod-0"})
+	recordInvocation("default", "cluster", "comp", Invocation{Action: "memberJoin", Pod: "pod-1", Error: errors.Wrap(ErrActionFailed, "exit code: 1")})
+	recordInvocation("default", "cluster", "comp", Invocation{Action: "memberJoin", Pod: "pod-1", Error: errors.Wrap(ErrActionInProgress, "memberJoin")})
+	recordInvocation("default", "cluster", "comp", Invocation{Action: "reconfigure", Pod: "pod-1", Error: fmt.Errorf("%w", ErrActionNotDefined)})
+	recordInvocation("default", "cluster", "other", Invocation{Action: "postProvision", Pod: "pod-0"})
+
+	invocations := PendingInvocations("default", "cluster-comp")
+	if len(invocations) != 2 {
+		t.Fatalf("expect 2 invocations recorded, got %d", len(invocations))
+	}
+	if invocations[0].Action != "postProvision" || invocations[1].Action != "memberJoin" {
+		t.Fatalf("unexpected invocations recorded: %v", invocations)
+	}
+
+	// the invocation recorded after the pending ones are read is kept
+	recordInvocation("default", "cluster", "comp", Invocation{Action: "switchover", Pod: "pod-0"})
+	ForgetInvocations("default", "cluster-comp", len(invocations))
+	invocations = PendingInvocations("default", "cluster-comp")
+	if len(invocations) != 1 || invocations[0].Action != "switchover" {
+		t.Fatalf("unexpected invocations pending: %v", invocations)
+	}
+	ForgetInvocations("default", "cluster-comp", len(invocations))
+	if invocations = PendingInvocations("default", "cluster-comp"); len(invocations) != 0 {
+		t.Fatalf("expect no invocation pending, got %v", invocations)
+	}
+	ForgetInvocations("default", "cluster-other", 1)
+}
+
+func TestRecordInvocationBounded(t *testing.T) {
+	for i := 0; i < maxPendingInvocations+2; i++ {
+		recordInvocation("default", "cluster", "bounded", Invocation{Action: "memberJoin", Pod: fmt.Sprintf("pod-%d", i)})
+	}
+	invocations := PendingInvocations("default", "cluster-bounded")
+	if len(invocations) != maxPendingInvocations || invocations[0].Pod != "pod-2" {
+		t.Fatalf("expect the latest %d invocations kept, got %d from %s", maxPendingInvocations, len(invocations), invocations[0].Pod)
+	}
+	ForgetInvocations("default", "cluster-bounded", len(invocations))
+}
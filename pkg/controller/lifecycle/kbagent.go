@@ -25,6 +25,7 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -41,6 +43,8 @@ import (
 	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
 	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	kbautil "github.com/apecloud/kubeblocks/pkg/kbagent/util"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type lifecycleAction interface {
@@ -133,6 +137,24 @@ func (a *kbagent) AccountProvision(ctx context.Context, cli client.Reader, opts
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.AccountProvision, lfa, opts))
 }
 
+func (a *kbagent) PreUpgrade(ctx context.Context, cli client.Reader, opts *Options, from, to ComponentVersion) error {
+	lfa := &preUpgrade{
+		from:   from,
+		to:     to,
+		action: a.lifecycleActions.PreUpgrade,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, lfa.action, lfa, opts))
+}
+
+func (a *kbagent) PostUpgrade(ctx context.Context, cli client.Reader, opts *Options, from, to ComponentVersion) error {
+	lfa := &postUpgrade{
+		from:   from,
+		to:     to,
+		action: a.lifecycleActions.PostUpgrade,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, lfa.action, lfa, opts))
+}
+
 func (a *kbagent) UserDefined(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action, args map[string]string) error {
 	lfa := &udf{
 		uname: name,
@@ -257,11 +279,27 @@ func (a *kbagent) readyCheck(ctx context.Context, cli client.Reader, name, kind
 }
 
 func (a *kbagent) callAction(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, opts *Options) ([]byte, error) {
+	if opts != nil && opts.DryRun && !spec.SupportDryRun {
+		return nil, fmt.Errorf("%w: %s does not support dry-run", ErrActionNotImplemented, lfa.name())
+	}
 	req, err1 := a.buildActionRequest(ctx, cli, lfa, opts)
 	if err1 != nil {
 		return nil, err1
 	}
-	return a.callActionWithSelector(ctx, spec, lfa, req)
+	output, err := a.callActionWithSelector(ctx, cli, spec, lfa, req)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.DryRun {
+		opts.DryRunOutput = output
+		return output, nil
+	}
+	if opts != nil && opts.Outputs != nil {
+		if err = recordOutputs(lfa.name(), spec, output, opts.Outputs); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
 }
 
 // BuildKBAgentRetryPolicy normalizes the API retry policy into the kbagent wire contract.
@@ -312,6 +350,14 @@ func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa
 		if len(opts.Arguments) > 0 {
 			req.Arguments = opts.Arguments
 		}
+		if opts.DryRun {
+			req.DryRun = ptr.To(true)
+		}
+		for k, v := range outputParameters(opts.Outputs) {
+			if _, ok := req.Parameters[k]; !ok {
+				req.Parameters[k] = v
+			}
+		}
 	}
 	return req, nil
 }
@@ -380,7 +426,7 @@ func (a *kbagent) templateVarsParameters() (map[string]string, error) {
 	return m, nil
 }
 
-func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Action, lfa lifecycleAction, req *proto.ActionRequest) ([]byte, error) {
+func (a *kbagent) callActionWithSelector(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, req *proto.ActionRequest) ([]byte, error) {
 	pods, err := a.selectTargetPods(spec)
 	if err != nil {
 		return nil, err
@@ -397,22 +443,7 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 	var output []byte
 	var actionErrors []error
 	for _, pod := range pods {
-		endpoint := func() (string, int32, error) {
-			host, port, err := a.serverEndpoint(pod)
-			if err != nil {
-				return "", 0, errors.Wrapf(err, "pod %s is unavailable to execute action %s", pod.Name, lfa.name())
-			}
-			return host, port, nil
-		}
-		var cli kbacli.Client
-		_, err := rest.InClusterConfig()
-		if err != nil {
-			// If kb is not run in a k8s cluster, using pod ip to call kb-agent would fail.
-			// So we use a client that utilizes k8s' portforward ability.
-			cli, err = kbacli.NewPortForwardClient(pod, endpoint)
-		} else {
-			cli, err = kbacli.NewClient(endpoint)
-		}
+		agentCli, err := a.agentClient(ctx, cli, pod, lfa.name())
 		if err != nil {
 			if !aggregateErrors {
 				return nil, err // mock client error
@@ -420,15 +451,32 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 			actionErrors = append(actionErrors, errors.Wrapf(err, "error creating client to execute action %s at pod %s", lfa.name(), pod.Name))
 			continue
 		}
-		if cli == nil {
+		if agentCli == nil {
 			continue // not kb-agent container and port defined, for test only
 		}
 
-		rsp, err := cli.Action(ctx, *req)
-		_ = cli.Close()
+		startTime := time.Now()
+		record := func(err error, exitCode *int32) {
+			recordInvocation(a.namespace, a.clusterName, a.compName, Invocation{
+				Action:    lfa.name(),
+				Pod:       pod.Name,
+				StartTime: startTime,
+				EndTime:   time.Now(),
+				ExitCode:  exitCode,
+				Error:     err,
+			})
+		}
+
+		rsp, err := agentCli.Action(ctx, *req)
+		var summary string
+		if err == nil && len(rsp.Error) > 0 {
+			summary = a.auditSummary(ctx, agentCli, lfa.name(), pod.Name, rsp)
+		}
+		_ = agentCli.Close()
 
 		if err != nil {
 			actionErr := errors.Wrapf(err, "http error occurred when executing action %s at pod %s", lfa.name(), pod.Name)
+			record(actionErr, nil)
 			if !aggregateErrors {
 				return nil, actionErr
 			}
@@ -436,13 +484,19 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 			continue
 		}
 		if len(rsp.Error) > 0 {
-			actionErr := a.formatError(lfa, rsp, pod.Name)
+			actionErr := withAuditSummary(a.formatError(lfa, rsp, pod.Name), summary)
+			record(actionErr, exitCodeOf(rsp.Message))
 			if !aggregateErrors {
 				return nil, actionErr
 			}
 			actionErrors = append(actionErrors, actionErr)
 			continue
 		}
+		if spec.Exec != nil {
+			record(nil, ptr.To[int32](0))
+		} else {
+			record(nil, nil)
+		}
 		// take first non-nil output
 		if output == nil && rsp.Output != nil {
 			output = rsp.Output
@@ -454,6 +508,166 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 	return output, nil
 }
 
+// auditSummary returns the summary of the latest invocation of the failed action in the audit log of kb-agent.
+// It is best-effort, and returns empty if the audit log is disabled or unavailable.
+func (a *kbagent) auditSummary(ctx context.Context, agentCli kbacli.Client, name, podName string, rsp proto.ActionResponse) string {
+	if !viper.GetBool(constant.FeatureGateKBAgentAuditLog) {
+		return ""
+	}
+	err := proto.Type2Error(rsp.Error)
+	if !errors.Is(err, proto.ErrFailed) && !errors.Is(err, proto.ErrTimedOut) {
+		return ""
+	}
+	auditRsp, err := agentCli.Audit(ctx, proto.AuditRequest{Action: name, Limit: 1})
+	if err != nil || len(auditRsp.Error) > 0 || len(auditRsp.Records) == 0 {
+		return ""
+	}
+	return formatAuditRecord(podName, auditRsp.Records[0])
+}
+
+func formatAuditRecord(podName string, record proto.AuditRecord) string {
+	summary := fmt.Sprintf("action %s at pod %s started at %s, took %s", record.Action, podName,
+		record.StartTime.UTC().Format(time.RFC3339), record.Elapsed.Round(time.Millisecond))
+	if len(record.Error) > 0 {
+		summary += fmt.Sprintf(", %s: %s", record.Error, record.Message)
+	}
+	if record.OutputSize > 0 {
+		summary += fmt.Sprintf(", output: %d bytes", record.OutputSize)
+	}
+	return summary
+}
+
+func (a *kbagent) agentClient(ctx context.Context, cli client.Reader, pod *corev1.Pod, name string) (kbacli.Client, error) {
+	endpoint := func() (string, int32, error) {
+		host, port, err := a.serverEndpoint(pod)
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "pod %s is unavailable to execute action %s", pod.Name, name)
+		}
+		return host, port, nil
+	}
+	cred, err := a.serverCredential(ctx, cli, pod)
+	if err != nil {
+		return nil, err
+	}
+	return agentClientManager().Client(pod, endpoint, cred)
+}
+
+var (
+	clientManagerOnce sync.Once
+	clientManager     *kbacli.Manager
+)
+
+// agentClientManager returns the manager shared by the controllers to call the kb-agent of pods.
+func agentClientManager() *kbacli.Manager {
+	clientManagerOnce.Do(func() {
+		options := kbacli.DefaultManagerOptions()
+		if viper.IsSet(constant.CfgKBAgentClientFailureThreshold) {
+			options.FailureThreshold = viper.GetInt(constant.CfgKBAgentClientFailureThreshold)
+		}
+		if viper.IsSet(constant.CfgKBAgentClientCircuitOpenSeconds) {
+			options.OpenDuration = time.Duration(viper.GetInt(constant.CfgKBAgentClientCircuitOpenSeconds)) * time.Second
+		}
+		if viper.IsSet(constant.CfgKBAgentClientMaxRetries) {
+			options.MaxRetries = viper.GetInt(constant.CfgKBAgentClientMaxRetries)
+		}
+		if viper.IsSet(constant.CfgKBAgentClientRetryBackoffMS) {
+			options.RetryBackoff = time.Duration(viper.GetInt(constant.CfgKBAgentClientRetryBackoffMS)) * time.Millisecond
+		}
+		for _, action := range strings.Split(viper.GetString(constant.CfgKBAgentClientIdempotentActions), ",") {
+			if action = strings.TrimSpace(action); len(action) > 0 {
+				options.IdempotentActions = append(options.IdempotentActions, action)
+			}
+		}
+		// If kb is not run in a k8s cluster, using pod ip to call kb-agent would fail.
+		// So we use a client that utilizes k8s' portforward ability.
+		if _, err := rest.InClusterConfig(); err != nil {
+			options.PortForward = true
+		}
+		clientManager = kbacli.NewManager(options)
+	})
+	return clientManager
+}
+
+func (a *kbagent) Abort(ctx context.Context, cli client.Reader, opts *Options, name string) error {
+	req := proto.ActionRequest{
+		Action:    name,
+		Operation: proto.ActionOperationCancel,
+	}
+	var retryPolicy *proto.RetryPolicy
+	if opts != nil {
+		if opts.TimeoutSeconds != nil && *opts.TimeoutSeconds > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(*opts.TimeoutSeconds)*time.Second)
+			defer cancel()
+		}
+		retryPolicy = BuildKBAgentRetryPolicy(opts.RetryPolicy)
+	}
+
+	var abortErrors []error
+	for _, pod := range a.pods {
+		if err := a.abortAt(ctx, cli, pod, req, retryPolicy); err != nil {
+			abortErrors = append(abortErrors, err)
+		}
+	}
+	if len(abortErrors) > 0 {
+		return newActionAggregateError(abortErrors)
+	}
+	return nil
+}
+
+// abortAt cancels the action at the pod, and retries on failures as the retry policy specified.
+func (a *kbagent) abortAt(ctx context.Context, cli client.Reader, pod *corev1.Pod, req proto.ActionRequest, retryPolicy *proto.RetryPolicy) error {
+	lfa := &namedAction{action: req.Action}
+	abort := func() error {
+		agentCli, err := a.agentClient(ctx, cli, pod, req.Action)
+		if err != nil {
+			return errors.Wrapf(err, "error creating client to abort action %s at pod %s", req.Action, pod.Name)
+		}
+		if agentCli == nil {
+			return nil // not kb-agent container and port defined, for test only
+		}
+		rsp, err := agentCli.Action(ctx, req)
+		_ = agentCli.Close()
+
+		switch {
+		case err != nil:
+			return errors.Wrapf(err, "http error occurred when aborting action %s at pod %s", req.Action, pod.Name)
+		case len(rsp.Error) > 0:
+			abortErr := a.formatError(lfa, rsp, pod.Name)
+			if errors.Is(abortErr, ErrActionNotDefined) {
+				return nil // the action is not defined in the pod, nothing to abort
+			}
+			return abortErr
+		}
+		return nil
+	}
+
+	var err error
+	for i := 0; ; i++ {
+		if err = abort(); err == nil || retryPolicy == nil || i >= retryPolicy.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryPolicy.RetryInterval):
+		}
+	}
+}
+
+// namedAction refers to an action by its name only, it is used to operate on the running actions.
+type namedAction struct {
+	action string
+}
+
+func (a *namedAction) name() string {
+	return a.action
+}
+
+func (a *namedAction) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return nil, nil
+}
+
 func (a *kbagent) selectTargetPods(spec *appsv1.Action) ([]*corev1.Pod, error) {
 	return SelectTargetPods(a.pods, a.pod, spec)
 }
@@ -471,6 +685,22 @@ func (a *kbagent) serverEndpoint(pod *corev1.Pod) (string, int32, error) {
 	return host, port, nil
 }
 
+// serverCredential loads the credential of the kb-agent server if the pod is launched with it.
+func (a *kbagent) serverCredential(ctx context.Context, cli client.Reader, pod *corev1.Pod) (*kbautil.Credential, error) {
+	if cli == nil || !kbagt.AuthEnabled(pod) {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{
+		Namespace: pod.Namespace,
+		Name:      kbagt.AuthSecretName(a.clusterName, a.compName),
+	}
+	if err := cli.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the kb-agent credential of pod %s", pod.Name)
+	}
+	return kbautil.CredentialFromData(secret.Data), nil
+}
+
 func (a *kbagent) formatError(lfa lifecycleAction, rsp proto.ActionResponse, podName string) error {
 	wrapError := func(err error) error {
 		return errors.Wrapf(err, "action: %s, executed on pod: %s, error: %s", lfa.name(), podName, rsp.Message)
@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+const (
+	upgradeFromCompDef        = "KB_UPGRADE_FROM_COMP_DEF"
+	upgradeToCompDef          = "KB_UPGRADE_TO_COMP_DEF"
+	upgradeFromServiceVersion = "KB_UPGRADE_FROM_SERVICE_VERSION"
+	upgradeToServiceVersion   = "KB_UPGRADE_TO_SERVICE_VERSION"
+)
+
+// ComponentVersion identifies the version of a component that an upgrade moves from or to.
+type ComponentVersion struct {
+	CompDef        string `json:"compDef,omitempty"`
+	ServiceVersion string `json:"serviceVersion,omitempty"`
+}
+
+type preUpgrade struct {
+	from   ComponentVersion
+	to     ComponentVersion
+	action *appsv1.Action
+}
+
+var _ lifecycleAction = &preUpgrade{}
+
+func (a *preUpgrade) name() string {
+	return "preUpgrade"
+}
+
+func (a *preUpgrade) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return upgradeParameters(a.from, a.to), nil
+}
+
+type postUpgrade struct {
+	from   ComponentVersion
+	to     ComponentVersion
+	action *appsv1.Action
+}
+
+var _ lifecycleAction = &postUpgrade{}
+
+func (a *postUpgrade) name() string {
+	return "postUpgrade"
+}
+
+func (a *postUpgrade) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return upgradeParameters(a.from, a.to), nil
+}
+
+func upgradeParameters(from, to ComponentVersion) map[string]string {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_UPGRADE_FROM_COMP_DEF: The name of the ComponentDefinition being upgraded from.
+	// - KB_UPGRADE_TO_COMP_DEF: The name of the ComponentDefinition being upgraded to.
+	// - KB_UPGRADE_FROM_SERVICE_VERSION: The service version being upgraded from.
+	// - KB_UPGRADE_TO_SERVICE_VERSION: The service version being upgraded to.
+	return map[string]string{
+		upgradeFromCompDef:        from.CompDef,
+		upgradeToCompDef:          to.CompDef,
+		upgradeFromServiceVersion: from.ServiceVersion,
+		upgradeToServiceVersion:   to.ServiceVersion,
+	}
+}
@@ -35,6 +35,13 @@ type Options struct {
 	RetryPolicy                *appsv1.RetryPolicy
 	Arguments                  [][]string
 	PreConditionObjectSelector client.MatchingLabels
+	// Outputs holds the outputs of the actions invoked by the same operation, keyed by `<action>.<output>`.
+	// They are passed to the action called, and the outputs declared by the action are recorded into it on success.
+	Outputs map[string]string
+	// DryRun calls the action in dry-run mode, it is only allowed for the actions that declare the dry-run support.
+	// The output of the action is kept in DryRunOutput, and the outputs declared by the action are not recorded.
+	DryRun       bool
+	DryRunOutput []byte
 }
 
 type Lifecycle interface {
@@ -58,7 +65,15 @@ type Lifecycle interface {
 
 	AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error
 
+	PreUpgrade(ctx context.Context, cli client.Reader, opts *Options, from, to ComponentVersion) error
+
+	PostUpgrade(ctx context.Context, cli client.Reader, opts *Options, from, to ComponentVersion) error
+
 	UserDefined(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action, args map[string]string) error
+
+	// Abort cancels the in-flight non-blocking action with the given name on all pods, it succeeds if the action is not running.
+	// The timeout and retry policy in opts apply to the cancellation requests.
+	Abort(ctx context.Context, cli client.Reader, opts *Options, name string) error
 }
 
 func New(namespace, clusterName, compName string, lifecycleActions *appsv1.ComponentLifecycleActions,
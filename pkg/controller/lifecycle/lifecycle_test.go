@@ -40,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type mockReader struct {
@@ -412,6 +413,40 @@ var _ = Describe("lifecycle", func() {
 			Expect(err.Error()).Should(ContainSubstring("command not found"))
 		})
 
+		It("fail - audit summary", func() {
+			viper.Set(constant.FeatureGateKBAgentAuditLog, true)
+			defer viper.Set(constant.FeatureGateKBAgentAuditLog, false)
+
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Return(proto.ActionResponse{
+					Error:   proto.Error2Type(proto.ErrFailed),
+					Message: "exit code: 1",
+				}, nil).AnyTimes()
+				recorder.Audit(gomock.Any(), proto.AuditRequest{Action: "postProvision", Limit: 1}).Return(proto.AuditResponse{
+					Records: []proto.AuditRecord{
+						{
+							Action:     "postProvision",
+							StartTime:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+							Elapsed:    1500 * time.Millisecond,
+							Error:      proto.Error2Type(proto.ErrFailed),
+							Message:    "exit code: 1",
+							OutputSize: 19,
+						},
+					},
+				}, nil).AnyTimes()
+			})
+
+			err = lifecycle.PostProvision(ctx, k8sClient, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, ErrActionFailed)).Should(BeTrue())
+			Expect(AuditSummary(fmt.Errorf("requeue: %w", err))).Should(Equal(
+				fmt.Sprintf("action postProvision at pod %s started at 2026-01-01T00:00:00Z, took 1.5s, failed: exit code: 1, output: 19 bytes", pods[0].Name)))
+		})
+
 		It("parameters", func() {
 			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
 			Expect(err).Should(BeNil())
@@ -453,6 +488,65 @@ var _ = Describe("lifecycle", func() {
 			Expect(err).Should(BeNil())
 		})
 
+		It("upgrade parameters", func() {
+			lifecycleActions.PreUpgrade = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n pre-upgrade"},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("preUpgrade"))
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_UPGRADE_FROM_COMP_DEF", "compdef-1.0"))
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_UPGRADE_TO_COMP_DEF", "compdef-2.0"))
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_UPGRADE_FROM_SERVICE_VERSION", "1.0.0"))
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_UPGRADE_TO_SERVICE_VERSION", "2.0.0"))
+					return proto.ActionResponse{}, nil
+				}).AnyTimes()
+			})
+
+			from := ComponentVersion{CompDef: "compdef-1.0", ServiceVersion: "1.0.0"}
+			to := ComponentVersion{CompDef: "compdef-2.0", ServiceVersion: "2.0.0"}
+			Expect(lifecycle.PreUpgrade(ctx, k8sClient, nil, from, to)).Should(Succeed())
+
+			err = lifecycle.PostUpgrade(ctx, k8sClient, nil, from, to)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, ErrActionNotDefined)).Should(BeTrue())
+		})
+
+		It("dry-run", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.DryRun).ShouldNot(BeNil())
+					Expect(*req.DryRun).Should(BeTrue())
+					return proto.ActionResponse{Output: []byte("would do")}, nil
+				}).Times(1)
+			})
+
+			action := &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n would do"},
+				},
+				SupportDryRun: true,
+			}
+			opts := &Options{DryRun: true}
+			Expect(lifecycle.UserDefined(ctx, k8sClient, opts, "check", action, nil)).Should(Succeed())
+			Expect(opts.DryRunOutput).Should(Equal([]byte("would do")))
+
+			action.SupportDryRun = false
+			err = lifecycle.UserDefined(ctx, k8sClient, &Options{DryRun: true}, "check", action, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, ErrActionNotImplemented)).Should(BeTrue())
+		})
+
 		It("template vars", func() {
 			key := "TEMPLATE_VAR1"
 			val := "template-vars1"
@@ -1038,4 +1132,81 @@ var _ = Describe("lifecycle", func() {
 			// TODO: impl
 		})
 	})
+
+	Context("abort", func() {
+		BeforeEach(func() {
+			pods = []*corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "pod-0"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "pod-1"}},
+			}
+		})
+
+		It("cancels the action on all pods", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("memberJoin"))
+					Expect(req.Operation).Should(Equal(proto.ActionOperationCancel))
+					return proto.ActionResponse{}, nil
+				}).Times(2)
+			})
+
+			Expect(lifecycle.Abort(ctx, k8sClient, nil, "memberJoin")).Should(Succeed())
+		})
+
+		It("ignores pods that have the action not defined", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					return proto.ActionResponse{Error: proto.Error2Type(proto.ErrNotDefined)}, nil
+				}).Times(2)
+			})
+
+			Expect(lifecycle.Abort(ctx, k8sClient, nil, "memberJoin")).Should(Succeed())
+		})
+
+		It("aggregates errors", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					return proto.ActionResponse{Error: proto.Error2Type(proto.ErrInternalError)}, nil
+				}).Times(2)
+			})
+
+			err = lifecycle.Abort(ctx, k8sClient, nil, "memberJoin")
+			Expect(errors.Is(err, ErrActionInternalError)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("pod-0"))
+			Expect(err.Error()).Should(ContainSubstring("pod-1"))
+		})
+
+		It("retries as the retry policy specified", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods)
+			Expect(err).Should(BeNil())
+
+			calls := map[string]int{}
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.Operation).Should(Equal(proto.ActionOperationCancel))
+					calls[req.Action]++
+					if calls[req.Action] < 3 {
+						return proto.ActionResponse{Error: proto.Error2Type(proto.ErrInternalError)}, nil
+					}
+					return proto.ActionResponse{}, nil
+				}).Times(3)
+			})
+
+			lifecycle.(*kbagent).pods = pods[:1]
+			opts := &Options{
+				TimeoutSeconds: ptr.To[int32](10),
+				RetryPolicy:    &appsv1.RetryPolicy{MaxRetries: 2},
+			}
+			Expect(lifecycle.Abort(ctx, k8sClient, opts, "memberJoin")).Should(Succeed())
+		})
+	})
 })
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+const (
+	outputParameterPrefix = "KB_OUTPUT_"
+	maxOutputValueLength  = 1024
+)
+
+// OutputKey returns the key of an action output recorded in the outputs of an operation.
+func OutputKey(action, output string) string {
+	return action + "." + output
+}
+
+// outputParameters returns the outputs recorded as parameters, named as KB_OUTPUT_<ACTION>_<OUTPUT>.
+func outputParameters(outputs map[string]string) map[string]string {
+	parameters := make(map[string]string, len(outputs))
+	for key, val := range outputs {
+		parameters[shellSafeParameterAlias(outputParameterPrefix+key)] = val
+	}
+	return parameters
+}
+
+// recordOutputs reads the outputs declared by the action from the JSON object it printed, and records them.
+func recordOutputs(action string, spec *appsv1.Action, output []byte, outputs map[string]string) error {
+	if len(spec.Outputs) == 0 {
+		return nil
+	}
+	fields := map[string]json.RawMessage{}
+	if len(bytes.TrimSpace(output)) > 0 {
+		if err := json.Unmarshal(output, &fields); err != nil {
+			return errors.Wrapf(ErrActionFailed, "%s: the output is not a JSON object: %s", action, err.Error())
+		}
+	}
+	for _, o := range spec.Outputs {
+		raw, ok := fields[o.Name]
+		if !ok || string(raw) == "null" {
+			if o.Required {
+				return errors.Wrapf(ErrActionFailed, "%s: the required output %s is missing", action, o.Name)
+			}
+			continue
+		}
+		var val string
+		if err := json.Unmarshal(raw, &val); err != nil {
+			val = string(raw) // keep the non-string value in its JSON form
+		}
+		if len(val) > maxOutputValueLength {
+			return errors.Wrapf(ErrActionFailed, "%s: the output %s exceeds %d bytes", action, o.Name, maxOutputValueLength)
+		}
+		outputs[OutputKey(action, o.Name)] = val
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+func TestRecordOutputs(t *testing.T) {
+	spec := &appsv1.Action{
+		Outputs: []appsv1.ActionOutput{
+			{Name: "leader", Required: true},
+			{Name: "term"},
+			{Name: "position"},
+			{Name: "missing"},
+		},
+	}
+	outputs := map[string]string{}
+	err := recordOutputs("switchover", spec, []byte(`{"leader":"pod-1","term":3,"position":{"file":"binlog.000002","pos":154},"others":"x"}`), outputs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"switchover.leader":   "pod-1",
+		"switchover.term":     "3",
+		"switchover.position": `{"file":"binlog.000002","pos":154}`,
+	}
+	if len(outputs) != len(expected) {
+		t.Fatalf("outputs mismatch, got %v", outputs)
+	}
+	for k, v := range expected {
+		if outputs[k] != v {
+			t.Fatalf("output %s mismatch, got %q, want %q", k, outputs[k], v)
+		}
+	}
+}
+
+func TestRecordOutputsFailed(t *testing.T) {
+	spec := &appsv1.Action{
+		Outputs: []appsv1.ActionOutput{{Name: "leader", Required: true}},
+	}
+	for _, output := range []string{"pod-1", `{"term":3}`, `{"leader":null}`, ""} {
+		err := recordOutputs("switchover", spec, []byte(output), map[string]string{})
+		if !errors.Is(err, ErrActionFailed) {
+			t.Fatalf("expect action failed error for output %q, got %v", output, err)
+		}
+	}
+}
+
+func TestRecordOutputsNotDeclared(t *testing.T) {
+	outputs := map[string]string{}
+	if err := recordOutputs("memberLeave", &appsv1.Action{}, []byte("not a json"), outputs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(outputs) != 0 {
+		t.Fatalf("expect no outputs, got %v", outputs)
+	}
+}
+
+func TestOutputParameters(t *testing.T) {
+	parameters := outputParameters(map[string]string{
+		OutputKey("switchover", "leader"):    "pod-1",
+		OutputKey("preUpgrade", "catalogID"): "42",
+	})
+	if got := parameters["KB_OUTPUT_SWITCHOVER_LEADER"]; got != "pod-1" {
+		t.Fatalf("switchover output parameter mismatch, got %q", got)
+	}
+	if got := parameters["KB_OUTPUT_PREUPGRADE_CATALOGID"]; got != "42" {
+		t.Fatalf("preUpgrade output parameter mismatch, got %q", got)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+const (
+	ReasonDriftReverted = "DriftReverted" // ReasonDriftReverted the drifts are reverted to the desired state
+	ReasonDriftDetected = "DriftDetected" // ReasonDriftDetected the drifts are kept in the live objects and reported as warnings
+	ReasonDriftAdopted  = "DriftAdopted"  // ReasonDriftAdopted the drifts are accepted and kept in the live objects
+)
+
+// maxDriftPathsPerObject limits the number of drifted fields reported for each object.
+const maxDriftPathsPerObject = 5
+
+// driftSections are the paths of the object to be compared for drifts. The status and the other metadata
+// are maintained by the server, and the annotations are widely used to record the states of the controllers,
+// so they are not compared.
+var driftSections = [][]string{
+	{"metadata", "labels"},
+	{"spec"},
+	{"data"},
+	{"binaryData"},
+}
+
+// ObjectDrift describes the fields of a live object that have drifted from the desired state.
+type ObjectDrift struct {
+	Kind  string
+	Name  string
+	Paths []string
+}
+
+func (d ObjectDrift) String() string {
+	paths := d.Paths
+	if len(paths) > maxDriftPathsPerObject {
+		paths = append(slices.Clone(paths[:maxDriftPathsPerObject]), fmt.Sprintf("and %d more", len(d.Paths)-maxDriftPathsPerObject))
+	}
+	return fmt.Sprintf("%s/%s: %s", d.Kind, d.Name, strings.Join(paths, ", "))
+}
+
+// DetectObjectDrift compares the live object with the desired one, which is the object the controller would write,
+// and returns the fields of the live object that have drifted. It returns nil if there is no drift.
+//
+// Only the labels, spec and data of the objects are compared.
+func DetectObjectDrift(desired, live client.Object) (*ObjectDrift, error) {
+	desiredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	liveObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, section := range driftSections {
+		diffDriftValues(driftSectionValue(desiredObj, section), driftSectionValue(liveObj, section), strings.Join(section, "."), &paths)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	return &ObjectDrift{
+		Kind:  reflect.TypeOf(live).Elem().Name(),
+		Name:  live.GetName(),
+		Paths: paths,
+	}, nil
+}
+
+// DriftConditionReason returns the reason of the Drifted condition for the drift policy.
+func DriftConditionReason(policy appsv1.DriftPolicyType) string {
+	switch policy {
+	case appsv1.WarnDriftPolicyType:
+		return ReasonDriftDetected
+	case appsv1.AdoptDriftPolicyType:
+		return ReasonDriftAdopted
+	default:
+		return ReasonDriftReverted
+	}
+}
+
+// DriftConditionStatus returns the status of the Drifted condition for the drift policy. The adopted drifts are
+// the new baseline of the live objects, so they are reported with the False status.
+func DriftConditionStatus(policy appsv1.DriftPolicyType) metav1.ConditionStatus {
+	if policy == appsv1.AdoptDriftPolicyType {
+		return metav1.ConditionFalse
+	}
+	return metav1.ConditionTrue
+}
+
+// FormatObjectDrifts formats the drifts in the order of kind and name.
+func FormatObjectDrifts(drifts []ObjectDrift) string {
+	messages := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		messages = append(messages, drift.String())
+	}
+	slices.Sort(messages)
+	return strings.Join(messages, "; ")
+}
+
+func driftSectionValue(obj map[string]any, section []string) any {
+	var value any = obj
+	for _, field := range section {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[field]
+	}
+	return value
+}
+
+func diffDriftValues(desired, live any, path string, paths *[]string) {
+	if isEmptyDriftValue(desired) && isEmptyDriftValue(live) {
+		return
+	}
+	desiredMap, ok1 := desired.(map[string]any)
+	liveMap, ok2 := live.(map[string]any)
+	if ok1 && ok2 {
+		keys := make([]string, 0, len(desiredMap)+len(liveMap))
+		for k := range desiredMap {
+			keys = append(keys, k)
+		}
+		for k := range liveMap {
+			if _, ok := desiredMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffDriftValues(desiredMap[k], liveMap[k], joinDriftPath(path, k), paths)
+		}
+		return
+	}
+	desiredList, ok1 := desired.([]any)
+	liveList, ok2 := live.([]any)
+	if ok1 && ok2 && len(desiredList) == len(liveList) {
+		for i := range desiredList {
+			diffDriftValues(desiredList[i], liveList[i], fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+		return
+	}
+	if !reflect.DeepEqual(desired, live) {
+		*paths = append(*paths, path)
+	}
+}
+
+func isEmptyDriftValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func joinDriftPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	return path + "." + key
+}
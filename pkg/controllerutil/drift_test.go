@@ -0,0 +1,145 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDetectObjectDrift(t *testing.T) {
+	newService := func() *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "mysql",
+				Namespace:       "default",
+				ResourceVersion: "1",
+				Labels:          map[string]string{"app.kubernetes.io/instance": "test"},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": "mysql"},
+				Ports:    []corev1.ServicePort{{Name: "mysql", Port: 3306}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(live *corev1.Service)
+		paths  []string
+	}{
+		{
+			name:   "no drift",
+			mutate: func(live *corev1.Service) {},
+		},
+		{
+			name: "server maintained fields",
+			mutate: func(live *corev1.Service) {
+				live.ResourceVersion = "2"
+				live.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			},
+		},
+		{
+			name: "annotations",
+			mutate: func(live *corev1.Service) {
+				live.Annotations = map[string]string{"kubeblocks.io/generation": "2"}
+			},
+		},
+		{
+			name: "empty and nil values",
+			mutate: func(live *corev1.Service) {
+				live.Spec.ExternalIPs = []string{}
+			},
+		},
+		{
+			name: "spec fields",
+			mutate: func(live *corev1.Service) {
+				live.Spec.Type = corev1.ServiceTypeNodePort
+				live.Spec.Ports[0].Port = 3307
+			},
+			paths: []string{"spec.ports[0].port", "spec.type"},
+		},
+		{
+			name: "list length",
+			mutate: func(live *corev1.Service) {
+				live.Spec.Ports = append(live.Spec.Ports, corev1.ServicePort{Name: "admin", Port: 33062})
+			},
+			paths: []string{"spec.ports"},
+		},
+		{
+			name: "labels",
+			mutate: func(live *corev1.Service) {
+				live.Labels["app.kubernetes.io/instance"] = "edited"
+				live.Labels["extra"] = "true"
+			},
+			paths: []string{"metadata.labels[app.kubernetes.io/instance]", "metadata.labels.extra"},
+		},
+	}
+	for _, tc := range cases {
+		desired := newService()
+		live := desired.DeepCopy()
+		tc.mutate(live)
+		drift, err := DetectObjectDrift(desired, live)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(tc.paths) == 0 {
+			if drift != nil {
+				t.Errorf("%s: expect no drift, got %v", tc.name, drift)
+			}
+			continue
+		}
+		if drift == nil {
+			t.Fatalf("%s: expect drift %v, got nil", tc.name, tc.paths)
+		}
+		if drift.Kind != "Service" || drift.Name != "mysql" || !reflect.DeepEqual(drift.Paths, tc.paths) {
+			t.Errorf("%s: got %v, want Service/mysql %v", tc.name, drift, tc.paths)
+		}
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-config"},
+		Data:       map[string]string{"my.cnf": "[mysqld]"},
+	}
+	live := desired.DeepCopy()
+	live.Data["my.cnf"] = "[mysqld]\nmax_connections=100"
+	drift, err := DetectObjectDrift(desired, live)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if drift == nil || drift.String() != "ConfigMap/mysql-config: data[my.cnf]" {
+		t.Errorf("unexpected config map drift: %v", drift)
+	}
+}
+
+func TestFormatObjectDrifts(t *testing.T) {
+	drifts := []ObjectDrift{
+		{Kind: "Service", Name: "mysql", Paths: []string{"spec.type"}},
+		{Kind: "InstanceSet", Name: "mysql", Paths: []string{"a", "b", "c", "d", "e", "f", "g"}},
+	}
+	expected := "InstanceSet/mysql: a, b, c, d, e, and 2 more; Service/mysql: spec.type"
+	if message := FormatObjectDrifts(drifts); message != expected {
+		t.Errorf("got %q, want %q", message, expected)
+	}
+}
@@ -411,7 +411,8 @@ func (m *definedPortManager) ReleaseByPrefix(prefix string) error {
 }
 
 func (m *definedPortManager) isKBAgentPort(containerName, portName string) bool {
-	return containerName == kbagent.ContainerName && (portName == kbagent.DefaultHTTPPortName || portName == kbagent.DefaultStreamingPortName)
+	return containerName == kbagent.ContainerName && (portName == kbagent.DefaultHTTPPortName ||
+		portName == kbagent.DefaultStreamingPortName || portName == kbagent.DefaultMetricsPortName)
 }
 
 func (m *definedPortManager) hasKBAgentPortDefined() bool {
@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// ErrInvalidNamespaceMaintenanceWindow indicates that the maintenance window annotated on the namespace is invalid.
+// The namespace-level window is shared by all the clusters in the namespace, so callers should treat it as no window
+// rather than blocking the clusters.
+var ErrInvalidNamespaceMaintenanceWindow = errors.New("invalid maintenance window annotated on namespace")
+
+// GetMaintenanceWindow returns the maintenance window of the cluster.
+// The cluster's spec.maintenanceWindow takes precedence over the one annotated on the namespace.
+// An error wrapping ErrInvalidNamespaceMaintenanceWindow is returned if the window annotated on the namespace is invalid.
+func GetMaintenanceWindow(ctx context.Context, cli client.Reader, cluster *appsv1.Cluster) (*appsv1.MaintenanceWindow, error) {
+	if cluster.Spec.MaintenanceWindow != nil {
+		return cluster.Spec.MaintenanceWindow, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: cluster.Namespace}, namespace); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	value := namespace.Annotations[constant.MaintenanceWindowAnnotationKey]
+	if len(value) == 0 {
+		return nil, nil
+	}
+	window := &appsv1.MaintenanceWindow{}
+	if err := json.Unmarshal([]byte(value), window); err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidNamespaceMaintenanceWindow, cluster.Namespace, err.Error())
+	}
+	if _, _, err := CheckMaintenanceWindow(window, time.Now()); err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidNamespaceMaintenanceWindow, cluster.Namespace, err.Error())
+	}
+	return window, nil
+}
+
+// IsMaintenanceWindowBypassed checks whether the maintenance window is bypassed for the spec changes of the cluster.
+func IsMaintenanceWindowBypassed(cluster *appsv1.Cluster) bool {
+	value := cluster.Annotations[constant.BypassMaintenanceWindowAnnotationKey]
+	return len(value) > 0 && value != "false"
+}
+
+// CheckMaintenanceWindow checks whether the maintenance window is open at the given time,
+// and returns the time when the window opens next if it is closed.
+// A nil window is treated as always open.
+func CheckMaintenanceWindow(window *appsv1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if window == nil {
+		return true, time.Time{}, nil
+	}
+	if window.Duration.Duration <= 0 {
+		return false, time.Time{}, fmt.Errorf("the duration of the maintenance window must be positive: %s", window.Duration.Duration)
+	}
+	location := time.UTC
+	if len(window.TimeZone) > 0 {
+		var err error
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid time zone %q of the maintenance window: %w", window.TimeZone, err)
+		}
+	}
+	schedule, err := common.ParseCronSchedule(window.Schedule, location)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule of the maintenance window: %w", err)
+	}
+	// the window is open if it is opened within the last duration.
+	opensAt := schedule.Next(now.Add(-window.Duration.Duration))
+	if opensAt.IsZero() {
+		return false, time.Time{}, fmt.Errorf("no time is matched by the schedule of the maintenance window: %s", window.Schedule)
+	}
+	if !opensAt.After(now) {
+		return true, time.Time{}, nil
+	}
+	return false, opensAt, nil
+}
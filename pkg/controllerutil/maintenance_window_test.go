@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestCheckMaintenanceWindow(t *testing.T) {
+	// opens at 02:00 every Saturday for 4 hours, 2026-05-09 is a Saturday
+	window := &appsv1.MaintenanceWindow{
+		Schedule: "0 2 * * 6",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+	opensAt := time.Date(2026, 5, 9, 2, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		now         time.Time
+		open        bool
+		nextOpensAt time.Time
+	}{
+		{"before the window", opensAt.Add(-time.Hour), false, opensAt},
+		{"at the opening", opensAt, true, time.Time{}},
+		{"within the window", opensAt.Add(3 * time.Hour), true, time.Time{}},
+		{"at the closing", opensAt.Add(4 * time.Hour), false, opensAt.AddDate(0, 0, 7)},
+		{"after the window", opensAt.Add(5 * time.Hour), false, opensAt.AddDate(0, 0, 7)},
+	}
+	for _, tc := range cases {
+		open, nextOpensAt, err := CheckMaintenanceWindow(window, tc.now)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if open != tc.open || !nextOpensAt.Equal(tc.nextOpensAt) {
+			t.Errorf("%s: got (%v, %s), want (%v, %s)", tc.name, open, nextOpensAt, tc.open, tc.nextOpensAt)
+		}
+	}
+
+	if open, _, err := CheckMaintenanceWindow(nil, opensAt); err != nil || !open {
+		t.Errorf("nil window should be always open, got (%v, %v)", open, err)
+	}
+
+	for _, invalid := range []*appsv1.MaintenanceWindow{
+		{Schedule: "0 2 * * 8", Duration: metav1.Duration{Duration: time.Hour}},
+		{Schedule: "0 2 * * 6"},
+		{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Invalid/Zone"},
+	} {
+		if _, _, err := CheckMaintenanceWindow(invalid, opensAt); err == nil {
+			t.Errorf("invalid window %+v should return an error", invalid)
+		}
+	}
+}
+
+func TestGetMaintenanceWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			Annotations: map[string]string{
+				constant.MaintenanceWindowAnnotationKey: `{"schedule":"0 2 * * 6","duration":"4h","timeZone":"UTC"}`,
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).Build()
+	cluster := &appsv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	window, err := GetMaintenanceWindow(context.Background(), cli, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window == nil || window.Schedule != "0 2 * * 6" || window.Duration.Duration != 4*time.Hour {
+		t.Errorf("unexpected maintenance window of the namespace: %+v", window)
+	}
+
+	cluster.Spec.MaintenanceWindow = &appsv1.MaintenanceWindow{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: time.Hour}}
+	if window, _ = GetMaintenanceWindow(context.Background(), cli, cluster); window != cluster.Spec.MaintenanceWindow {
+		t.Errorf("the maintenance window of the cluster should take precedence, got %+v", window)
+	}
+
+	cluster.Spec.MaintenanceWindow = nil
+	cluster.Namespace = "not-exist"
+	if window, err = GetMaintenanceWindow(context.Background(), cli, cluster); err != nil || window != nil {
+		t.Errorf("expect no maintenance window, got (%+v, %v)", window, err)
+	}
+
+	cluster.Namespace = "default"
+	for _, invalid := range []string{`{"schedule":`, `{"schedule":"0 2 * *","duration":"4h"}`, `{"schedule":"0 2 * * 6"}`} {
+		namespace.Annotations[constant.MaintenanceWindowAnnotationKey] = invalid
+		if err = cli.Update(context.Background(), namespace); err != nil {
+			t.Fatal(err)
+		}
+		window, err = GetMaintenanceWindow(context.Background(), cli, cluster)
+		if window != nil || !errors.Is(err, ErrInvalidNamespaceMaintenanceWindow) {
+			t.Errorf("invalid window %s annotated on namespace: expect the invalid error, got (%+v, %v)", invalid, window, err)
+		}
+	}
+}
+
+func TestIsMaintenanceWindowBypassed(t *testing.T) {
+	cluster := &appsv1.Cluster{}
+	if IsMaintenanceWindowBypassed(cluster) {
+		t.Error("the maintenance window should not be bypassed without the annotation")
+	}
+	for value, bypassed := range map[string]bool{"true": true, "ops-restart": true, "false": false} {
+		cluster.Annotations = map[string]string{constant.BypassMaintenanceWindowAnnotationKey: value}
+		if IsMaintenanceWindowBypassed(cluster) != bypassed {
+			t.Errorf("annotation value %q: expect bypassed %v", value, bypassed)
+		}
+	}
+}
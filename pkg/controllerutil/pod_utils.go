@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -286,6 +287,20 @@ func PodIsReadyWithLabel(pod corev1.Pod) bool {
 	return IsPodReady(&pod)
 }
 
+// GetPodReplicationStatus gets the replication status of pod reported by the structured role probe output,
+// nil is returned if it is not reported or malformed.
+func GetPodReplicationStatus(pod *corev1.Pod) *workloads.ReplicationStatus {
+	str := pod.Annotations[constant.RoleReplicationStatusAnnotationKey]
+	if str == "" {
+		return nil
+	}
+	status := &workloads.ReplicationStatus{}
+	if err := json.Unmarshal([]byte(str), status); err != nil {
+		return nil
+	}
+	return status
+}
+
 // GetPodRevision gets the revision of Pod by inspecting the StatefulSetRevisionLabel. If pod has no revision empty
 // string is returned.
 func GetPodRevision(pod *corev1.Pod) string {
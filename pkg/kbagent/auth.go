@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package kbagent
+
+import (
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	AuthVolumeName = "kbagent-auth"
+	AuthMountPath  = "/etc/kubeblocks/kbagent-auth"
+)
+
+// AuthSecretName returns the name of the secret that holds the kb-agent credential of a component.
+func AuthSecretName(clusterName, compName string) string {
+	return clusterName + "-" + compName + "-kbagent-auth"
+}
+
+// AuthArgs returns the kb-agent args to load the credential from the mounted auth secret.
+func AuthArgs() []string {
+	return []string{
+		"--auth-token-file", filepath.Join(AuthMountPath, util.AuthTokenKey),
+		"--tls-ca-file", filepath.Join(AuthMountPath, util.AuthCAFileKey),
+		"--tls-cert-file", filepath.Join(AuthMountPath, util.AuthCertKey),
+		"--tls-key-file", filepath.Join(AuthMountPath, util.AuthKeyKey),
+	}
+}
+
+// AuthSecretItems returns the keys of the auth secret to mount, the CA key is used to renew the certificate
+// by the controller only and is never mounted.
+func AuthSecretItems() []corev1.KeyToPath {
+	var items []corev1.KeyToPath
+	for _, key := range []string{util.AuthTokenKey, util.AuthCAFileKey, util.AuthCertKey, util.AuthKeyKey} {
+		items = append(items, corev1.KeyToPath{Key: key, Path: key})
+	}
+	return items
+}
+
+// AuthEnabled checks whether the kb-agent server in the pod is launched with the auth secret mounted.
+func AuthEnabled(pod *corev1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != ContainerName {
+			continue
+		}
+		for _, m := range c.VolumeMounts {
+			if m.Name == AuthVolumeName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the kb-agent, when the circuit of the pod is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "halfOpen"
+)
+
+// circuitBreaker breaks the calls to a pod after the consecutive failures reach the threshold. Once the circuit has
+// been open for the duration, it turns half-open and lets a single probe call through, the circuit is closed if the
+// probe succeeds, otherwise it is open again.
+type circuitBreaker struct {
+	name         string
+	threshold    int
+	openDuration time.Duration
+
+	mutex    sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(name string, threshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		name:         name,
+		threshold:    threshold,
+		openDuration: openDuration,
+		state:        circuitClosed,
+	}
+}
+
+// allow checks whether a call is allowed, the done function should be called with the result of the call if allowed.
+func (b *circuitBreaker) allow() (func(err error), error) {
+	if b.threshold <= 0 {
+		return func(error) {}, nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if wait := b.openDuration - time.Since(b.openedAt); wait > 0 {
+			return nil, fmt.Errorf("%w: %s is unreachable, retry after %s", ErrCircuitOpen, b.name, wait.Round(time.Second))
+		}
+		b.transit(circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if b.probing {
+			return nil, fmt.Errorf("%w: %s is being probed", ErrCircuitOpen, b.name)
+		}
+		b.probing = true
+	}
+	return b.done, nil
+}
+
+func (b *circuitBreaker) done(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+	switch {
+	case err == nil:
+		b.failures = 0
+		if b.state != circuitClosed {
+			b.transit(circuitClosed)
+		}
+	case errors.Is(err, context.Canceled):
+		// canceled by the caller, it says nothing about the pod
+	default:
+		b.failures++
+		if b.state == circuitHalfOpen || b.failures >= b.threshold {
+			b.openedAt = time.Now()
+			if b.state != circuitOpen {
+				b.transit(circuitOpen)
+			}
+		}
+	}
+}
+
+func (b *circuitBreaker) transit(state string) {
+	if b.state == circuitOpen {
+		openCircuits.Dec()
+	}
+	if state == circuitOpen {
+		openCircuits.Inc()
+	}
+	b.state = state
+	circuitTransitions.WithLabelValues(state).Inc()
+}
+
+// release releases the breaker when the pod is evicted from the manager.
+func (b *circuitBreaker) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.state == circuitOpen {
+		openCircuits.Dec()
+	}
+	b.state = circuitClosed
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 const (
@@ -36,6 +37,9 @@ const (
 type Client interface {
 	io.Closer
 	Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error)
+	BatchAction(ctx context.Context, req proto.BatchActionRequest) (proto.BatchActionResponse, error)
+	Audit(ctx context.Context, req proto.AuditRequest) (proto.AuditResponse, error)
+	File(ctx context.Context, req proto.FileRequest) (proto.FileResponse, error)
 }
 
 // HACK: for unit test only.
@@ -56,7 +60,8 @@ func GetMockClient() Client {
 	return mockClient
 }
 
-func NewClient(endpoint func() (string, int32, error)) (Client, error) {
+// NewClient creates a client to call the kb-agent server at the endpoint, the credential is optional.
+func NewClient(endpoint func() (string, int32, error), cred *util.Credential) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -69,6 +74,11 @@ func NewClient(endpoint func() (string, int32, error)) (Client, error) {
 		return nil, nil
 	}
 
+	tlsConfig, err := cred.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	// don't use default http-client
 	dialer := &net.Dialer{
 		Timeout: defaultConnectTimeout,
@@ -76,15 +86,22 @@ func NewClient(endpoint func() (string, int32, error)) (Client, error) {
 	transport := &http.Transport{
 		Dial:                dialer.Dial,
 		TLSHandshakeTimeout: defaultConnectTimeout,
+		TLSClientConfig:     tlsConfig,
 	}
 	cli := &http.Client{
 		// don't set timeout at client level
 		// Timeout:   time.Second * 30,
 		Transport: transport,
 	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
 	return &httpClient{
+		scheme: scheme,
 		host:   host,
 		port:   port,
 		client: cli,
+		cred:   cred,
 	}, nil
 }
@@ -75,3 +75,48 @@ func (mr *MockClientMockRecorder) Action(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Action", reflect.TypeOf((*MockClient)(nil).Action), arg0, arg1)
 }
+
+// BatchAction mocks base method.
+func (m *MockClient) BatchAction(arg0 context.Context, arg1 proto.BatchActionRequest) (proto.BatchActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAction", arg0, arg1)
+	ret0, _ := ret[0].(proto.BatchActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchAction indicates an expected call of BatchAction.
+func (mr *MockClientMockRecorder) BatchAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAction", reflect.TypeOf((*MockClient)(nil).BatchAction), arg0, arg1)
+}
+
+// Audit mocks base method.
+func (m *MockClient) Audit(arg0 context.Context, arg1 proto.AuditRequest) (proto.AuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Audit", arg0, arg1)
+	ret0, _ := ret[0].(proto.AuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Audit indicates an expected call of Audit.
+func (mr *MockClientMockRecorder) Audit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Audit", reflect.TypeOf((*MockClient)(nil).Audit), arg0, arg1)
+}
+
+// File mocks base method.
+func (m *MockClient) File(arg0 context.Context, arg1 proto.FileRequest) (proto.FileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "File", arg0, arg1)
+	ret0, _ := ret[0].(proto.FileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// File indicates an expected call of File.
+func (mr *MockClientMockRecorder) File(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "File", reflect.TypeOf((*MockClient)(nil).File), arg0, arg1)
+}
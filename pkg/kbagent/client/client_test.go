@@ -43,6 +43,18 @@ func (stubClient) Action(context.Context, proto.ActionRequest) (proto.ActionResp
 	return proto.ActionResponse{Message: "ok"}, nil
 }
 
+func (stubClient) BatchAction(context.Context, proto.BatchActionRequest) (proto.BatchActionResponse, error) {
+	return proto.BatchActionResponse{Message: "ok"}, nil
+}
+
+func (stubClient) Audit(context.Context, proto.AuditRequest) (proto.AuditResponse, error) {
+	return proto.AuditResponse{}, nil
+}
+
+func (stubClient) File(context.Context, proto.FileRequest) (proto.FileResponse, error) {
+	return proto.FileResponse{}, nil
+}
+
 func TestMockClientLifecycle(t *testing.T) {
 	t.Cleanup(UnsetMockClient)
 
@@ -54,7 +66,7 @@ func TestMockClientLifecycle(t *testing.T) {
 	got, err := NewClient(func() (string, int32, error) {
 		t.Fatal("endpoint should not be called when mock client is set")
 		return "", 0, nil
-	})
+	}, nil)
 	if err != nil || got != mock {
 		t.Fatalf("NewClient() = %v, %v, want mock nil-error", got, err)
 	}
@@ -64,7 +76,7 @@ func TestMockClientLifecycle(t *testing.T) {
 	got, err = NewClient(func() (string, int32, error) {
 		t.Fatal("endpoint should not be called when mock error is set")
 		return "", 0, nil
-	})
+	}, nil)
 	if got != nil || !errors.Is(err, mockErr) {
 		t.Fatalf("NewClient() = %v, %v, want nil mockErr", got, err)
 	}
@@ -77,15 +89,15 @@ func TestMockClientLifecycle(t *testing.T) {
 
 func TestNewClientEndpointBranches(t *testing.T) {
 	endpointErr := errors.New("endpoint")
-	if got, err := NewClient(func() (string, int32, error) { return "", 0, endpointErr }); got != nil || !errors.Is(err, endpointErr) {
+	if got, err := NewClient(func() (string, int32, error) { return "", 0, endpointErr }, nil); got != nil || !errors.Is(err, endpointErr) {
 		t.Fatalf("NewClient endpoint error = %v, %v", got, err)
 	}
 
-	if got, err := NewClient(func() (string, int32, error) { return "", 0, nil }); got != nil || err != nil {
+	if got, err := NewClient(func() (string, int32, error) { return "", 0, nil }, nil); got != nil || err != nil {
 		t.Fatalf("NewClient empty endpoint = %v, %v, want nil nil", got, err)
 	}
 
-	got, err := NewClient(func() (string, int32, error) { return "127.0.0.1", 3501, nil })
+	got, err := NewClient(func() (string, int32, error) { return "127.0.0.1", 3501, nil }, nil)
 	if err != nil {
 		t.Fatalf("NewClient returned error: %v", err)
 	}
@@ -124,7 +136,7 @@ func TestNewPortForwardClientStableBranches(t *testing.T) {
 	got, err := NewPortForwardClient(&corev1.Pod{}, func() (string, int32, error) {
 		t.Fatal("endpoint should not be called when mock client is set")
 		return "", 0, nil
-	})
+	}, nil)
 	if err != nil || got != mock {
 		t.Fatalf("NewPortForwardClient mock = %v, %v", got, err)
 	}
@@ -133,7 +145,7 @@ func TestNewPortForwardClientStableBranches(t *testing.T) {
 	endpointErr := errors.New("endpoint")
 	got, err = NewPortForwardClient(&corev1.Pod{}, func() (string, int32, error) {
 		return "", 0, endpointErr
-	})
+	}, nil)
 	if got != nil || !errors.Is(err, endpointErr) {
 		t.Fatalf("NewPortForwardClient endpoint error = %v, %v", got, err)
 	}
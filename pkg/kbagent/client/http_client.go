@@ -29,16 +29,19 @@ import (
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 const (
-	urlTemplate = "http://%s:%d%s"
+	urlTemplate = "%s://%s:%d%s"
 )
 
 type httpClient struct {
+	scheme string
 	host   string
 	port   int32
 	client *http.Client
+	cred   *util.Credential
 }
 
 var _ Client = &httpClient{}
@@ -61,7 +64,34 @@ func (c *httpClient) Action(ctx context.Context, req proto.ActionRequest) (proto
 		return rsp, err
 	}
 
-	url := fmt.Sprintf(urlTemplate, c.host, c.port, proto.ServiceAction.URI)
+	uri := proto.ServiceAction.URI
+	if req.Operation != proto.ActionOperationCall {
+		uri = proto.ServiceActionOperation.URI
+	}
+	url := fmt.Sprintf(urlTemplate, c.urlScheme(), c.host, c.port, uri)
+	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) BatchAction(ctx context.Context, req proto.BatchActionRequest) (proto.BatchActionResponse, error) {
+	rsp := proto.BatchActionResponse{}
+
+	dryRun, ok := ctx.Value(constant.DryRunContextKey).(bool)
+	if ok && dryRun {
+		return rsp, nil
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	url := fmt.Sprintf(urlTemplate, c.urlScheme(), c.host, c.port, proto.ServiceActionBatch.URI)
 	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return rsp, err
@@ -71,11 +101,62 @@ func (c *httpClient) Action(ctx context.Context, req proto.ActionRequest) (proto
 	return decode(payload, &rsp)
 }
 
+func (c *httpClient) Audit(ctx context.Context, req proto.AuditRequest) (proto.AuditResponse, error) {
+	rsp := proto.AuditResponse{}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	url := fmt.Sprintf(urlTemplate, c.urlScheme(), c.host, c.port, proto.ServiceAudit.URI)
+	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) File(ctx context.Context, req proto.FileRequest) (proto.FileResponse, error) {
+	rsp := proto.FileResponse{}
+
+	dryRun, ok := ctx.Value(constant.DryRunContextKey).(bool)
+	if ok && dryRun && (req.Operation == proto.FileOperationWrite || req.Operation == proto.FileOperationDelete) {
+		return rsp, nil
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	url := fmt.Sprintf(urlTemplate, c.urlScheme(), c.host, c.port, proto.ServiceFile.URI)
+	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) urlScheme() string {
+	if len(c.scheme) == 0 {
+		return "http"
+	}
+	return c.scheme
+}
+
 func (c *httpClient) request(ctx context.Context, method, url string, body io.Reader) (io.ReadCloser, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
+	if c.cred.TokenEnabled() {
+		req.Header.Set("Authorization", util.BearerPrefix+c.cred.Token)
+	}
 
 	rsp, err := c.client.Do(req)
 	if err != nil {
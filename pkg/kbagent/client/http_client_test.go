@@ -33,6 +33,7 @@ import (
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 type errorReader struct{}
@@ -126,3 +127,124 @@ func TestHTTPClientRequestAndDecodeErrors(t *testing.T) {
 		t.Fatalf("expected read error")
 	}
 }
+
+func TestHTTPClientActionWithToken(t *testing.T) {
+	cli, closeServer := newHTTPClientForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != util.BearerPrefix+"secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"done"}`))
+	})
+	defer closeServer()
+
+	if _, err := cli.Action(context.Background(), proto.ActionRequest{Action: "switchover"}); err == nil {
+		t.Fatalf("expected unauthorized error without token")
+	}
+
+	cli.cred = &util.Credential{Token: "secret"}
+	resp, err := cli.Action(context.Background(), proto.ActionRequest{Action: "switchover"})
+	if err != nil {
+		t.Fatalf("Action() error = %v", err)
+	}
+	if resp.Message != "done" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestHTTPClientActionOperation(t *testing.T) {
+	cli, closeServer := newHTTPClientForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != proto.ServiceActionOperation.URI || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"actions":[{"action":"dataDump","startTime":"2026-01-01T00:00:00Z","elapsed":1000000000}]}`))
+	})
+	defer closeServer()
+
+	resp, err := cli.Action(context.Background(), proto.ActionRequest{Operation: proto.ActionOperationList})
+	if err != nil {
+		t.Fatalf("Action() error = %v", err)
+	}
+	if len(resp.Actions) != 1 || resp.Actions[0].Action != "dataDump" || resp.Actions[0].Elapsed.Seconds() != 1 {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestHTTPClientBatchAction(t *testing.T) {
+	cli, closeServer := newHTTPClientForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != proto.ServiceActionBatch.URI || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"failed","results":[{"action":"stop","compensation":{"action":"start"}},{"action":"reconfigure","error":"failed"}]}`))
+	})
+	defer closeServer()
+
+	resp, err := cli.BatchAction(context.Background(), proto.BatchActionRequest{
+		Steps: []proto.BatchActionStep{
+			{ActionRequest: proto.ActionRequest{Action: "stop"}, Compensation: &proto.ActionRequest{Action: "start"}},
+			{ActionRequest: proto.ActionRequest{Action: "reconfigure"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchAction() error = %v", err)
+	}
+	if resp.Error != "failed" || len(resp.Results) != 2 || resp.Results[0].Compensation == nil || resp.Results[0].Compensation.Action != "start" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+
+	resp, err = cli.BatchAction(context.WithValue(context.Background(), constant.DryRunContextKey, true), proto.BatchActionRequest{})
+	if err != nil || resp.Error != "" || len(resp.Results) != 0 {
+		t.Fatalf("dry-run BatchAction() = %#v, %v", resp, err)
+	}
+}
+
+func TestHTTPClientAudit(t *testing.T) {
+	cli, closeServer := newHTTPClientForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != proto.ServiceAudit.URI || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"records":[{"seq":3,"action":"switchover","source":"Action","startTime":"2026-01-01T00:00:00Z","elapsed":1000000000,"error":"failed"}]}`))
+	})
+	defer closeServer()
+
+	resp, err := cli.Audit(context.Background(), proto.AuditRequest{Action: "switchover", Limit: 1})
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+	if len(resp.Records) != 1 || resp.Records[0].Seq != 3 || resp.Records[0].Error != "failed" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestHTTPClientFile(t *testing.T) {
+	cli, closeServer := newHTTPClientForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != proto.ServiceFile.URI || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"checksum":"abc","size":3}`))
+	})
+	defer closeServer()
+
+	resp, err := cli.File(context.Background(), proto.FileRequest{Operation: proto.FileOperationWrite, Path: "/etc/conf/my.cnf", Content: []byte("abc")})
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	if resp.Checksum != "abc" || resp.Size != 3 {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+
+	dryRun := context.WithValue(context.Background(), constant.DryRunContextKey, true)
+	resp, err = cli.File(dryRun, proto.FileRequest{Operation: proto.FileOperationDelete, Path: "/etc/conf/my.cnf"})
+	if err != nil || resp.Checksum != "" {
+		t.Fatalf("dry-run File() = %#v, %v", resp, err)
+	}
+	resp, err = cli.File(dryRun, proto.FileRequest{Operation: proto.FileOperationChecksum, Path: "/etc/conf/my.cnf"})
+	if err != nil || resp.Checksum != "abc" {
+		t.Fatalf("dry-run checksum File() = %#v, %v", resp, err)
+	}
+}
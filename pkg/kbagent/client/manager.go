@@ -0,0 +1,290 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+// ManagerOptions configures the client manager.
+type ManagerOptions struct {
+	// FailureThreshold is the number of consecutive failures to open the circuit of a pod,
+	// the circuit breaking is disabled if it is not positive.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a probe call is let through.
+	OpenDuration time.Duration
+	// MaxRetries is the max number of retries of the idempotent calls failed, the calls are not retried if it is not positive.
+	MaxRetries int
+	// RetryBackoff is the backoff before the first retry, it is doubled on each retry up to the MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// IdempotentActions are the names of the actions that are safe to retry, besides the operations on the running actions.
+	IdempotentActions []string
+	// IdleTimeout is how long the client of a pod is kept unused before it is evicted.
+	IdleTimeout time.Duration
+	// PortForward specifies to call the kb-agent through the port-forward of the API server.
+	PortForward bool
+}
+
+// DefaultManagerOptions returns the default options of the client manager.
+func DefaultManagerOptions() ManagerOptions {
+	return ManagerOptions{
+		FailureThreshold: 3,
+		OpenDuration:     30 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     200 * time.Millisecond,
+		MaxRetryBackoff:  2 * time.Second,
+		IdleTimeout:      10 * time.Minute,
+	}
+}
+
+// Manager shares the clients to the kb-agent of pods. The clients are pooled by pod, so that the connections are reused,
+// and the calls to a pod are broken once it keeps failing, so that the callers don't pay the full timeout on each call
+// to an unreachable pod. The idempotent calls are retried with backoff on failures.
+type Manager struct {
+	options           ManagerOptions
+	idempotentActions sets.Set[string]
+
+	mutex sync.Mutex
+	pods  map[types.NamespacedName]*managedPod
+}
+
+type managedPod struct {
+	uid      types.UID
+	host     string
+	port     int32
+	cred     map[string][]byte
+	client   Client
+	breaker  *circuitBreaker
+	lastUsed time.Time
+}
+
+// NewManager creates a client manager with the options.
+func NewManager(options ManagerOptions) *Manager {
+	return &Manager{
+		options:           options,
+		idempotentActions: sets.New(options.IdempotentActions...),
+		pods:              make(map[types.NamespacedName]*managedPod),
+	}
+}
+
+// Client returns the shared client to the kb-agent of the pod, the client is refreshed if the pod is recreated,
+// or its endpoint or credential changes. The client returned should not be used after the manager is closed,
+// and closing it is a no-op.
+func (m *Manager) Client(pod *corev1.Pod, endpoint func() (string, int32, error), cred *util.Credential) (Client, error) {
+	if mockClient != nil || mockClientError != nil {
+		return mockClient, mockClientError
+	}
+
+	host, port, err := endpoint()
+	if err != nil {
+		return nil, err
+	}
+	if host == "" && port == 0 {
+		return nil, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.evict()
+
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	mp, ok := m.pods[key]
+	if ok && mp.uid != pod.UID {
+		// the pod is recreated, forget its failures
+		m.remove(key, mp)
+		ok = false
+	}
+	if !ok {
+		mp = &managedPod{
+			uid:     pod.UID,
+			breaker: newCircuitBreaker("pod "+key.String(), m.options.FailureThreshold, m.options.OpenDuration),
+		}
+		m.pods[key] = mp
+		pooledClients.Inc()
+	}
+	var data map[string][]byte
+	if cred != nil {
+		data = cred.Data()
+	}
+	if mp.client == nil || mp.host != host || mp.port != port || !reflect.DeepEqual(mp.cred, data) {
+		cli, err := m.newClient(pod, host, port, cred)
+		if err != nil {
+			return nil, err
+		}
+		if mp.client != nil {
+			_ = mp.client.Close()
+		}
+		mp.host, mp.port, mp.cred, mp.client = host, port, maps.Clone(data), cli
+	}
+	mp.lastUsed = time.Now()
+	return &managedClient{manager: m, client: mp.client, breaker: mp.breaker}, nil
+}
+
+func (m *Manager) newClient(pod *corev1.Pod, host string, port int32, cred *util.Credential) (Client, error) {
+	endpoint := func() (string, int32, error) {
+		return host, port, nil
+	}
+	if m.options.PortForward {
+		return NewPortForwardClient(pod, endpoint, cred)
+	}
+	return NewClient(endpoint, cred)
+}
+
+// evict removes the clients of pods unused for the idle timeout.
+func (m *Manager) evict() {
+	if m.options.IdleTimeout <= 0 {
+		return
+	}
+	for key, mp := range m.pods {
+		if time.Since(mp.lastUsed) > m.options.IdleTimeout {
+			m.remove(key, mp)
+		}
+	}
+}
+
+func (m *Manager) remove(key types.NamespacedName, mp *managedPod) {
+	if mp.client != nil {
+		_ = mp.client.Close()
+	}
+	mp.breaker.release()
+	delete(m.pods, key)
+	pooledClients.Dec()
+}
+
+// Close closes all the clients pooled.
+func (m *Manager) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for key, mp := range m.pods {
+		m.remove(key, mp)
+	}
+	return nil
+}
+
+// call calls the kb-agent through the circuit breaker of the pod, and retries the idempotent calls failed.
+func (m *Manager) call(ctx context.Context, breaker *circuitBreaker, method string, idempotent bool, f func() error) error {
+	retries := 0
+	if idempotent {
+		retries = max(m.options.MaxRetries, 0)
+	}
+	backoff := m.options.RetryBackoff
+	var err error
+	for i := 0; i <= retries; i++ {
+		if i > 0 {
+			retryTotal.WithLabelValues(method).Inc()
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(backoff*2, m.options.MaxRetryBackoff)
+		}
+
+		done, err1 := breaker.allow()
+		if err1 != nil {
+			requestTotal.WithLabelValues(method, outcomeRejected).Inc()
+			return err1
+		}
+		start := time.Now()
+		err = f()
+		done(err)
+		requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		if err == nil {
+			requestTotal.WithLabelValues(method, outcomeSuccess).Inc()
+			return nil
+		}
+		requestTotal.WithLabelValues(method, outcomeError).Inc()
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// managedClient calls the kb-agent of a pod through the manager.
+type managedClient struct {
+	manager *Manager
+	client  Client
+	breaker *circuitBreaker
+}
+
+var _ Client = &managedClient{}
+
+// Close is a no-op, the client is shared.
+func (c *managedClient) Close() error {
+	return nil
+}
+
+func (c *managedClient) Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+	var rsp proto.ActionResponse
+	idempotent := req.Operation != proto.ActionOperationCall || c.manager.idempotentActions.Has(req.Action)
+	err := c.manager.call(ctx, c.breaker, "Action", idempotent, func() error {
+		var err error
+		rsp, err = c.client.Action(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+func (c *managedClient) BatchAction(ctx context.Context, req proto.BatchActionRequest) (proto.BatchActionResponse, error) {
+	var rsp proto.BatchActionResponse
+	err := c.manager.call(ctx, c.breaker, "BatchAction", false, func() error {
+		var err error
+		rsp, err = c.client.BatchAction(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+func (c *managedClient) Audit(ctx context.Context, req proto.AuditRequest) (proto.AuditResponse, error) {
+	var rsp proto.AuditResponse
+	err := c.manager.call(ctx, c.breaker, "Audit", true, func() error {
+		var err error
+		rsp, err = c.client.Audit(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+// File retries all the file operations, since the writes are atomic and the deletes are idempotent.
+func (c *managedClient) File(ctx context.Context, req proto.FileRequest) (proto.FileResponse, error) {
+	var rsp proto.FileResponse
+	err := c.manager.call(ctx, c.breaker, "File", true, func() error {
+		var err error
+		rsp, err = c.client.File(ctx, req)
+		return err
+	})
+	return rsp, err
+}
@@ -0,0 +1,248 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func newServerForManagerTest(t *testing.T, failures int32) (func() (string, int32, error), *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			panic(http.ErrAbortHandler) // drop the connection
+		}
+		_, _ = w.Write([]byte(`{"message":"done"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	host, portString, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatalf("split host port: %v", err)
+	}
+	var port int32
+	if _, err := fmt.Sscan(portString, &port); err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+	return func() (string, int32, error) { return host, port, nil }, calls
+}
+
+func newPodForManagerTest(uid string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-0", UID: k8stypes.UID("uid-" + uid)}}
+}
+
+func TestManagerRetryIdempotentOnly(t *testing.T) {
+	endpoint, calls := newServerForManagerTest(t, 2)
+	options := DefaultManagerOptions()
+	options.FailureThreshold = 0
+	options.RetryBackoff = time.Millisecond
+	m := NewManager(options)
+	defer m.Close()
+
+	cli, err := m.Client(newPodForManagerTest("0"), endpoint, nil)
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	if _, err := cli.Action(context.Background(), proto.ActionRequest{Action: "switchover"}); err == nil {
+		t.Fatalf("expected the non-idempotent call to fail without retries")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls.Load())
+	}
+
+	rsp, err := cli.Action(context.Background(), proto.ActionRequest{Action: "switchover", Operation: proto.ActionOperationList})
+	if err != nil || rsp.Message != "done" {
+		t.Fatalf("expected the idempotent call to succeed after retry, got %+v, %v", rsp, err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestManagerRetryIdempotentActions(t *testing.T) {
+	endpoint, calls := newServerForManagerTest(t, 1)
+	options := DefaultManagerOptions()
+	options.FailureThreshold = 0
+	options.RetryBackoff = time.Millisecond
+	options.IdempotentActions = []string{"roleProbe"}
+	m := NewManager(options)
+	defer m.Close()
+
+	cli, _ := m.Client(newPodForManagerTest("0"), endpoint, nil)
+	if _, err := cli.Action(context.Background(), proto.ActionRequest{Action: "roleProbe"}); err != nil {
+		t.Fatalf("expected the call to succeed after retry, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestManagerCircuitBreaker(t *testing.T) {
+	endpoint, calls := newServerForManagerTest(t, 3)
+	options := DefaultManagerOptions()
+	options.FailureThreshold = 2
+	options.OpenDuration = 50 * time.Millisecond
+	options.MaxRetries = 0
+	m := NewManager(options)
+	defer m.Close()
+
+	cli, _ := m.Client(newPodForManagerTest("0"), endpoint, nil)
+	req := proto.ActionRequest{Action: "switchover"}
+	for i := 0; i < 2; i++ {
+		if _, err := cli.Action(context.Background(), req); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected a transport error, got %v", err)
+		}
+	}
+	if _, err := cli.Action(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to be open, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected the call rejected without reaching the server, got %d calls", calls.Load())
+	}
+
+	// the probe fails, and the circuit is open again
+	time.Sleep(options.OpenDuration)
+	if _, err := cli.Action(context.Background(), req); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the probe to fail, got %v", err)
+	}
+	if _, err := cli.Action(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to be open again, got %v", err)
+	}
+
+	// the probe succeeds, and the circuit is closed
+	time.Sleep(options.OpenDuration)
+	for i := 0; i < 2; i++ {
+		if _, err := cli.Action(context.Background(), req); err != nil {
+			t.Fatalf("expected the circuit to be closed, got %v", err)
+		}
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	b := newCircuitBreaker("test", 1, 0)
+	done, err := b.allow()
+	if err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+	done(errors.New("unreachable"))
+
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatalf("expected the probe to be allowed, got %v", err)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the calls rejected while probing, got %v", err)
+	}
+	probe(context.Canceled)
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("expected a new probe allowed after the cancellation, got %v", err)
+	}
+}
+
+func TestManagerPooling(t *testing.T) {
+	endpoint, _ := newServerForManagerTest(t, 0)
+	options := DefaultManagerOptions()
+	m := NewManager(options)
+	defer m.Close()
+
+	pod := newPodForManagerTest("0")
+	cli1, _ := m.Client(pod, endpoint, nil)
+	cli2, _ := m.Client(pod, endpoint, nil)
+	if cli1.(*managedClient).client != cli2.(*managedClient).client {
+		t.Fatalf("expected the client to be shared")
+	}
+	_ = cli1.Close() // no-op
+	if _, err := cli2.Action(context.Background(), proto.ActionRequest{Action: "a"}); err != nil {
+		t.Fatalf("expected the shared client usable after close, got %v", err)
+	}
+
+	endpoint2 := func() (string, int32, error) {
+		host, port, _ := endpoint()
+		return host, port + 1, nil
+	}
+	cli3, _ := m.Client(pod, endpoint2, nil)
+	if cli3.(*managedClient).client == cli2.(*managedClient).client {
+		t.Fatalf("expected the client refreshed on the endpoint change")
+	}
+	if cli3.(*managedClient).breaker != cli2.(*managedClient).breaker {
+		t.Fatalf("expected the breaker kept on the endpoint change")
+	}
+
+	cli4, _ := m.Client(newPodForManagerTest("1"), endpoint, nil)
+	if cli4.(*managedClient).breaker == cli3.(*managedClient).breaker {
+		t.Fatalf("expected a new breaker for the recreated pod")
+	}
+	if len(m.pods) != 1 {
+		t.Fatalf("expected 1 pod pooled, got %d", len(m.pods))
+	}
+}
+
+func TestManagerEviction(t *testing.T) {
+	endpoint, _ := newServerForManagerTest(t, 0)
+	options := DefaultManagerOptions()
+	options.IdleTimeout = 10 * time.Millisecond
+	m := NewManager(options)
+	defer m.Close()
+
+	pod := newPodForManagerTest("0")
+	_, _ = m.Client(pod, endpoint, nil)
+	time.Sleep(2 * options.IdleTimeout)
+
+	other := newPodForManagerTest("0")
+	other.Name = "pod-1"
+	_, _ = m.Client(other, endpoint, nil)
+	if len(m.pods) != 1 {
+		t.Fatalf("expected the idle pod evicted, got %d pods", len(m.pods))
+	}
+	if _, ok := m.pods[k8stypes.NamespacedName{Namespace: "default", Name: "pod-1"}]; !ok {
+		t.Fatalf("expected pod-1 pooled")
+	}
+}
+
+func TestManagerMockClient(t *testing.T) {
+	SetMockClient(stubClient{}, nil)
+	defer UnsetMockClient()
+
+	m := NewManager(DefaultManagerOptions())
+	cli, err := m.Client(newPodForManagerTest("0"), func() (string, int32, error) { return "", 0, errors.New("unused") }, nil)
+	if err != nil || cli != (stubClient{}) {
+		t.Fatalf("expected the mock client, got %v, %v", cli, err)
+	}
+}
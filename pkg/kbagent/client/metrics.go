@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "kubeblocks"
+	metricsSubsystem = "kbagent_client"
+
+	outcomeSuccess  = "success"
+	outcomeError    = "error"
+	outcomeRejected = "rejected"
+)
+
+var (
+	requestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "requests_total",
+		Help:      "Total number of requests to kb-agent, partitioned by method and outcome, the rejected requests are broken by the circuit breaker.",
+	}, []string{"method", "outcome"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of requests to kb-agent, partitioned by method.",
+		Buckets:   []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"method"})
+
+	retryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "retries_total",
+		Help:      "Total number of retries of the idempotent requests to kb-agent, partitioned by method.",
+	}, []string{"method"})
+
+	circuitTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "circuit_transitions_total",
+		Help:      "Total number of the circuit state transitions of pods, partitioned by the state transited to.",
+	}, []string{"state"})
+
+	openCircuits = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "open_circuits",
+		Help:      "Number of pods whose circuit is open.",
+	})
+
+	pooledClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "pooled_clients",
+		Help:      "Number of pods whose client is pooled.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestTotal, requestDuration, retryTotal, circuitTransitions, openCircuits, pooledClients)
+}
@@ -36,6 +36,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 type portForwardClient struct {
@@ -43,6 +44,7 @@ type portForwardClient struct {
 	port   string
 	config *rest.Config
 	logger logr.Logger
+	cred   *util.Credential
 }
 
 var _ Client = &portForwardClient{}
@@ -55,7 +57,49 @@ func (pf *portForwardClient) Close() error {
 // Since we can't know httpClient's lifecycle, a portforward is bound to one request.
 // It's not efficient, but enough for debugging purposes.
 func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
-	emptyResp := proto.ActionResponse{}
+	rsp := proto.ActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Action(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+// BatchAction forwards the target port to localhost, and then execute the batch.
+func (pf *portForwardClient) BatchAction(ctx context.Context, req proto.BatchActionRequest) (proto.BatchActionResponse, error) {
+	rsp := proto.BatchActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.BatchAction(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+// Audit forwards the target port to localhost, and then query the audit log.
+func (pf *portForwardClient) Audit(ctx context.Context, req proto.AuditRequest) (proto.AuditResponse, error) {
+	rsp := proto.AuditResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Audit(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+// File forwards the target port to localhost, and then operate the file.
+func (pf *portForwardClient) File(ctx context.Context, req proto.FileRequest) (proto.FileResponse, error) {
+	rsp := proto.FileResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.File(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+func (pf *portForwardClient) forward(f func(Client) error) error {
 	stopCh := make(chan struct{})
 	defer close(stopCh) // this will stop forwarder
 	readyCh := make(chan struct{})
@@ -78,7 +122,7 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 
 	forwarder, err := pf.newPortForwarder(readyCh, stopCh, outWriter)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	go func() {
 		err := forwarder.ForwardPorts()
@@ -92,29 +136,29 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 		// do nothing
 	case err := <-errCh:
 		pf.logger.Error(err, "port forward failed")
-		return emptyResp, err
+		return err
 	}
 
 	ports, err := forwarder.GetPorts()
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	if len(ports) == 0 {
-		return emptyResp, fmt.Errorf("no port was forwarded")
+		return fmt.Errorf("no port was forwarded")
 	}
 
 	endpoint := func() (string, int32, error) {
 		return "localhost", int32(ports[0].Local), nil
 	}
-	client, err := NewClient(endpoint)
+	client, err := NewClient(endpoint, pf.cred)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 
-	rsp, err := client.Action(ctx, req)
+	err = f(client)
 	_ = client.Close()
 
-	return rsp, err
+	return err
 }
 
 func (pf *portForwardClient) createDialer(method string, url *url.URL, config *rest.Config) (httpstream.Dialer, error) {
@@ -149,7 +193,7 @@ func (pf *portForwardClient) newPortForwarder(readyCh, stopCh chan struct{}, out
 	return fw, nil
 }
 
-func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error)) (Client, error) {
+func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error), cred *util.Credential) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -165,5 +209,6 @@ func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error
 		port:   fmt.Sprint(port),
 		config: config,
 		logger: ctrl.Log.WithName("portforward"),
+		cred:   cred,
 	}, nil
 }
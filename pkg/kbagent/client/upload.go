@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+// UploadFile uploads the content to the file through the streaming server of the kb-agent at the address.
+// The content is sent from the offset replied by the kb-agent, so that an interrupted upload of the same content is resumed.
+func UploadFile(ctx context.Context, address string, cred *util.Credential, upload proto.FileUpload, content io.ReadSeeker) (proto.FileResponse, error) {
+	rsp := proto.FileResponse{}
+
+	conn, err := dialStreaming(ctx, address, cred)
+	if err != nil {
+		return rsp, err
+	}
+	defer conn.Close()
+
+	// break the reads and writes blocked once the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	if cred.TokenEnabled() {
+		if err = writeLine(conn, proto.StreamingAuthRequest{Token: cred.Token}); err != nil {
+			return rsp, err
+		}
+	}
+	if err = writeLine(conn, proto.StreamingRequest{Upload: &upload}); err != nil {
+		return rsp, err
+	}
+
+	reader := bufio.NewReader(conn)
+	negotiated := proto.StreamingResponse{}
+	if err = readLine(reader, &negotiated); err != nil {
+		return rsp, fmt.Errorf("read streaming response error: %s", err.Error())
+	}
+	if len(negotiated.Error) > 0 {
+		return rsp, fmt.Errorf("upload is rejected by the remote: %s", negotiated.Error)
+	}
+
+	if _, err = content.Seek(negotiated.Offset, io.SeekStart); err != nil {
+		return rsp, err
+	}
+	if _, err = io.CopyN(conn, content, upload.Size-negotiated.Offset); err != nil {
+		return rsp, fmt.Errorf("send file content error at offset %d: %s", negotiated.Offset, err.Error())
+	}
+
+	if err = readLine(reader, &rsp); err != nil {
+		return rsp, fmt.Errorf("read upload response error: %s", err.Error())
+	}
+	return rsp, nil
+}
+
+func dialStreaming(ctx context.Context, address string, cred *util.Credential) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: defaultConnectTimeout,
+	}
+	config, err := cred.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: config}
+		return tlsDialer.DialContext(ctx, "tcp", address)
+	}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+func writeLine(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func readLine(r *bufio.Reader, v any) error {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(line, v)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+func TestUploadFile(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error = %v", err)
+	}
+	defer l.Close()
+
+	content := []byte("0123456789")
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		auth := proto.StreamingAuthRequest{}
+		if err := readLine(reader, &auth); err != nil || auth.Token != "token" {
+			t.Errorf("unexpected auth request: %#v, %v", auth, err)
+			return
+		}
+		req := proto.StreamingRequest{}
+		if err := readLine(reader, &req); err != nil || req.Upload == nil || req.Upload.Path != "/data/dump" {
+			t.Errorf("unexpected upload request: %#v, %v", req, err)
+			return
+		}
+		// resume from the offset 4
+		_ = writeLine(conn, proto.StreamingResponse{Offset: 4})
+		data := make([]byte, req.Upload.Size-4)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			t.Errorf("read content error = %v", err)
+			return
+		}
+		received <- data
+		_ = writeLine(conn, proto.FileResponse{Checksum: req.Upload.Checksum, Size: req.Upload.Size})
+	}()
+
+	upload := proto.FileUpload{Path: "/data/dump", Size: int64(len(content)), Checksum: "abc"}
+	rsp, err := UploadFile(context.Background(), l.Addr().String(), &util.Credential{Token: "token"}, upload, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if rsp.Checksum != "abc" || rsp.Size != int64(len(content)) {
+		t.Fatalf("unexpected response: %#v", rsp)
+	}
+	if data := <-received; string(data) != "456789" {
+		t.Fatalf("unexpected content received: %s", data)
+	}
+}
+
+func TestUploadFileRejected(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error = %v", err)
+	}
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = json.NewDecoder(conn).Decode(&proto.StreamingRequest{})
+		_ = writeLine(conn, proto.StreamingResponse{Error: "the file path is not allowed"})
+	}()
+
+	upload := proto.FileUpload{Path: "/etc/passwd", Size: 1, Checksum: "abc"}
+	_, err = UploadFile(context.Background(), l.Addr().String(), nil, upload, bytes.NewReader([]byte("x")))
+	if err == nil {
+		t.Fatalf("expected the upload to be rejected")
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	Path = "/metrics"
+
+	namespace = "kbagent"
+
+	OutcomeSuccess = "success"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	actionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "action",
+		Name:      "duration_seconds",
+		Help:      "Duration of action calls, partitioned by action name and outcome.",
+		Buckets:   []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300, 600},
+	}, []string{"action", "outcome"})
+
+	actionTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "action",
+		Name:      "calls_total",
+		Help:      "Total number of action calls, partitioned by action name and outcome.",
+	}, []string{"action", "outcome"})
+
+	actionInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "action",
+		Name:      "in_flight",
+		Help:      "Number of action calls currently executing, partitioned by action name.",
+	}, []string{"action"})
+
+	actionNonBlockingRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "action",
+		Name:      "non_blocking_running",
+		Help:      "Number of non-blocking action calls started but not yet collected, partitioned by action name.",
+	}, []string{"action"})
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently served, bounded by --max-concurrency.",
+	})
+
+	probeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "probe",
+		Name:      "duration_seconds",
+		Help:      "Duration of probe runs, partitioned by probe and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"probe", "outcome"})
+
+	probeTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "probe",
+		Name:      "runs_total",
+		Help:      "Total number of probe runs, partitioned by probe and outcome.",
+	}, []string{"probe", "outcome"})
+
+	probeSuccessStreak = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "probe",
+		Name:      "consecutive_successes",
+		Help:      "Number of consecutive successful runs of the probe.",
+	}, []string{"probe"})
+
+	probeFailureStreak = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "probe",
+		Name:      "consecutive_failures",
+		Help:      "Number of consecutive failed runs of the probe.",
+	}, []string{"probe"})
+
+	taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "duration_seconds",
+		Help:      "Duration of the finished tasks, partitioned by task and outcome.",
+		Buckets:   []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200, 21600},
+	}, []string{"task", "outcome"})
+
+	taskTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "runs_total",
+		Help:      "Total number of finished tasks, partitioned by task and outcome.",
+	}, []string{"task", "outcome"})
+
+	taskRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "running",
+		Help:      "Number of tasks currently running, partitioned by task.",
+	}, []string{"task"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		actionDuration,
+		actionTotal,
+		actionInFlight,
+		actionNonBlockingRunning,
+		requestsInFlight,
+		probeDuration,
+		probeTotal,
+		probeSuccessStreak,
+		probeFailureStreak,
+		taskDuration,
+		taskTotal,
+		taskRunning,
+	)
+}
+
+// Handler returns the HTTP handler that exposes the kb-agent metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	})
+}
+
+// Outcome maps the error returned by an action, probe or task to a metric label value.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	return proto.Error2Type(err)
+}
+
+// ActionStarted records the start of an action call, and returns a function to record its finish.
+func ActionStarted(action string) func(err error) {
+	start := time.Now()
+	actionInFlight.WithLabelValues(action).Inc()
+	return func(err error) {
+		outcome := Outcome(err)
+		actionInFlight.WithLabelValues(action).Dec()
+		actionDuration.WithLabelValues(action, outcome).Observe(time.Since(start).Seconds())
+		actionTotal.WithLabelValues(action, outcome).Inc()
+	}
+}
+
+func NonBlockingActionStarted(action string) {
+	actionNonBlockingRunning.WithLabelValues(action).Inc()
+}
+
+func NonBlockingActionCollected(action string) {
+	actionNonBlockingRunning.WithLabelValues(action).Dec()
+}
+
+func RequestStarted() func() {
+	requestsInFlight.Inc()
+	return requestsInFlight.Dec
+}
+
+func ProbeObserved(probe string, duration time.Duration, err error, succeedCount, failedCount int64) {
+	outcome := Outcome(err)
+	probeDuration.WithLabelValues(probe, outcome).Observe(duration.Seconds())
+	probeTotal.WithLabelValues(probe, outcome).Inc()
+	probeSuccessStreak.WithLabelValues(probe).Set(float64(succeedCount))
+	probeFailureStreak.WithLabelValues(probe).Set(float64(failedCount))
+}
+
+// TaskStarted records the start of a task, and returns a function to record its finish.
+func TaskStarted(task string) func(err error) {
+	start := time.Now()
+	taskRunning.WithLabelValues(task).Inc()
+	return func(err error) {
+		outcome := Outcome(err)
+		taskRunning.WithLabelValues(task).Dec()
+		taskDuration.WithLabelValues(task, outcome).Observe(time.Since(start).Seconds())
+		taskTotal.WithLabelValues(task, outcome).Inc()
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func TestOutcome(t *testing.T) {
+	if got := Outcome(nil); got != OutcomeSuccess {
+		t.Fatalf("Outcome(nil) = %q, want %q", got, OutcomeSuccess)
+	}
+	if got := Outcome(errors.Wrap(proto.ErrTimedOut, "switchover")); got != "timedOut" {
+		t.Fatalf("Outcome(timedOut) = %q", got)
+	}
+}
+
+func TestActionMetrics(t *testing.T) {
+	done := ActionStarted("switchover")
+	if got := testutil.ToFloat64(actionInFlight.WithLabelValues("switchover")); got != 1 {
+		t.Fatalf("in flight = %v, want 1", got)
+	}
+	done(proto.ErrTimedOut)
+	if got := testutil.ToFloat64(actionInFlight.WithLabelValues("switchover")); got != 0 {
+		t.Fatalf("in flight = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(actionTotal.WithLabelValues("switchover", "timedOut")); got != 1 {
+		t.Fatalf("calls total = %v, want 1", got)
+	}
+
+	NonBlockingActionStarted("dataDump")
+	if got := testutil.ToFloat64(actionNonBlockingRunning.WithLabelValues("dataDump")); got != 1 {
+		t.Fatalf("non-blocking running = %v, want 1", got)
+	}
+	NonBlockingActionCollected("dataDump")
+	if got := testutil.ToFloat64(actionNonBlockingRunning.WithLabelValues("dataDump")); got != 0 {
+		t.Fatalf("non-blocking running = %v, want 0", got)
+	}
+}
+
+func TestProbeMetrics(t *testing.T) {
+	ProbeObserved("roleProbe", time.Millisecond, nil, 3, 0)
+	ProbeObserved("roleProbe", time.Millisecond, proto.ErrFailed, 0, 1)
+	if got := testutil.ToFloat64(probeSuccessStreak.WithLabelValues("roleProbe")); got != 0 {
+		t.Fatalf("success streak = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(probeFailureStreak.WithLabelValues("roleProbe")); got != 1 {
+		t.Fatalf("failure streak = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(probeTotal.WithLabelValues("roleProbe", OutcomeSuccess)); got != 1 {
+		t.Fatalf("runs total = %v, want 1", got)
+	}
+}
+
+func TestTaskMetrics(t *testing.T) {
+	done1 := TaskStarted("newReplica")
+	done2 := TaskStarted("newReplica")
+	if got := testutil.ToFloat64(taskRunning.WithLabelValues("newReplica")); got != 2 {
+		t.Fatalf("running = %v, want 2", got)
+	}
+	done1(nil)
+	done2(proto.ErrFailed)
+	if got := testutil.ToFloat64(taskRunning.WithLabelValues("newReplica")); got != 0 {
+		t.Fatalf("running = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(taskTotal.WithLabelValues("newReplica", OutcomeSuccess)); got != 1 {
+		t.Fatalf("runs total = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(taskDuration); got != 2 {
+		t.Fatalf("duration series = %v, want 2", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ActionStarted("roleProbe")(nil)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", Path, nil))
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `kbagent_action_calls_total{action="roleProbe",outcome="success"}`) {
+		t.Fatalf("metrics body does not contain the action counter:\n%s", rec.Body.String())
+	}
+}
@@ -24,14 +24,33 @@ import (
 )
 
 type Action struct {
-	Name           string       `json:"name"`
-	Exec           *ExecAction  `json:"exec,omitempty"`
-	HTTP           *HTTPAction  `json:"http,omitempty"`
-	GRPC           *GRPCAction  `json:"grpc,omitempty"`
-	TimeoutSeconds int32        `json:"timeoutSeconds,omitempty"`
-	RetryPolicy    *RetryPolicy `json:"retryPolicy,omitempty"`
+	Name           string        `json:"name"`
+	Exec           *ExecAction   `json:"exec,omitempty"`
+	HTTP           *HTTPAction   `json:"http,omitempty"`
+	GRPC           *GRPCAction   `json:"grpc,omitempty"`
+	TCP            *TCPAction    `json:"tcp,omitempty"`
+	Script         *ScriptAction `json:"script,omitempty"`
+	TimeoutSeconds int32         `json:"timeoutSeconds,omitempty"`
+	RetryPolicy    *RetryPolicy  `json:"retryPolicy,omitempty"`
+	Lock           *ActionLock   `json:"lock,omitempty"`
+	SupportDryRun  bool          `json:"supportDryRun,omitempty"`
 }
 
+// ActionLock declares the lock group of an action, the actions in the same group are not run concurrently.
+type ActionLock struct {
+	Group               string               `json:"group"`
+	Policy              ActionConflictPolicy `json:"policy,omitempty"`
+	QueueTimeoutSeconds int32                `json:"queueTimeoutSeconds,omitempty"`
+}
+
+type ActionConflictPolicy string
+
+const (
+	ActionConflictPolicyReject  ActionConflictPolicy = "Reject" // fail with the busy error, it is the default policy
+	ActionConflictPolicyQueue   ActionConflictPolicy = "Queue"  // wait for the running action to finish, up to the queue timeout
+	ActionConflictPolicyPreempt ActionConflictPolicy = "Preempt"
+)
+
 type ExecAction struct {
 	Commands []string `json:"command,omitempty"`
 	Args     []string `json:"args,omitempty"`
@@ -65,6 +84,26 @@ type GRPCResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+type TCPAction struct {
+	Port    string        `json:"port"`
+	Host    string        `json:"host,omitempty"`
+	Payload string        `json:"payload,omitempty"`
+	Expect  string        `json:"expect,omitempty"` // the regular expression that the response is expected to match
+	TLS     *TCPTLSConfig `json:"tls,omitempty"`
+}
+
+type TCPTLSConfig struct {
+	ServerName         string `json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// ScriptAction evaluates a CEL expression within kb-agent.
+type ScriptAction struct {
+	Expression  string `json:"expression"`
+	CostLimit   *int64 `json:"costLimit,omitempty"`   // the limit of the runtime cost of the evaluation
+	MemoryLimit *int64 `json:"memoryLimit,omitempty"` // the limit of the bytes of the data fetched, parsed and returned by the evaluation
+}
+
 type RetryPolicy struct {
 	MaxRetries    int           `json:"maxRetries,omitempty"`
 	RetryInterval time.Duration `json:"retryInterval,omitempty"`
@@ -72,17 +111,161 @@ type RetryPolicy struct {
 
 type ActionRequest struct {
 	Action         string            `json:"action"`
+	Operation      ActionOperation   `json:"operation,omitempty"`
 	Parameters     map[string]string `json:"parameters,omitempty"`
 	Arguments      [][]string        `json:"arguments,omitempty"`
 	NonBlocking    *bool             `json:"nonBlocking,omitempty"`
 	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
 	RetryPolicy    *RetryPolicy      `json:"retryPolicy,omitempty"`
+	DryRun         *bool             `json:"dryRun,omitempty"` // ask the action to report what it would do, without making any change
 }
 
+// ActionOperation is the operation requested on an action, the action is called if it is not specified.
+type ActionOperation string
+
+const (
+	ActionOperationCall   ActionOperation = ""
+	ActionOperationList   ActionOperation = "list"   // list the running non-blocking actions, or the named one only
+	ActionOperationOutput ActionOperation = "output" // fetch the output of the named non-blocking action buffered so far
+	ActionOperationCancel ActionOperation = "cancel" // cancel the named non-blocking action
+)
+
 type ActionResponse struct {
+	Error   string          `json:"error,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Output  []byte          `json:"output,omitempty"`
+	Actions []RunningAction `json:"actions,omitempty"` // running actions for the list operation
+}
+
+// BatchActionRequest runs a sequence of actions in order within kb-agent, it is all-or-nothing:
+// if any step fails, the compensations of the succeeded steps are called in reverse order.
+// The batch runs to the end even if the client is gone, so the replica will not be left half-way.
+type BatchActionRequest struct {
+	// ID identifies the batch, the request joins the running batch with the same ID instead of running it again,
+	// and gets the result of the finished batch with the same ID if it is retried in a while.
+	ID             string            `json:"id,omitempty"`
+	Steps          []BatchActionStep `json:"steps"`
+	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"` // timeout of the steps, the compensations are not limited by it
+}
+
+type BatchActionStep struct {
+	ActionRequest `json:",inline"`
+	// Compensation is the action called to undo the step when any following step fails.
+	Compensation *ActionRequest `json:"compensation,omitempty"`
+}
+
+type BatchActionResponse struct {
+	Error   string              `json:"error,omitempty"`
+	Message string              `json:"message,omitempty"`
+	Results []BatchActionResult `json:"results,omitempty"` // results of the steps called, in order
+}
+
+type BatchActionResult struct {
+	Action  string `json:"action"`
 	Error   string `json:"error,omitempty"`
 	Message string `json:"message,omitempty"`
 	Output  []byte `json:"output,omitempty"`
+	// Compensation is the result of the compensation, if the step is compensated.
+	Compensation *BatchActionResult `json:"compensation,omitempty"`
+}
+
+type RunningAction struct {
+	Action    string        `json:"action"`
+	StartTime time.Time     `json:"startTime"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Finished  bool          `json:"finished,omitempty"` // the action is finished, but its result is not collected yet
+}
+
+// AuditRequest queries the audit log of the action invocations, the records are returned in the order of invocation.
+type AuditRequest struct {
+	Action string     `json:"action,omitempty"` // the records of the named action only
+	Since  *time.Time `json:"since,omitempty"`  // the records of the invocations started at or after the time
+	Until  *time.Time `json:"until,omitempty"`  // the records of the invocations started before the time
+	Limit  int        `json:"limit,omitempty"`  // the latest records only, up to the limit
+}
+
+type AuditResponse struct {
+	Error   string        `json:"error,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Records []AuditRecord `json:"records,omitempty"`
+}
+
+// AuditRecord records an action invocation requested through the kb-agent services, the probes run by kb-agent itself
+// are not recorded. The secret parameters are redacted and the output is truncated.
+type AuditRecord struct {
+	Seq         int64             `json:"seq"`
+	Action      string            `json:"action"`
+	Source      string            `json:"source"` // the kind of the service that invoked the action
+	Parameters  map[string]string `json:"parameters,omitempty"`
+	NonBlocking bool              `json:"nonBlocking,omitempty"`
+	DryRun      bool              `json:"dryRun,omitempty"`
+	StartTime   time.Time         `json:"startTime"`
+	Elapsed     time.Duration     `json:"elapsed"`
+	Error       string            `json:"error,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	OutputSize  int64             `json:"outputSize,omitempty"` // the output is not recorded since it may contain secrets
+}
+
+const (
+	FileOperationWrite    = "write"
+	FileOperationRead     = "read"
+	FileOperationChecksum = "checksum"
+	FileOperationDelete   = "delete"
+)
+
+// FileRequest operates a file under the allowed paths of the kb-agent, the checksum is the hex-encoded SHA-256 digest.
+type FileRequest struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   []byte `json:"content,omitempty"`  // the content to write
+	Mode      *int32 `json:"mode,omitempty"`     // the permission bits of the file written, 0644 if not set
+	Checksum  string `json:"checksum,omitempty"` // the checksum of the content to write, it is verified before the file is replaced
+}
+
+type FileResponse struct {
+	Error    string `json:"error,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Content  []byte `json:"content,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+}
+
+// FileUpload uploads a file over a streaming connection in chunks. The content is sent after the StreamingResponse,
+// from the offset replied, and the upload is replied with a FileResponse line once the file is replaced.
+// The partial content received is kept if the connection is broken, the upload of the same file and checksum
+// is resumed from it.
+type FileUpload struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+	Mode     *int32 `json:"mode,omitempty"`
+}
+
+// StreamingAuthRequest is sent as the first line of a streaming connection, before the handshake packet,
+// when the streaming server requires token authentication.
+type StreamingAuthRequest struct {
+	Token string `json:"token"`
+}
+
+// StreamingRequest is the handshake packet of a streaming connection.
+// The streaming options are ignored by the old streaming servers, so they are replied with a StreamingResponse
+// only if any option is requested, and the data is sent after the response.
+type StreamingRequest struct {
+	ActionRequest `json:",inline"`
+	Compression   StreamingCompression `json:"compression,omitempty"`
+	Offset        int64                `json:"offset,omitempty"` // the offset of the uncompressed data to start from
+	Upload        *FileUpload          `json:"upload,omitempty"` // the connection uploads a file instead of streaming the output of an action
+}
+
+func (r *StreamingRequest) Negotiable() bool {
+	return len(r.Compression) > 0 || r.Offset > 0
+}
+
+// StreamingResponse is sent as a line after the handshake packet, if any streaming option is requested.
+type StreamingResponse struct {
+	Compression StreamingCompression `json:"compression,omitempty"`
+	Offset      int64                `json:"offset,omitempty"`
+	Error       string               `json:"error,omitempty"`
 }
 
 // TODO: define the event spec for probe or async action
@@ -93,6 +276,29 @@ const (
 	ProbeEventSourceComponent     = "kbagent"
 )
 
+const (
+	// ReportChannelEvent reports the probe and task results as Events.
+	ReportChannelEvent = "event"
+	// ReportChannelStatus reports the probe and task results by patching the status object of the pod,
+	// a Lease that holds the latest record of each probe and task in its annotations.
+	ReportChannelStatus = "status"
+
+	StatusObjectLabelKey         = "kubeblocks.io/kbagent-status" // the label of the status object, the value is the pod name
+	StatusRecordAnnotationPrefix = "status.kbagent.kubeblocks.io/"
+)
+
+// StatusObjectName returns the name of the status object of the pod.
+func StatusObjectName(podName string) string {
+	return podName + "-kbagent"
+}
+
+// StatusRecord is the latest report of a probe or task, kept in the status object.
+type StatusRecord struct {
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
 type Probe struct {
 	Instance            string `json:"instance"`
 	Action              string `json:"action"`
@@ -105,6 +311,15 @@ type Probe struct {
 	// Directory paths watch immediate child create/remove/rename events only; file paths
 	// watch create/write/remove/rename events for the exact file path.
 	ReportOnFileChange []string `json:"reportOnFileChange,omitempty"`
+	// JitterPercent randomizes each probe period by up to the percentage of PeriodSeconds in both directions,
+	// to spread the probes of replicas that are started together.
+	JitterPercent int32 `json:"jitterPercent,omitempty"`
+	// MaxBackoffSeconds enables the exponential backoff of the probe period once the FailureThreshold is reached,
+	// the period is doubled on each failure up to the value, and it is reset on success.
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+	// MaxEventsPerMinute limits the events sent by the probe, no limit if not set.
+	// The events exceeding the limit are delayed, and only the latest one is kept.
+	MaxEventsPerMinute int32 `json:"maxEventsPerMinute,omitempty"`
 }
 
 type ProbeEvent struct {
@@ -113,16 +328,31 @@ type ProbeEvent struct {
 	Code     int32  `json:"code"`
 	Output   []byte `json:"output,omitempty"`  // output of the probe on success, or latest succeed output on failure
 	Message  string `json:"message,omitempty"` // message of the probe on failure
+	Count    int32  `json:"count,omitempty"`   // number of the consecutive identical probe results coalesced into the event
 }
 
+const (
+	TaskKindNewReplica     = "newReplica"
+	TaskKindResyncReplica  = "resyncReplica"
+	TaskKindRebuildReplica = "rebuildReplica"
+	TaskKindVerifyChecksum = "verifyChecksum"
+	TaskKindCompactStorage = "compactStorage"
+)
+
+// Task is a long-running task run on the replicas by the kb-agent worker. The Task is the kind of the task,
+// and the parameters of each kind are carried by its own payload.
 type Task struct {
-	Instance            string          `json:"instance"`
-	Task                string          `json:"task"`
-	UID                 string          `json:"UID"`                           // the unique identifier of the task
-	Replicas            string          `json:"replicas"`                      // target replicas to run the task
-	NotifyAtFinish      bool            `json:"notifyAtFinish,omitempty"`      // whether to notify the controller when the task is finished
-	ReportPeriodSeconds int32           `json:"reportPeriodSeconds,omitempty"` // the period to report the progress of the task
-	NewReplica          *NewReplicaTask `json:"newReplica,omitempty"`
+	Instance            string              `json:"instance"`
+	Task                string              `json:"task"`
+	UID                 string              `json:"UID"`                           // the unique identifier of the task
+	Replicas            string              `json:"replicas"`                      // target replicas to run the task
+	NotifyAtFinish      bool                `json:"notifyAtFinish,omitempty"`      // whether to notify the controller when the task is finished
+	ReportPeriodSeconds int32               `json:"reportPeriodSeconds,omitempty"` // the period to report the progress of the task
+	NewReplica          *NewReplicaTask     `json:"newReplica,omitempty"`
+	ResyncReplica       *ResyncReplicaTask  `json:"resyncReplica,omitempty"`
+	RebuildReplica      *RebuildReplicaTask `json:"rebuildReplica,omitempty"`
+	VerifyChecksum      *VerifyChecksumTask `json:"verifyChecksum,omitempty"`
+	CompactStorage      *CompactStorageTask `json:"compactStorage,omitempty"`
 }
 
 type TaskEvent struct {
@@ -142,5 +372,58 @@ type NewReplicaTask struct {
 	Port           int32             `json:"port"`
 	Replicas       string            `json:"replicas"`                 // replicas to load the data
 	Parameters     map[string]string `json:"parameters,omitempty"`     // parameters for data dump and load
-	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"` // TODO: not implemented
+	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"` // timeout of the whole transfer, the timeout of the dataLoad action is used if not set
+	// RateLimitBytesPerSecond limits the bandwidth of the transfer on the wire, no limit if not set.
+	RateLimitBytesPerSecond int64 `json:"rateLimitBytesPerSecond,omitempty"`
+	// Compression specifies the algorithm to compress the data on the wire, the data is not compressed if not set.
+	Compression StreamingCompression `json:"compression,omitempty"`
+	// Resumable specifies whether the transfer can be resumed from the byte offset checkpointed after interruption.
+	// The dataDump action should generate the same data for the same parameters, and the dataLoad action
+	// should continue the load from the offset passed by the env KB_DATA_OFFSET.
+	// Only the offset committed by the dataLoad action is checkpointed: the action writes the absolute offset
+	// of the data it has durably applied to the file passed by the env KB_DATA_COMMIT_FILE. The transfer
+	// is restarted from the beginning if no offset is committed.
+	Resumable bool `json:"resumable,omitempty"`
+}
+
+type StreamingCompression string
+
+const (
+	StreamingCompressionNone StreamingCompression = ""
+	StreamingCompressionGzip StreamingCompression = "gzip"
+	StreamingCompressionZstd StreamingCompression = "zstd"
+)
+
+// ReplicaActionTask is the common part of the replica tasks that are carried out by an action of the kb-agent.
+type ReplicaActionTask struct {
+	Action         string            `json:"action"`                   // the name of the action to call
+	Parameters     map[string]string `json:"parameters,omitempty"`     // parameters passed to the action
+	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"` // timeout of the task, the timeout of the action is used if not set
+}
+
+// ResyncReplicaTask resyncs the data of the replicas from the primary,
+// the address of the primary is passed to the action by the env KB_PRIMARY_POD_FQDN.
+type ResyncReplicaTask struct {
+	ReplicaActionTask `json:",inline"`
+	Primary           string `json:"primary"`
+}
+
+// RebuildReplicaTask rebuilds the data of the replicas from a backup,
+// the name of the backup is passed to the action by the env KB_BACKUP_NAME.
+type RebuildReplicaTask struct {
+	ReplicaActionTask `json:",inline"`
+	Backup            string `json:"backup"`
+}
+
+// VerifyChecksumTask verifies the checksum of the data against the peer. The action is called on both the replica
+// and the peer, through the kb-agent of the peer at the port, and the task fails if their outputs differ.
+type VerifyChecksumTask struct {
+	ReplicaActionTask `json:",inline"`
+	Peer              string `json:"peer"`
+	Port              int32  `json:"port"`
+}
+
+// CompactStorageTask compacts the storage of the replicas.
+type CompactStorageTask struct {
+	ReplicaActionTask `json:",inline"`
 }
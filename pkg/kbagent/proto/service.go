@@ -31,6 +31,26 @@ var (
 		Version: "v1.0",
 		URI:     "/v1.0/action",
 	}
+	ServiceActionOperation = &Service{
+		Kind:    "ActionOperation",
+		Version: "v1.0",
+		URI:     "/v1.0/action/operation",
+	}
+	ServiceActionBatch = &Service{
+		Kind:    "ActionBatch",
+		Version: "v1.0",
+		URI:     "/v1.0/action/batch",
+	}
+	ServiceAudit = &Service{
+		Kind:    "Audit",
+		Version: "v1.0",
+		URI:     "/v1.0/audit",
+	}
+	ServiceFile = &Service{
+		Kind:    "File",
+		Version: "v1.0",
+		URI:     "/v1.0/file",
+	}
 	ServiceProbe = &Service{
 		Kind:    "Probe",
 		Version: "v1.0",
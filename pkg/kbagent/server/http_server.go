@@ -29,8 +29,11 @@ import (
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/go-logr/logr"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/metrics"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 const (
@@ -43,6 +46,7 @@ type httpServer struct {
 	config   Config
 	services []service.Service
 	servers  []*fasthttp.Server
+	cred     *util.Credential
 }
 
 var _ Server = &httpServer{}
@@ -51,22 +55,37 @@ var _ Server = &httpServer{}
 func (s *httpServer) StartNonBlocking() error {
 	s.logger.Info("starting the HTTP server")
 
+	cred, err := s.config.Credential()
+	if err != nil {
+		return err
+	}
+	s.cred = cred
+
 	handler := s.router()
 
 	var listeners []net.Listener
+	var handlers []fasthttp.RequestHandler
 	if s.config.UnixDomainSocket != "" {
 		socket := fmt.Sprintf("%s/kbagent.socket", s.config.UnixDomainSocket)
 		l, err := net.Listen("unix", socket)
 		if err != nil {
 			return err
 		}
+		// the unix domain socket is only reachable from the pod, TLS is not required
 		listeners = append(listeners, l)
+		handlers = append(handlers, handler)
 	} else {
 		l, err := net.Listen("tcp", fmt.Sprintf("%s:%v", s.config.Address, s.config.Port))
 		if err != nil {
 			s.logger.Error(err, "listen HTTP server error", "address", s.config.Address, "port", s.config.Port)
 		} else {
-			listeners = append(listeners, l)
+			sl, err := secureListener(l, s.config, s.cred)
+			if err != nil {
+				_ = l.Close()
+				return err
+			}
+			listeners = append(listeners, sl)
+			handlers = append(handlers, handler)
 		}
 	}
 
@@ -74,11 +93,22 @@ func (s *httpServer) StartNonBlocking() error {
 		return errors.New("no endpoint to listen on")
 	}
 
-	for _, listener := range listeners {
+	if s.config.MetricsPort > 0 {
+		// the metrics are served in plain text on a dedicated port, so that they can be scraped without the client certificate
+		l, err := net.Listen("tcp", fmt.Sprintf("%s:%v", s.config.Address, s.config.MetricsPort))
+		if err != nil {
+			s.logger.Error(err, "listen metrics server error", "address", s.config.Address, "port", s.config.MetricsPort)
+		} else {
+			listeners = append(listeners, l)
+			handlers = append(handlers, s.metricsRouter())
+		}
+	}
+
+	for i, listener := range listeners {
 		// customServer is created in a loop because each instance
 		// has a handle on the underlying listener.
 		customServer := &fasthttp.Server{
-			Handler: handler,
+			Handler: handlers[i],
 		}
 
 		if s.config.Concurrency > 0 {
@@ -117,6 +147,15 @@ func (s *httpServer) router() fasthttp.RequestHandler {
 	for i := range s.services {
 		s.registerService(router, s.services[i])
 	}
+	if s.config.MetricsPort <= 0 {
+		router.Handle(fasthttp.MethodGet, metrics.Path, fasthttpadaptor.NewFastHTTPHandler(metrics.Handler()))
+	}
+	return router.Handler
+}
+
+func (s *httpServer) metricsRouter() fasthttp.RequestHandler {
+	router := fasthttprouter.New()
+	router.Handle(fasthttp.MethodGet, metrics.Path, fasthttpadaptor.NewFastHTTPHandler(metrics.Handler()))
 	return router.Handler
 }
 
@@ -127,6 +166,20 @@ func (s *httpServer) registerService(router *fasthttprouter.Router, svc service.
 
 func (s *httpServer) dispatcher(svc service.Service) func(*fasthttp.RequestCtx) {
 	return func(reqCtx *fasthttp.RequestCtx) {
+		defer metrics.RequestStarted()()
+
+		if !s.cred.VerifyBearer(string(reqCtx.Request.Header.Peek(fasthttp.HeaderAuthorization))) {
+			httpRespond(reqCtx, fasthttp.StatusUnauthorized, nil, errors.New("unauthorized"))
+			if s.config.Logging {
+				s.logger.Info("HTTP API Unauthorized",
+					"user-agent", string(reqCtx.Request.Header.UserAgent()),
+					"path", string(reqCtx.Path()),
+					"remote", reqCtx.RemoteAddr().String(),
+				)
+			}
+			return
+		}
+
 		ctx := context.Background()
 		body := reqCtx.PostBody()
 
@@ -22,14 +22,18 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"strings"
 	"testing"
 
 	"github.com/valyala/fasthttp"
 	"k8s.io/klog/v2/ktesting"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/metrics"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 type serverFakeService struct {
@@ -157,6 +161,93 @@ func TestHTTPServerRouterDispatcherAndRespond(t *testing.T) {
 	}
 }
 
+func TestHTTPServerDispatcherUnauthorized(t *testing.T) {
+	logger := ktesting.NewLogger(t, ktesting.NewConfig())
+	svc := &serverFakeService{
+		kind:   proto.ServiceAction.Kind,
+		uri:    proto.ServiceAction.URI,
+		output: []byte(`{"ok":true}`),
+	}
+	srv := &httpServer{
+		logger:   logger,
+		services: []service.Service{svc},
+		cred:     &util.Credential{Token: "secret"},
+	}
+	handler := srv.router()
+
+	ctx := runFastHTTP(handler, fasthttp.MethodPost, proto.ServiceAction.URI, "ok")
+	if ctx.Response.StatusCode() != fasthttp.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", ctx.Response.StatusCode())
+	}
+
+	var req fasthttp.Request
+	req.Header.SetMethod(fasthttp.MethodPost)
+	req.Header.Set(fasthttp.HeaderAuthorization, util.BearerPrefix+"secret")
+	req.SetRequestURI(proto.ServiceAction.URI)
+	req.SetBodyString("ok")
+	ctx = &fasthttp.RequestCtx{}
+	ctx.Init(&req, nil, nil)
+	handler(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("status = %d, want 200", ctx.Response.StatusCode())
+	}
+}
+
+func TestHTTPServerMetrics(t *testing.T) {
+	logger := ktesting.NewLogger(t, ktesting.NewConfig())
+	srv := &httpServer{
+		logger: logger,
+	}
+	handler := srv.router()
+
+	ctx := runFastHTTP(handler, fasthttp.MethodGet, metrics.Path, "")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("status = %d, want 200", ctx.Response.StatusCode())
+	}
+	if !strings.Contains(string(ctx.Response.Body()), "kbagent_http_requests_in_flight") {
+		t.Fatalf("metrics body = %q", ctx.Response.Body())
+	}
+}
+
+func TestHTTPServerMetricsPort(t *testing.T) {
+	logger := ktesting.NewLogger(t, ktesting.NewConfig())
+	srv := &httpServer{
+		logger: logger,
+		config: Config{Address: "127.0.0.1", MetricsPort: freePort(t)},
+	}
+
+	// the metrics are not served by the HTTP server
+	ctx := runFastHTTP(srv.router(), fasthttp.MethodGet, metrics.Path, "")
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("status = %d, want 404", ctx.Response.StatusCode())
+	}
+
+	if err := srv.StartNonBlocking(); err != nil {
+		t.Fatalf("StartNonBlocking() error = %v", err)
+	}
+	defer srv.Close()
+	if len(srv.servers) != 2 {
+		t.Fatalf("servers = %d, want 2", len(srv.servers))
+	}
+
+	code, body, err := fasthttp.Get(nil, fmt.Sprintf("http://127.0.0.1:%d%s", srv.config.MetricsPort, metrics.Path))
+	if err != nil {
+		t.Fatalf("scrape the metrics error = %v", err)
+	}
+	if code != fasthttp.StatusOK || !strings.Contains(string(body), "kbagent_http_requests_in_flight") {
+		t.Fatalf("status = %d, metrics body = %q", code, body)
+	}
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error = %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
 func runFastHTTP(handler fasthttp.RequestHandler, method, uri, body string) *fasthttp.RequestCtx {
 	var req fasthttp.Request
 	req.Header.SetMethod(method)
@@ -20,11 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package server
 
 import (
+	"crypto/tls"
 	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 // Server is an interface for the kb-agent server.
@@ -39,8 +45,77 @@ type Config struct {
 	UnixDomainSocket string
 	Port             int
 	StreamingPort    int
+	MetricsPort      int
 	Concurrency      int
 	Logging          bool
+	AuthTokenFile    string
+	TLSCAFile        string
+	TLSCertFile      string
+	TLSKeyFile       string
+	TaskJournalDir   string
+	ReportChannel    string
+	AuditLogDir      string
+	AuditLogSize     int
+	FileAllowedPaths []string
+}
+
+// Credential loads the credential configured for the servers, it returns nil if neither token nor TLS is configured.
+func (c Config) Credential() (*util.Credential, error) {
+	if len(c.AuthTokenFile) == 0 && len(c.TLSCertFile) == 0 && len(c.TLSKeyFile) == 0 {
+		return nil, nil
+	}
+	return util.LoadCredential(c.AuthTokenFile, c.TLSCAFile, c.TLSCertFile, c.TLSKeyFile)
+}
+
+// secureListener wraps the listener with TLS if it is enabled in the credential.
+// The certificate files are reloaded once they are updated, so that the renewed certificate is served without a restart.
+func secureListener(l net.Listener, c Config, cred *util.Credential) (net.Listener, error) {
+	config, err := cred.ServerTLSConfig()
+	if err != nil || config == nil {
+		return l, err
+	}
+	reloader := &tlsConfigReloader{
+		config:    c,
+		tlsConfig: config,
+		modTime:   c.tlsModTime(),
+	}
+	return tls.NewListener(l, &tls.Config{GetConfigForClient: reloader.get}), nil
+}
+
+// tlsModTime returns the latest modification time of the certificate files.
+func (c Config) tlsModTime() time.Time {
+	var modTime time.Time
+	for _, path := range []string{c.TLSCAFile, c.TLSCertFile, c.TLSKeyFile} {
+		if len(path) == 0 {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime
+}
+
+type tlsConfigReloader struct {
+	config    Config
+	mu        sync.Mutex
+	tlsConfig *tls.Config
+	modTime   time.Time
+}
+
+func (r *tlsConfigReloader) get(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if modTime := r.config.tlsModTime(); modTime.After(r.modTime) {
+		// keep serving the current certificate if the files are being updated or broken
+		if cred, err := r.config.Credential(); err == nil {
+			if config, err := cred.ServerTLSConfig(); err == nil && config != nil {
+				r.tlsConfig = config
+				r.modTime = modTime
+			}
+		}
+	}
+	return r.tlsConfig, nil
 }
 
 // NewHTTPServer returns a new HTTP server.
@@ -0,0 +1,109 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+func TestSecureListenerReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	config := Config{
+		TLSCAFile:   filepath.Join(dir, util.AuthCAFileKey),
+		TLSCertFile: filepath.Join(dir, util.AuthCertKey),
+		TLSKeyFile:  filepath.Join(dir, util.AuthKeyKey),
+	}
+	write := func(cred *util.Credential, modTime time.Time) {
+		for path, data := range map[string][]byte{
+			config.TLSCAFile:   cred.CACert,
+			config.TLSCertFile: cred.Cert,
+			config.TLSKeyFile:  cred.Key,
+		} {
+			if err := os.WriteFile(path, data, 0600); err != nil {
+				t.Fatalf("write file error = %v", err)
+			}
+			if err := os.Chtimes(path, modTime, modTime); err != nil {
+				t.Fatalf("chtimes error = %v", err)
+			}
+		}
+	}
+
+	oldCred, err := util.GenerateCredential("test-comp")
+	if err != nil {
+		t.Fatalf("GenerateCredential() error = %v", err)
+	}
+	write(oldCred, time.Now().Add(-time.Hour))
+	cred, err := config.Credential()
+	if err != nil {
+		t.Fatalf("Credential() error = %v", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error = %v", err)
+	}
+	sl, err := secureListener(l, config, cred)
+	if err != nil {
+		t.Fatalf("secureListener() error = %v", err)
+	}
+	defer sl.Close()
+	go func() {
+		for {
+			conn, err := sl.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	handshake := func(cred *util.Credential) error {
+		clientConfig, err := cred.ClientTLSConfig()
+		if err != nil {
+			t.Fatalf("ClientTLSConfig() error = %v", err)
+		}
+		conn, err := tls.Dial("tcp", sl.Addr().String(), clientConfig)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	if err = handshake(oldCred); err != nil {
+		t.Fatalf("handshake error = %v", err)
+	}
+
+	// the credential is replaced with a new CA, the server serves it without a restart
+	newCred, err := util.GenerateCredential("test-comp")
+	if err != nil {
+		t.Fatalf("GenerateCredential() error = %v", err)
+	}
+	write(newCred, time.Now())
+	if err = handshake(newCred); err != nil {
+		t.Fatalf("handshake with the new credential error = %v", err)
+	}
+}
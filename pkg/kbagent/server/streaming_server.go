@@ -20,7 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,7 +31,14 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	maxStreamingAuthPacketSize = 4096
+	streamingAuthTimeout       = 10 * time.Second
 )
 
 type streamingServer struct {
@@ -37,6 +46,7 @@ type streamingServer struct {
 	config   Config
 	service  service.Service
 	listener net.Listener
+	cred     *util.Credential
 }
 
 var _ Server = &streamingServer{}
@@ -50,12 +60,22 @@ func (s *streamingServer) StartNonBlocking() error {
 		return nil
 	}
 
-	var err1 error
-	s.listener, err1 = net.Listen("tcp", fmt.Sprintf("%s:%v", s.config.Address, s.config.StreamingPort))
+	cred, err := s.config.Credential()
+	if err != nil {
+		return err
+	}
+	s.cred = cred
+
+	listener, err1 := net.Listen("tcp", fmt.Sprintf("%s:%v", s.config.Address, s.config.StreamingPort))
 	if err1 != nil {
 		s.logger.Error(err1, "listen failed", "listen address", s.config.Address, "port", s.config.StreamingPort)
 		return err1
 	}
+	s.listener, err1 = secureListener(listener, s.config, s.cred)
+	if err1 != nil {
+		_ = listener.Close()
+		return err1
+	}
 
 	go func() {
 		const (
@@ -108,6 +128,11 @@ func (s *streamingServer) handleConn(conn net.Conn) {
 	logger := s.logger.WithValues("remote", conn.RemoteAddr())
 	logger.Info("accepted a new streaming connection")
 
+	if err := s.authenticate(conn); err != nil {
+		logger.Error(err, "authenticate streaming connection error")
+		return
+	}
+
 	now := time.Now()
 	err := s.service.HandleConn(context.Background(), conn)
 	if err != nil {
@@ -116,3 +141,40 @@ func (s *streamingServer) handleConn(conn net.Conn) {
 		logger.Info("handle streaming connection done", "elapsed", time.Since(now))
 	}
 }
+
+// authenticate reads and verifies the auth packet if the token check is enabled.
+// The packet is read byte by byte to leave the following handshake packet untouched for the service.
+func (s *streamingServer) authenticate(conn net.Conn) error {
+	if !s.cred.TokenEnabled() {
+		return nil
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(streamingAuthTimeout))
+	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
+
+	var (
+		packet bytes.Buffer
+		b      = make([]byte, 1)
+	)
+	for {
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return fmt.Errorf("read auth packet error: %v", err)
+		}
+		if b[0] == '\n' {
+			break
+		}
+		if packet.Len() >= maxStreamingAuthPacketSize {
+			return fmt.Errorf("auth packet size exceeds %d", maxStreamingAuthPacketSize)
+		}
+		packet.WriteByte(b[0])
+	}
+
+	req := &proto.StreamingAuthRequest{}
+	if err := json.Unmarshal(packet.Bytes(), req); err != nil {
+		return fmt.Errorf("unmarshal auth packet error: %v", err)
+	}
+	if !s.cred.VerifyToken(req.Token) {
+		return errors.New("unauthorized")
+	}
+	return nil
+}
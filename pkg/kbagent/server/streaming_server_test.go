@@ -21,6 +21,7 @@ package server
 
 import (
 	"errors"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/klog/v2/ktesting"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 type errorCloser struct {
@@ -160,3 +162,47 @@ func TestStreamingServerHandleConn(t *testing.T) {
 		t.Fatalf("expected closed pipe after handleConn")
 	}
 }
+
+func TestStreamingServerAuthenticate(t *testing.T) {
+	logger := ktesting.NewLogger(t, ktesting.NewConfig())
+	srv := &streamingServer{
+		logger: logger,
+		cred:   &util.Credential{Token: "secret"},
+	}
+
+	authenticate := func(packet string) (string, error) {
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+		defer clientConn.Close()
+		go func() {
+			_, _ = clientConn.Write([]byte(packet))
+		}()
+		err := srv.authenticate(serverConn)
+		if err != nil {
+			return "", err
+		}
+		// the following handshake packet should be left untouched
+		rest := make([]byte, len("handshake"))
+		_, _ = io.ReadFull(serverConn, rest)
+		return string(rest), nil
+	}
+
+	rest, err := authenticate(`{"token":"secret"}` + "\nhandshake")
+	if err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+	if rest != "handshake" {
+		t.Fatalf("handshake packet = %q", rest)
+	}
+	if _, err = authenticate(`{"token":"other"}` + "\n"); err == nil {
+		t.Fatalf("expected the wrong token to be rejected")
+	}
+	if _, err = authenticate("not-json\n"); err == nil {
+		t.Fatalf("expected the malformed auth packet to be rejected")
+	}
+
+	srv.cred = nil
+	if err := srv.authenticate(nil); err != nil {
+		t.Fatalf("authenticate() without token error = %v", err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"sync"
@@ -33,15 +34,22 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/metrics"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
+const (
+	maxRunningActionOutputSize = 1024 * 1024
+)
+
 func newActionService(logger logr.Logger, actions []proto.Action) (*actionService, error) {
 	sa := &actionService{
 		logger:         logger,
 		actions:        make(map[string]*proto.Action),
 		mutex:          sync.Mutex{},
 		runningActions: map[string]*runningAction{},
+		locks:          newActionLocks(),
+		audit:          defaultAuditLog,
 	}
 	for i, action := range actions {
 		sa.actions[action.Name] = &actions[i]
@@ -56,10 +64,16 @@ type actionService struct {
 
 	mutex          sync.Mutex
 	runningActions map[string]*runningAction
+
+	locks *actionLocks
+	audit *auditLog
 }
 
 type runningAction struct {
 	resultChan chan *asyncResult
+	startTime  time.Time
+	output     *outputBuffer
+	cancel     context.CancelFunc
 }
 
 var _ Service = &actionService{}
@@ -85,6 +99,9 @@ func (s *actionService) HandleRequest(ctx context.Context, payload []byte) ([]by
 	if err != nil {
 		return s.encode(nil, err), nil
 	}
+	if req.Operation != proto.ActionOperationCall {
+		return s.encode(nil, errors.Wrapf(proto.ErrBadRequest, "operation %s is not supported by %s", req.Operation, s.URI())), nil
+	}
 	resp, err := s.handleRequest(ctx, req)
 	result := string(resp)
 	if err != nil {
@@ -114,52 +131,164 @@ func (s *actionService) encode(out []byte, err error) []byte {
 	return data
 }
 
+// handleRequest handles the action requests from the controller, the invocations are recorded in the audit log.
 func (s *actionService) handleRequest(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
+	return s.handle(ctx, req, s.audit)
+}
+
+// handleInternalRequest handles the action requests issued by kb-agent itself, such as the probes.
+// They are not audited, to keep the audit log for the invocations from the controller.
+func (s *actionService) handleInternalRequest(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
+	return s.handle(ctx, req, nil)
+}
+
+func (s *actionService) handle(ctx context.Context, req *proto.ActionRequest, audit *auditLog) ([]byte, error) {
 	action, ok := s.actions[req.Action]
 	if !ok {
 		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", req.Action)
 	}
-	if action.Exec == nil && action.HTTP == nil && action.GRPC == nil {
+	if action.Exec == nil && action.HTTP == nil && action.GRPC == nil && action.TCP == nil && action.Script == nil {
 		return nil, errors.Wrapf(proto.ErrBadRequest, "%s is invalid", req.Action)
 	}
 	// HACK: pre-check for the reconfigure action
 	if err := checkReconfigure(ctx, req); err != nil {
 		return nil, err
 	}
+	if err := resolveDryRun(action, req); err != nil {
+		return nil, err
+	}
 	timeout := resolveTimeout(&action.TimeoutSeconds, req.TimeoutSeconds)
 	retryPolicy := resolveRetryPolicy(action.RetryPolicy, req.RetryPolicy)
 	if req.NonBlocking == nil || !*req.NonBlocking {
-		return callActionWithRetry(ctx, action, req.Parameters, req.Arguments, timeout, retryPolicy)
+		record := s.auditRecord(req)
+		lockCtx, holder, err := s.locks.acquire(ctx, action)
+		if err != nil {
+			audit.record(record, err)
+			return nil, err
+		}
+		done := metrics.ActionStarted(action.Name)
+		output, err := callActionWithRetry(lockCtx, action, req.Parameters, req.Arguments, timeout, retryPolicy, nil)
+		err = holder.release(err)
+		done(err)
+		record.OutputSize = int64(len(output))
+		audit.record(record, err)
+		return output, err
 	}
-	return s.handleRequestNonBlocking(ctx, req, action, timeout, retryPolicy)
+	return s.handleRequestNonBlocking(ctx, req, action, timeout, retryPolicy, audit)
 }
 
-func (s *actionService) handleRequestNonBlocking(ctx context.Context, req *proto.ActionRequest, action *proto.Action, timeout *int32, retryPolicy *proto.RetryPolicy) ([]byte, error) {
+func (s *actionService) handleRequestNonBlocking(ctx context.Context, req *proto.ActionRequest, action *proto.Action,
+	timeout *int32, retryPolicy *proto.RetryPolicy, audit *auditLog) ([]byte, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	running, ok := s.runningActions[req.Action]
 	if !ok {
-		resultChan, err := nonBlockingCallActionWithRetry(ctx, action, req.Parameters, req.Arguments, timeout, retryPolicy)
+		output := newOutputBuffer(maxRunningActionOutputSize)
+		ctx, cancel := context.WithCancel(ctx)
+		record := s.auditRecord(req)
+		resultChan, err := s.nonBlockingCallActionWithLock(ctx, action, req, timeout, retryPolicy, output)
 		if err != nil {
+			cancel()
+			audit.record(record, err)
 			return nil, err
 		}
+		resultChan = audited(audit, record, resultChan)
 		running = &runningAction{
 			resultChan: resultChan,
+			startTime:  time.Now(),
+			output:     output,
+			cancel:     cancel,
 		}
 		s.runningActions[req.Action] = running
+		metrics.NonBlockingActionStarted(req.Action)
 	}
 	result := gather(running.resultChan)
 	if result == nil {
 		return nil, proto.ErrInProgress
 	}
-	delete(s.runningActions, req.Action)
+	s.collect(req.Action, running)
 	if (*result).err != nil {
 		return nil, (*result).err
 	}
 	return (*result).stdout.Bytes(), nil
 }
 
+// nonBlockingCallActionWithLock calls the action with the lock of its group held,
+// the action waits for the lock in background if it is queued or preempting the running one.
+func (s *actionService) nonBlockingCallActionWithLock(ctx context.Context, action *proto.Action, req *proto.ActionRequest,
+	timeout *int32, retryPolicy *proto.RetryPolicy, output io.Writer) (chan *asyncResult, error) {
+	if action.Lock == nil || len(action.Lock.Group) == 0 {
+		return nonBlockingCallActionWithRetry(ctx, action, req.Parameters, req.Arguments, timeout, retryPolicy, output)
+	}
+	if len(req.Arguments) > 0 && action.Exec == nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "runtime arguments are only supported for exec actions")
+	}
+
+	lockCtx, holder, running := s.locks.tryAcquire(ctx, action)
+	if holder == nil && action.Lock.Policy != proto.ActionConflictPolicyQueue && action.Lock.Policy != proto.ActionConflictPolicyPreempt {
+		return nil, running.busyError()
+	}
+
+	resultChan := make(chan *asyncResult, 1)
+	go func() {
+		failed := func(err error) *asyncResult {
+			return &asyncResult{err: err, stdout: bytes.NewBuffer(nil), stderr: bytes.NewBuffer(nil)}
+		}
+		if holder == nil {
+			var err error
+			if lockCtx, holder, err = s.locks.acquire(ctx, action); err != nil {
+				resultChan <- failed(err)
+				return
+			}
+		}
+		var result *asyncResult
+		ch, err := nonBlockingCallActionWithRetry(lockCtx, action, req.Parameters, req.Arguments, timeout, retryPolicy, output)
+		if err != nil {
+			result = failed(err)
+		} else {
+			result = <-ch
+		}
+		result.err = holder.release(result.err)
+		resultChan <- result
+	}()
+	return resultChan, nil
+}
+
+func (s *actionService) auditRecord(req *proto.ActionRequest) proto.AuditRecord {
+	return proto.AuditRecord{
+		Action:      req.Action,
+		Source:      s.Kind(),
+		Parameters:  req.Parameters,
+		NonBlocking: req.NonBlocking != nil && *req.NonBlocking,
+		DryRun:      req.DryRun != nil && *req.DryRun,
+		StartTime:   time.Now(),
+	}
+}
+
+// audited records the result of the non-blocking action once it is finished, rather than when it is collected.
+func audited(audit *auditLog, record proto.AuditRecord, resultChan chan *asyncResult) chan *asyncResult {
+	if audit == nil {
+		return resultChan
+	}
+	ch := make(chan *asyncResult, 1)
+	go func() {
+		result := <-resultChan
+		record.OutputSize = int64(result.stdout.Len())
+		audit.record(record, result.err)
+		ch <- result
+	}()
+	return ch
+}
+
+func (s *actionService) collect(name string, running *runningAction) {
+	if running.cancel != nil {
+		running.cancel() // release the resources
+	}
+	delete(s.runningActions, name)
+	metrics.NonBlockingActionCollected(name)
+}
+
 func resolveTimeout(actionTimeout *int32, requestTimeout *int32) *int32 {
 	if requestTimeout != nil {
 		return requestTimeout
@@ -174,33 +303,38 @@ func resolveRetryPolicy(actionRetryPolicy *proto.RetryPolicy, requestRetryPolicy
 	return actionRetryPolicy
 }
 
-func callActionWithRetry(ctx context.Context, action *proto.Action, parameters map[string]string, arguments [][]string, timeout *int32, retryPolicy *proto.RetryPolicy) ([]byte, error) {
+// callActionWithRetry calls the action, the output writer is optional to capture the stdout as it is produced.
+func callActionWithRetry(ctx context.Context, action *proto.Action, parameters map[string]string, arguments [][]string,
+	timeout *int32, retryPolicy *proto.RetryPolicy, output io.Writer) ([]byte, error) {
 	if len(arguments) == 0 {
-		return callActionWithRetryOnce(ctx, action, parameters, nil, timeout, retryPolicy)
+		return callActionWithRetryOnce(ctx, action, parameters, nil, timeout, retryPolicy, output)
 	}
 	if action.Exec == nil {
 		return nil, errors.Wrapf(proto.ErrBadRequest, "runtime arguments are only supported for exec actions")
 	}
-	output := bytes.NewBuffer(nil)
+	stdout := bytes.NewBuffer(nil)
 	for _, args := range arguments {
-		out, err := callActionWithRetryOnce(ctx, action, parameters, args, timeout, retryPolicy)
+		out, err := callActionWithRetryOnce(ctx, action, parameters, args, timeout, retryPolicy, output)
 		if err != nil {
-			return output.Bytes(), err
+			return stdout.Bytes(), err
 		}
 		if out != nil {
-			output.Write(out)
+			stdout.Write(out)
 		}
 	}
-	return output.Bytes(), nil
+	return stdout.Bytes(), nil
 }
 
-func nonBlockingCallActionWithRetry(ctx context.Context, action *proto.Action, parameters map[string]string, arguments [][]string, timeout *int32, retryPolicy *proto.RetryPolicy) (chan *asyncResult, error) {
+func nonBlockingCallActionWithRetry(ctx context.Context, action *proto.Action, parameters map[string]string, arguments [][]string,
+	timeout *int32, retryPolicy *proto.RetryPolicy, output io.Writer) (chan *asyncResult, error) {
 	if len(arguments) > 0 && action.Exec == nil {
 		return nil, errors.Wrapf(proto.ErrBadRequest, "runtime arguments are only supported for exec actions")
 	}
 	resultChan := make(chan *asyncResult, 1)
 	go func() {
-		stdout, err := callActionWithRetry(ctx, action, parameters, arguments, timeout, retryPolicy)
+		done := metrics.ActionStarted(action.Name)
+		stdout, err := callActionWithRetry(ctx, action, parameters, arguments, timeout, retryPolicy, output)
+		done(err)
 		resultChan <- &asyncResult{
 			err:    err,
 			stdout: bytes.NewBuffer(stdout),
@@ -210,10 +344,11 @@ func nonBlockingCallActionWithRetry(ctx context.Context, action *proto.Action, p
 	return resultChan, nil
 }
 
-func callActionWithRetryOnce(ctx context.Context, action *proto.Action, parameters map[string]string, arguments []string, timeout *int32, retryPolicy *proto.RetryPolicy) ([]byte, error) {
-	output, err := blockingCallAction(ctx, action, parameters, arguments, timeout)
+func callActionWithRetryOnce(ctx context.Context, action *proto.Action, parameters map[string]string, arguments []string,
+	timeout *int32, retryPolicy *proto.RetryPolicy, output io.Writer) ([]byte, error) {
+	stdout, err := blockingCallActionWithOutput(ctx, action, parameters, arguments, timeout, output)
 	if err == nil || retryPolicy == nil || retryPolicy.MaxRetries <= 0 {
-		return output, err
+		return stdout, err
 	}
 
 	interval := retryPolicy.RetryInterval
@@ -225,10 +360,10 @@ func callActionWithRetryOnce(ctx context.Context, action *proto.Action, paramete
 			case <-time.After(interval):
 			}
 		}
-		output, err = blockingCallAction(ctx, action, parameters, arguments, timeout)
+		stdout, err = blockingCallActionWithOutput(ctx, action, parameters, arguments, timeout, output)
 		if err == nil {
-			return output, nil
+			return stdout, nil
 		}
 	}
-	return output, err
+	return stdout, err
 }
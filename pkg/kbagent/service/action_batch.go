@@ -0,0 +1,256 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultFinishedBatchTTL = 10 * time.Minute
+)
+
+var (
+	// finishedBatchTTL is how long the result of a finished batch is kept for the retries of the client.
+	finishedBatchTTL = defaultFinishedBatchTTL
+)
+
+// actionBatchService runs the batches of actions of the action service.
+// A batch runs in background, so it is not interrupted if the client is gone before it finishes,
+// and the result of a finished batch is kept for a while, so the client can get it on retry.
+type actionBatchService struct {
+	logger        logr.Logger
+	actionService *actionService
+
+	mutex   sync.Mutex
+	batches map[string]*runningBatch // batch ID -> running or finished batch
+}
+
+type runningBatch struct {
+	done       chan struct{}
+	rsp        *proto.BatchActionResponse
+	finishedAt *time.Time
+}
+
+var _ Service = &actionBatchService{}
+
+func newActionBatchService(logger logr.Logger, actionService *actionService) (*actionBatchService, error) {
+	return &actionBatchService{
+		logger:        logger,
+		actionService: actionService,
+		batches:       map[string]*runningBatch{},
+	}, nil
+}
+
+func (s *actionBatchService) Kind() string {
+	return proto.ServiceActionBatch.Kind
+}
+
+func (s *actionBatchService) URI() string {
+	return proto.ServiceActionBatch.URI
+}
+
+func (s *actionBatchService) Start() error {
+	return nil
+}
+
+func (s *actionBatchService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *actionBatchService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	var rsp *proto.BatchActionResponse
+	req, err := s.decode(payload)
+	if err == nil {
+		err = s.validate(req)
+	}
+	if err == nil {
+		rsp = s.handleRequest(ctx, req)
+	} else {
+		rsp = &proto.BatchActionResponse{
+			Error:   proto.Error2Type(err),
+			Message: err.Error(),
+		}
+	}
+	data, _ := json.Marshal(rsp)
+	return data, nil
+}
+
+func (s *actionBatchService) decode(payload []byte) (*proto.BatchActionRequest, error) {
+	req := &proto.BatchActionRequest{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal batch action request error: %s", err.Error())
+	}
+	return req, nil
+}
+
+func (s *actionBatchService) validate(req *proto.BatchActionRequest) error {
+	if len(req.Steps) == 0 {
+		return errors.Wrap(proto.ErrBadRequest, "batch has no steps")
+	}
+	check := func(action *proto.ActionRequest) error {
+		if _, ok := s.actionService.actions[action.Action]; !ok {
+			return errors.Wrapf(proto.ErrNotDefined, "%s is not defined", action.Action)
+		}
+		if action.Operation != proto.ActionOperationCall {
+			return errors.Wrapf(proto.ErrBadRequest, "operation %s is not supported in batch", action.Operation)
+		}
+		if action.NonBlocking != nil && *action.NonBlocking {
+			return errors.Wrapf(proto.ErrBadRequest, "non-blocking action %s is not supported in batch", action.Action)
+		}
+		return nil
+	}
+	for i := range req.Steps {
+		if err := check(&req.Steps[i].ActionRequest); err != nil {
+			return err
+		}
+		if req.Steps[i].Compensation != nil {
+			if err := check(req.Steps[i].Compensation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *actionBatchService) handleRequest(ctx context.Context, req *proto.BatchActionRequest) *proto.BatchActionResponse {
+	batch, running := s.join(req.ID)
+	if !running {
+		go func() {
+			defer s.finish(req.ID, batch)
+			batch.rsp = s.run(req)
+		}()
+	}
+
+	select {
+	case <-batch.done:
+		return batch.rsp
+	case <-ctx.Done():
+		err := errors.Wrapf(proto.ErrInProgress, "batch %s is still running: %s", req.ID, ctx.Err().Error())
+		return &proto.BatchActionResponse{
+			Error:   proto.Error2Type(err),
+			Message: err.Error(),
+		}
+	}
+}
+
+// join returns the running or finished batch with the ID if exists, otherwise, a new one.
+func (s *actionBatchService) join(id string) (*runningBatch, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.evict(time.Now())
+
+	if batch, ok := s.batches[id]; ok && len(id) > 0 {
+		return batch, true
+	}
+	batch := &runningBatch{done: make(chan struct{})}
+	if len(id) > 0 {
+		s.batches[id] = batch
+	}
+	return batch, false
+}
+
+func (s *actionBatchService) finish(id string, batch *runningBatch) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	batch.finishedAt = &now
+	close(batch.done)
+}
+
+// evict removes the finished batches that have been kept longer than the TTL.
+func (s *actionBatchService) evict(now time.Time) {
+	for id, batch := range s.batches {
+		if batch.finishedAt != nil && now.Sub(*batch.finishedAt) > finishedBatchTTL {
+			delete(s.batches, id)
+		}
+	}
+}
+
+// run calls the steps in order, and compensates the succeeded steps in reverse order if any step fails.
+func (s *actionBatchService) run(req *proto.BatchActionRequest) *proto.BatchActionResponse {
+	ctx := context.Background()
+	stepsCtx, cancel := ctx, context.CancelFunc(func() {})
+	if req.TimeoutSeconds != nil && *req.TimeoutSeconds > 0 {
+		stepsCtx, cancel = context.WithTimeout(ctx, time.Duration(*req.TimeoutSeconds)*time.Second)
+	}
+	defer cancel()
+
+	rsp := &proto.BatchActionResponse{}
+	for i := range req.Steps {
+		step := &req.Steps[i]
+		output, err := s.actionService.handleRequest(stepsCtx, &step.ActionRequest)
+		rsp.Results = append(rsp.Results, batchActionResult(step.Action, output, err))
+		if err != nil {
+			rsp.Error = proto.Error2Type(err)
+			rsp.Message = fmt.Sprintf("step %d (%s) failed: %s", i, step.Action, err.Error())
+			// the compensations are not limited by the timeout of steps
+			if failed := s.compensate(ctx, req.Steps[:i], rsp.Results); len(failed) > 0 {
+				rsp.Message = fmt.Sprintf("%s, compensation failed: %s", rsp.Message, strings.Join(failed, ", "))
+			}
+			break
+		}
+	}
+	s.logger.Info("Action Batch Executed", "id", req.ID, "steps", len(req.Steps), "called", len(rsp.Results), "error", rsp.Message)
+	return rsp
+}
+
+// compensate calls the compensations of the steps in reverse order, it returns the steps failed to compensate.
+func (s *actionBatchService) compensate(ctx context.Context, steps []proto.BatchActionStep, results []proto.BatchActionResult) []string {
+	failed := make([]string, 0)
+	for i := len(steps) - 1; i >= 0; i-- {
+		compensation := steps[i].Compensation
+		if compensation == nil {
+			continue
+		}
+		output, err := s.actionService.handleRequest(ctx, compensation)
+		result := batchActionResult(compensation.Action, output, err)
+		results[i].Compensation = &result
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("step %d (%s)", i, steps[i].Action))
+		}
+	}
+	return failed
+}
+
+func batchActionResult(action string, output []byte, err error) proto.BatchActionResult {
+	result := proto.BatchActionResult{
+		Action: action,
+		Output: output,
+	}
+	if err != nil {
+		result.Error = proto.Error2Type(err)
+		result.Message = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("action batch", func() {
+	var (
+		dir string
+		svc *actionBatchService
+	)
+
+	// each action appends its name to the trace file
+	newAction := func(name string, exitCode int) proto.Action {
+		return proto.Action{
+			Name: name,
+			Exec: &proto.ExecAction{
+				Commands: []string{"/bin/bash", "-c", `echo -n "$0," >> "$1"; echo -n "$0"; exit $2`, name, filepath.Join(dir, "trace"), string(rune('0' + exitCode))},
+			},
+		}
+	}
+
+	trace := func() string {
+		data, _ := os.ReadFile(filepath.Join(dir, "trace"))
+		return string(data)
+	}
+
+	call := func(req proto.BatchActionRequest) *proto.BatchActionResponse {
+		payload, err := json.Marshal(req)
+		Expect(err).Should(BeNil())
+		data, err := svc.HandleRequest(ctx, payload)
+		Expect(err).Should(BeNil())
+		rsp := &proto.BatchActionResponse{}
+		Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+		return rsp
+	}
+
+	step := func(action, compensation string) proto.BatchActionStep {
+		s := proto.BatchActionStep{ActionRequest: proto.ActionRequest{Action: action}}
+		if len(compensation) > 0 {
+			s.Compensation = &proto.ActionRequest{Action: compensation}
+		}
+		return s
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		actionSvc, err := newActionService(logr.Discard(), []proto.Action{
+			newAction("readonly", 0),
+			newAction("readwrite", 0),
+			newAction("dataDump", 0),
+			newAction("failed", 1),
+			{
+				Name: "slow",
+				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "sleep 1; echo -n slow"}},
+			},
+		})
+		Expect(err).Should(BeNil())
+		svc, err = newActionBatchService(logr.Discard(), actionSvc)
+		Expect(err).Should(BeNil())
+		Expect(svc.Kind()).Should(Equal(proto.ServiceActionBatch.Kind))
+		Expect(svc.URI()).Should(Equal(proto.ServiceActionBatch.URI))
+	})
+
+	It("rejects invalid batches", func() {
+		rsp := call(proto.BatchActionRequest{})
+		Expect(rsp.Error).Should(Equal("badRequest"))
+
+		rsp = call(proto.BatchActionRequest{Steps: []proto.BatchActionStep{step("readonly", "missing")}})
+		Expect(rsp.Error).Should(Equal("notDefined"))
+
+		nonBlocking := step("readonly", "")
+		nonBlocking.NonBlocking = ptr.To(true)
+		rsp = call(proto.BatchActionRequest{Steps: []proto.BatchActionStep{nonBlocking}})
+		Expect(rsp.Error).Should(Equal("badRequest"))
+		Expect(trace()).Should(BeEmpty())
+	})
+
+	It("runs the steps in order", func() {
+		rsp := call(proto.BatchActionRequest{
+			Steps: []proto.BatchActionStep{
+				step("readonly", "readwrite"),
+				step("dataDump", ""),
+				step("readwrite", ""),
+			},
+		})
+		Expect(rsp.Error).Should(BeEmpty())
+		Expect(rsp.Results).Should(HaveLen(3))
+		Expect(rsp.Results[1].Output).Should(Equal([]byte("dataDump")))
+		Expect(trace()).Should(Equal("readonly,dataDump,readwrite,"))
+	})
+
+	It("compensates the succeeded steps in reverse order on failure", func() {
+		rsp := call(proto.BatchActionRequest{
+			Steps: []proto.BatchActionStep{
+				step("readonly", "readwrite"),
+				step("dataDump", "readonly"),
+				step("failed", "readonly"),
+				step("readwrite", ""),
+			},
+		})
+		Expect(rsp.Error).ShouldNot(BeEmpty())
+		Expect(rsp.Message).Should(ContainSubstring("step 2 (failed) failed"))
+		Expect(rsp.Results).Should(HaveLen(3))
+		Expect(rsp.Results[0].Compensation).ShouldNot(BeNil())
+		Expect(rsp.Results[0].Compensation.Action).Should(Equal("readwrite"))
+		Expect(rsp.Results[1].Compensation).ShouldNot(BeNil())
+		Expect(rsp.Results[2].Compensation).Should(BeNil())
+		Expect(trace()).Should(Equal("readonly,dataDump,failed,readonly,readwrite,"))
+	})
+
+	It("joins the running batch with the same ID", func() {
+		req := proto.BatchActionRequest{
+			ID:    "b1",
+			Steps: []proto.BatchActionStep{step("slow", "")},
+		}
+		results := make(chan *proto.BatchActionResponse, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				defer GinkgoRecover()
+				results <- call(req)
+			}()
+		}
+		for i := 0; i < 2; i++ {
+			var rsp *proto.BatchActionResponse
+			Eventually(results, 5*time.Second).Should(Receive(&rsp))
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Results).Should(HaveLen(1))
+		}
+		Expect(svc.batches).Should(HaveLen(1))
+	})
+
+	It("returns the result of the finished batch on retry", func() {
+		req := proto.BatchActionRequest{
+			ID:    "b1",
+			Steps: []proto.BatchActionStep{step("readonly", ""), step("dataDump", "")},
+		}
+		rsp := call(req)
+		Expect(rsp.Error).Should(BeEmpty())
+		Expect(trace()).Should(Equal("readonly,dataDump,"))
+
+		By("retry the finished batch")
+		retried := call(req)
+		Expect(retried).Should(Equal(rsp))
+		Expect(trace()).Should(Equal("readonly,dataDump,"))
+
+		By("run the batch again after the TTL")
+		finishedBatchTTL = 0
+		defer func() { finishedBatchTTL = defaultFinishedBatchTTL }()
+		rsp = call(req)
+		Expect(rsp.Error).Should(BeEmpty())
+		Expect(trace()).Should(Equal("readonly,dataDump,readonly,dataDump,"))
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	dryRunEnv = "KB_DRY_RUN"
+)
+
+// resolveDryRun passes the dry-run flag of the request to the action as the parameter KB_DRY_RUN, which is
+// available to the action both as an environment variable and a template variable.
+// The parameter is always set for the actions that support dry-run, so that they can refer to it unconditionally.
+func resolveDryRun(action *proto.Action, req *proto.ActionRequest) error {
+	dryRun := req.DryRun != nil && *req.DryRun
+	if dryRun {
+		if !action.SupportDryRun {
+			return errors.Wrapf(proto.ErrNotImplemented, "%s does not support dry-run", req.Action)
+		}
+		if req.NonBlocking != nil && *req.NonBlocking {
+			return errors.Wrapf(proto.ErrBadRequest, "dry-run is not supported for non-blocking calls of %s", req.Action)
+		}
+	}
+	if !action.SupportDryRun {
+		return nil
+	}
+	if req.Parameters == nil {
+		req.Parameters = map[string]string{}
+	}
+	req.Parameters[dryRunEnv] = strconv.FormatBool(dryRun)
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// actionLocks serializes the actions in the same lock group, it is separate from the limit of --max-concurrency.
+type actionLocks struct {
+	mutex   sync.Mutex
+	holders map[string]*actionLockHolder // lock group -> holder
+}
+
+type actionLockHolder struct {
+	group       string
+	action      string
+	cancel      context.CancelFunc
+	released    chan struct{}
+	preemptedBy string
+	locks       *actionLocks
+}
+
+func newActionLocks() *actionLocks {
+	return &actionLocks{
+		holders: make(map[string]*actionLockHolder),
+	}
+}
+
+// acquire acquires the lock of the action's group according to its conflict policy, it returns a context
+// that is canceled if the holder is preempted. The holder should be released after the action is finished.
+func (l *actionLocks) acquire(ctx context.Context, action *proto.Action) (context.Context, *actionLockHolder, error) {
+	if action.Lock == nil || len(action.Lock.Group) == 0 {
+		return ctx, nil, nil
+	}
+	var timeout <-chan time.Time
+	for {
+		lockCtx, holder, running := l.tryAcquire(ctx, action)
+		if holder != nil {
+			return lockCtx, holder, nil
+		}
+		switch action.Lock.Policy {
+		case proto.ActionConflictPolicyQueue:
+			if timeout == nil {
+				timer := time.NewTimer(queueTimeout(action))
+				defer timer.Stop()
+				timeout = timer.C
+			}
+			select {
+			case <-running.released:
+			case <-timeout:
+				return nil, nil, errors.Wrapf(proto.ErrBusy, "timed out waiting for action %s in lock group %s", running.action, running.group)
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		case proto.ActionConflictPolicyPreempt:
+			l.preempt(running, action.Name)
+			select {
+			case <-running.released:
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		default:
+			return nil, nil, running.busyError()
+		}
+	}
+}
+
+// tryAcquire acquires the lock if it is free, otherwise, it returns the running holder.
+func (l *actionLocks) tryAcquire(ctx context.Context, action *proto.Action) (context.Context, *actionLockHolder, *actionLockHolder) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if running, ok := l.holders[action.Lock.Group]; ok {
+		return nil, nil, running
+	}
+	lockCtx, cancel := context.WithCancel(ctx)
+	holder := &actionLockHolder{
+		group:    action.Lock.Group,
+		action:   action.Name,
+		cancel:   cancel,
+		released: make(chan struct{}),
+		locks:    l,
+	}
+	l.holders[holder.group] = holder
+	return lockCtx, holder, nil
+}
+
+func (l *actionLocks) preempt(running *actionLockHolder, action string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(running.preemptedBy) == 0 {
+		running.preemptedBy = action
+	}
+	running.cancel()
+}
+
+func (h *actionLockHolder) busyError() error {
+	return errors.Wrapf(proto.ErrBusy, "action %s is running in lock group %s", h.action, h.group)
+}
+
+// release releases the lock, the error of the preempted action is reported as busy to be retried later.
+func (h *actionLockHolder) release(err error) error {
+	if h == nil {
+		return err
+	}
+	h.locks.mutex.Lock()
+	defer h.locks.mutex.Unlock()
+	if h.locks.holders[h.group] == h {
+		delete(h.locks.holders, h.group)
+		close(h.released)
+	}
+	h.cancel()
+	if err != nil && len(h.preemptedBy) > 0 {
+		return errors.Wrapf(proto.ErrBusy, "action %s is preempted by %s in lock group %s: %s", h.action, h.preemptedBy, h.group, err.Error())
+	}
+	return err
+}
+
+func queueTimeout(action *proto.Action) time.Duration {
+	switch {
+	case action.Lock.QueueTimeoutSeconds > 0:
+		return time.Duration(action.Lock.QueueTimeoutSeconds) * time.Second
+	case action.TimeoutSeconds > 0:
+		return time.Duration(action.TimeoutSeconds) * time.Second
+	default:
+		return defaultActionCallTimeout
+	}
+}
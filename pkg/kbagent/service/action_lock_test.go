@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("action lock", func() {
+	newAction := func(name string, policy proto.ActionConflictPolicy, queueTimeoutSeconds int32) *proto.Action {
+		return &proto.Action{
+			Name: name,
+			Lock: &proto.ActionLock{
+				Group:               "data",
+				Policy:              policy,
+				QueueTimeoutSeconds: queueTimeoutSeconds,
+			},
+		}
+	}
+
+	It("is a no-op without lock group", func() {
+		locks := newActionLocks()
+		lockCtx, holder, err := locks.acquire(ctx, &proto.Action{Name: "a"})
+		Expect(err).Should(BeNil())
+		Expect(holder).Should(BeNil())
+		Expect(lockCtx).Should(Equal(ctx))
+		Expect(holder.release(nil)).Should(Succeed())
+	})
+
+	It("rejects the conflicting action", func() {
+		locks := newActionLocks()
+		_, holder, err := locks.acquire(ctx, newAction("a", "", 0))
+		Expect(err).Should(BeNil())
+
+		_, _, err = locks.acquire(ctx, newAction("b", proto.ActionConflictPolicyReject, 0))
+		Expect(errors.Is(err, proto.ErrBusy)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("action a is running in lock group data"))
+
+		Expect(holder.release(nil)).Should(Succeed())
+		_, holder, err = locks.acquire(ctx, newAction("b", proto.ActionConflictPolicyReject, 0))
+		Expect(err).Should(BeNil())
+		Expect(holder.release(nil)).Should(Succeed())
+	})
+
+	It("queues the conflicting action", func() {
+		locks := newActionLocks()
+		_, holder, err := locks.acquire(ctx, newAction("a", "", 0))
+		Expect(err).Should(BeNil())
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(100 * time.Millisecond)
+			Expect(holder.release(nil)).Should(Succeed())
+		}()
+		_, queued, err := locks.acquire(ctx, newAction("b", proto.ActionConflictPolicyQueue, 5))
+		Expect(err).Should(BeNil())
+		Expect(queued.action).Should(Equal("b"))
+
+		// time out waiting for the lock
+		_, _, err = locks.acquire(ctx, newAction("c", proto.ActionConflictPolicyQueue, 1))
+		Expect(errors.Is(err, proto.ErrBusy)).Should(BeTrue())
+		Expect(queued.release(nil)).Should(Succeed())
+	})
+
+	It("preempts the running action", func() {
+		locks := newActionLocks()
+		lockCtx, holder, err := locks.acquire(ctx, newAction("a", "", 0))
+		Expect(err).Should(BeNil())
+
+		go func() {
+			defer GinkgoRecover()
+			<-lockCtx.Done()
+			err := holder.release(lockCtx.Err())
+			Expect(errors.Is(err, proto.ErrBusy)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("preempted by b"))
+		}()
+		_, preempting, err := locks.acquire(ctx, newAction("b", proto.ActionConflictPolicyPreempt, 0))
+		Expect(err).Should(BeNil())
+		Expect(preempting.release(nil)).Should(Succeed())
+	})
+
+	It("serializes the actions of the service", func() {
+		newExecAction := func(name string, policy proto.ActionConflictPolicy) proto.Action {
+			action := newAction(name, policy, 0)
+			action.Exec = &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "sleep 60"}}
+			action.TimeoutSeconds = -1
+			return *action
+		}
+		svc, err := newActionService(logr.Discard(), []proto.Action{
+			newExecAction("a", ""),
+			newExecAction("b", proto.ActionConflictPolicyReject),
+			newExecAction("c", proto.ActionConflictPolicyPreempt),
+		})
+		Expect(err).Should(BeNil())
+
+		_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "a", NonBlocking: ptr.To(true)})
+		Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+
+		_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "b", NonBlocking: ptr.To(true)})
+		Expect(errors.Is(err, proto.ErrBusy)).Should(BeTrue())
+		_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "b"})
+		Expect(errors.Is(err, proto.ErrBusy)).Should(BeTrue())
+
+		// preempt the running action in background
+		_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "c", NonBlocking: ptr.To(true)})
+		Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+		Eventually(func() error {
+			_, err := svc.handleRequest(ctx, &proto.ActionRequest{Action: "a", NonBlocking: ptr.To(true)})
+			return err
+		}).Should(MatchError(ContainSubstring("preempted by c")))
+
+		Expect(svc.cancelRunningAction("c")).Should(Succeed())
+	})
+})
@@ -0,0 +1,158 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// actionOperationService serves the operations on the running non-blocking actions of the action service.
+// It is served at a dedicated URI, so that the operations will not be taken as action calls by older kb-agents.
+type actionOperationService struct {
+	logger        logr.Logger
+	actionService *actionService
+}
+
+var _ Service = &actionOperationService{}
+
+func newActionOperationService(logger logr.Logger, actionService *actionService) (*actionOperationService, error) {
+	return &actionOperationService{
+		logger:        logger,
+		actionService: actionService,
+	}, nil
+}
+
+func (s *actionOperationService) Kind() string {
+	return proto.ServiceActionOperation.Kind
+}
+
+func (s *actionOperationService) URI() string {
+	return proto.ServiceActionOperation.URI
+}
+
+func (s *actionOperationService) Start() error {
+	return nil
+}
+
+func (s *actionOperationService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *actionOperationService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	req, err := s.actionService.decode(payload)
+	if err != nil {
+		return s.actionService.encode(nil, err), nil
+	}
+	return s.actionService.handleOperation(req), nil
+}
+
+func (s *actionService) handleOperation(req *proto.ActionRequest) []byte {
+	rsp := &proto.ActionResponse{}
+	var err error
+	switch req.Operation {
+	case proto.ActionOperationList:
+		rsp.Actions, err = s.listRunningActions(req.Action)
+	case proto.ActionOperationOutput:
+		rsp.Output, err = s.runningActionOutput(req.Action)
+	case proto.ActionOperationCancel:
+		err = s.cancelRunningAction(req.Action)
+	default:
+		err = errors.Wrapf(proto.ErrBadRequest, "unknown operation %s", req.Operation)
+	}
+	if err != nil {
+		rsp = &proto.ActionResponse{
+			Error:   proto.Error2Type(err),
+			Message: err.Error(),
+		}
+	}
+	s.logger.Info("Action Operation Handled", "operation", req.Operation, "action", req.Action, "error", rsp.Message)
+	data, _ := json.Marshal(rsp)
+	return data
+}
+
+func (s *actionService) listRunningActions(name string) ([]proto.RunningAction, error) {
+	if len(name) > 0 {
+		if _, ok := s.actions[name]; !ok {
+			return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", name)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	actions := make([]proto.RunningAction, 0)
+	for action, running := range s.runningActions {
+		if len(name) > 0 && action != name {
+			continue
+		}
+		actions = append(actions, proto.RunningAction{
+			Action:    action,
+			StartTime: running.startTime,
+			Elapsed:   time.Since(running.startTime),
+			Finished:  len(running.resultChan) > 0,
+		})
+	}
+	slices.SortFunc(actions, func(a, b proto.RunningAction) int {
+		return strings.Compare(a.Action, b.Action)
+	})
+	return actions, nil
+}
+
+func (s *actionService) runningActionOutput(name string) ([]byte, error) {
+	if _, ok := s.actions[name]; !ok {
+		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", name)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	running, ok := s.runningActions[name]
+	if !ok {
+		return nil, errors.Wrapf(proto.ErrPreconditionFailed, "%s is not running", name)
+	}
+	return running.output.Bytes(), nil
+}
+
+// cancelRunningAction cancels the action and drops its result, it is a no-op if the action is not running.
+func (s *actionService) cancelRunningAction(name string) error {
+	if _, ok := s.actions[name]; !ok {
+		return errors.Wrapf(proto.ErrNotDefined, "%s is not defined", name)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	running, ok := s.runningActions[name]
+	if !ok {
+		return nil
+	}
+	s.collect(name, running)
+	return nil
+}
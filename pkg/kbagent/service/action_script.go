@@ -0,0 +1,257 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/utils/ptr"
+
+	kbaproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultScriptCostLimit        = 1000000
+	defaultScriptMemoryLimit      = 1024 * 1024
+	scriptInterruptCheckFrequency = 100
+)
+
+// scriptBudget tracks the bytes of the data held by an evaluation against the memory limit:
+// the data fetched by the HTTP and TCP calls, the JSON text parsed, and the result.
+// The memory of the other operations, such as the string concatenations, is bounded by the cost limit.
+type scriptBudget struct {
+	limit int64
+	used  int64
+}
+
+func (b *scriptBudget) read(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, b.limit-b.used+1))
+	if err != nil {
+		return nil, err
+	}
+	return data, b.consume(len(data))
+}
+
+func (b *scriptBudget) consume(n int) error {
+	b.used += int64(n)
+	if b.used > b.limit {
+		return errors.Errorf("memory limit exceeded: %d bytes", b.limit)
+	}
+	return nil
+}
+
+// scriptActionCallX evaluates the CEL expression of the action, the result is interpreted as:
+//   - string or bytes: the output of the action.
+//   - bool: the action succeeds if it is true.
+//   - int: the exit code of the action.
+//   - map or list: the output of the action, encoded as JSON.
+func scriptActionCallX(ctx context.Context, cancel context.CancelFunc,
+	action *kbaproto.ScriptAction, parameters map[string]string, errChan chan error, _ io.Reader, stdoutWriter, _ io.Writer) error {
+	budget := &scriptBudget{limit: ptr.Deref(action.MemoryLimit, defaultScriptMemoryLimit)}
+	prg, err := newScriptProgram(ctx, action, budget)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	go func() {
+		defer cancel()
+		defer close(errChan)
+
+		val, _, err1 := prg.ContextEval(ctx, map[string]any{"env": mergeEnvWith(parameters)})
+		if err1 != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				errChan <- kbaproto.ErrTimedOut
+			} else {
+				errChan <- errors.Wrapf(kbaproto.ErrFailed, "failed to evaluate script: %v", err1)
+			}
+			return
+		}
+
+		output, err2 := scriptResult(val)
+		if err2 != nil {
+			errChan <- err2
+			return
+		}
+		if err3 := budget.consume(len(output)); err3 != nil {
+			errChan <- errors.Wrapf(kbaproto.ErrFailed, "script result: %v", err3)
+			return
+		}
+		if len(output) > 0 && stdoutWriter != nil {
+			_, _ = stdoutWriter.Write(output)
+		}
+		errChan <- nil
+	}()
+
+	return nil
+}
+
+func newScriptProgram(ctx context.Context, action *kbaproto.ScriptAction, budget *scriptBudget) (cel.Program, error) {
+	responseType := cel.MapType(cel.StringType, cel.DynType)
+	env, err := cel.NewEnv(
+		ext.Strings(),
+		cel.Variable("env", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Function("httpGet",
+			cel.Overload("httpGet_string", []*cel.Type{cel.StringType}, responseType,
+				cel.UnaryBinding(func(url ref.Val) ref.Val {
+					return scriptHTTPCall(ctx, budget, http.MethodGet, url.(types.String), "")
+				}))),
+		cel.Function("httpPost",
+			cel.Overload("httpPost_string_string", []*cel.Type{cel.StringType, cel.StringType}, responseType,
+				cel.BinaryBinding(func(url, body ref.Val) ref.Val {
+					return scriptHTTPCall(ctx, budget, http.MethodPost, url.(types.String), body.(types.String))
+				}))),
+		cel.Function("tcpCall",
+			cel.Overload("tcpCall_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(address, payload ref.Val) ref.Val {
+					return scriptTCPCall(ctx, budget, address.(types.String), payload.(types.String), "")
+				})),
+			cel.Overload("tcpCall_string_string_string", []*cel.Type{cel.StringType, cel.StringType, cel.StringType}, cel.StringType,
+				cel.FunctionBinding(func(args ...ref.Val) ref.Val {
+					return scriptTCPCall(ctx, budget, args[0].(types.String), args[1].(types.String), args[2].(types.String))
+				}))),
+		cel.Function("parseJSON",
+			cel.Overload("parseJSON_string", []*cel.Type{cel.StringType}, cel.DynType,
+				cel.UnaryBinding(func(data ref.Val) ref.Val {
+					return scriptParseJSON(budget, data.(types.String))
+				}))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(action.Expression)
+	if iss.Err() != nil {
+		return nil, errors.Wrapf(kbaproto.ErrBadRequest, "invalid script: %v", iss.Err())
+	}
+	costLimit := ptr.Deref(action.CostLimit, defaultScriptCostLimit)
+	return env.Program(ast, cel.CostLimit(uint64(costLimit)), cel.InterruptCheckFrequency(scriptInterruptCheckFrequency))
+}
+
+// scriptHTTPCall issues the http request, and returns the status code and body of the response.
+// Unlike the http action, a non-2xx response is not an error, it is up to the script to check the status.
+func scriptHTTPCall(ctx context.Context, budget *scriptBudget, method string, url, body types.String) ref.Val {
+	req, err := http.NewRequestWithContext(ctx, method, string(url), strings.NewReader(string(body)))
+	if err != nil {
+		return types.NewErr("%s %s: %v", method, url, err)
+	}
+	rsp, err := httpClient().Do(req)
+	if err != nil {
+		return types.NewErr("%s %s: %v", method, url, err)
+	}
+	defer safeClose(rsp.Body)
+
+	data, err := budget.read(rsp.Body)
+	if err != nil {
+		return types.NewErr("%s %s: %v", method, url, err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(map[string]any{
+		"status": rsp.StatusCode,
+		"body":   string(data),
+	})
+}
+
+// scriptTCPCall sends the payload to the address, and returns the response,
+// the response is read until it matches the expected pattern if specified.
+func scriptTCPCall(ctx context.Context, budget *scriptBudget, address, payload, pattern types.String) ref.Val {
+	host, port, err := net.SplitHostPort(string(address))
+	if err != nil {
+		return types.NewErr("tcp %s: %v", address, err)
+	}
+	var expect *regexp.Regexp
+	if len(pattern) > 0 {
+		if expect, err = regexp.Compile(string(pattern)); err != nil {
+			return types.NewErr("tcp %s: invalid pattern: %v", address, err)
+		}
+	}
+
+	conn, err := tcpConnection(ctx, &kbaproto.TCPAction{Host: host, Port: port})
+	if err != nil {
+		return types.NewErr("tcp %s: %v", address, err)
+	}
+	defer safeClose(conn)
+	stop := context.AfterFunc(ctx, func() { safeClose(conn) })
+	defer stop()
+
+	if len(payload) > 0 {
+		if _, err = conn.Write([]byte(payload)); err != nil {
+			return types.NewErr("tcp %s: %v", address, err)
+		}
+	}
+	output, matched, err := readTCPResponse(conn, expect)
+	if err != nil {
+		return types.NewErr("tcp %s: %v", address, err)
+	}
+	if err = budget.consume(len(output)); err != nil {
+		return types.NewErr("tcp %s: %v", address, err)
+	}
+	if expect != nil && !matched {
+		return types.NewErr("tcp %s: response does not match the expected pattern: %s", address, pattern)
+	}
+	return types.String(output)
+}
+
+func scriptParseJSON(budget *scriptBudget, data types.String) ref.Val {
+	if err := budget.consume(len(data)); err != nil {
+		return types.NewErr("parseJSON: %v", err)
+	}
+	val := &structpb.Value{}
+	if err := protojson.Unmarshal([]byte(data), val); err != nil {
+		return types.NewErr("parseJSON: %v", err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(val)
+}
+
+func scriptResult(val ref.Val) ([]byte, error) {
+	switch v := val.(type) {
+	case types.String:
+		return []byte(v), nil
+	case types.Bytes:
+		return v, nil
+	case types.Bool:
+		if v {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(kbaproto.ErrFailed, "script evaluated to false")
+	case types.Int:
+		if v == 0 {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(kbaproto.ErrFailed, "exit code: %d", v)
+	default:
+		native, err := val.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+		if err != nil {
+			return nil, errors.Wrapf(kbaproto.ErrFailed, "unsupported script result type: %s", val.Type().TypeName())
+		}
+		return protojson.Marshal(native.(*structpb.Value))
+	}
+}
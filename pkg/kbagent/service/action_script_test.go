@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("script action", func() {
+	call := func(action *proto.ScriptAction, parameters map[string]string, timeout *int32) (string, error) {
+		stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+		errChan, err := nonBlockingCallActionX(ctx, &proto.Action{Script: action}, parameters, nil, timeout, nil, stdoutBuf, nil)
+		if err != nil {
+			return "", err
+		}
+		err, ok := <-errChan
+		Expect(ok).Should(BeTrue())
+		return stdoutBuf.String(), err
+	}
+
+	It("invalid expression", func() {
+		_, err := call(&proto.ScriptAction{Expression: "1 +"}, nil, nil)
+		Expect(err).Should(HaveOccurred())
+		Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+
+		_, err = call(&proto.ScriptAction{Expression: "undefined(1)"}, nil, nil)
+		Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+	})
+
+	It("results", func() {
+		output, err := call(&proto.ScriptAction{Expression: `"primary"`}, nil, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(Equal("primary"))
+
+		output, err = call(&proto.ScriptAction{Expression: `true`}, nil, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(BeEmpty())
+
+		_, err = call(&proto.ScriptAction{Expression: `false`}, nil, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+
+		_, err = call(&proto.ScriptAction{Expression: `0`}, nil, nil)
+		Expect(err).Should(BeNil())
+
+		_, err = call(&proto.ScriptAction{Expression: `3`}, nil, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("exit code: 3"))
+
+		output, err = call(&proto.ScriptAction{Expression: `{"role": "primary", "lag": 0}`}, nil, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(MatchJSON(`{"role": "primary", "lag": 0}`))
+	})
+
+	It("parameters", func() {
+		output, err := call(&proto.ScriptAction{Expression: `env.KB_POD_NAME + "-" + env.KB_ROLE.upperAscii()`},
+			map[string]string{"KB_POD_NAME": "pod-0", "KB_ROLE": "leader"}, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(Equal("pod-0-LEADER"))
+	})
+
+	It("http and json", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/status":
+				_, _ = w.Write([]byte(`{"state": {"role": "Leader"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		parameters := map[string]string{"ENDPOINT": server.URL}
+		output, err := call(&proto.ScriptAction{
+			Expression: `parseJSON(httpGet(env.ENDPOINT + "/status").body).state.role.lowerAscii()`,
+		}, parameters, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(Equal("leader"))
+
+		output, err = call(&proto.ScriptAction{
+			Expression: `httpGet(env.ENDPOINT + "/missing").status == 404 ? "unknown" : "known"`,
+		}, parameters, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(Equal("unknown"))
+	})
+
+	It("tcp", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).Should(BeNil())
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					buf := make([]byte, defaultBufferSize)
+					if _, err := conn.Read(buf); err != nil {
+						return
+					}
+					_, _ = conn.Write([]byte("role:master\r\n"))
+				}()
+			}
+		}()
+
+		output, err := call(&proto.ScriptAction{
+			Expression: `tcpCall(env.ADDRESS, "INFO replication\r\n", "\r\n$").contains("role:master") ? "primary" : "secondary"`,
+		}, map[string]string{"ADDRESS": listener.Addr().String()}, nil)
+		Expect(err).Should(BeNil())
+		Expect(output).Should(Equal("primary"))
+	})
+
+	It("limits", func() {
+		By("cost limit")
+		_, err := call(&proto.ScriptAction{
+			Expression: `[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(x, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(y, x * y > 0))`,
+			CostLimit:  ptr.To(int64(10)),
+		}, nil, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("cost limit"))
+
+		By("memory limit")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("x", 1024)))
+		}))
+		defer server.Close()
+		_, err = call(&proto.ScriptAction{
+			Expression:  `httpGet(env.ENDPOINT).body`,
+			MemoryLimit: ptr.To(int64(512)),
+		}, map[string]string{"ENDPOINT": server.URL}, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("memory limit exceeded"))
+
+		By("memory limit - parse json")
+		_, err = call(&proto.ScriptAction{
+			Expression:  `parseJSON(env.DATA).size() > 0`,
+			MemoryLimit: ptr.To(int64(512)),
+		}, map[string]string{"DATA": "[" + strings.Repeat(`"x",`, 256) + `"x"]`}, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("memory limit exceeded"))
+
+		By("memory limit - result")
+		_, err = call(&proto.ScriptAction{
+			Expression:  `env.DATA + env.DATA`,
+			MemoryLimit: ptr.To(int64(512)),
+		}, map[string]string{"DATA": strings.Repeat("x", 300)}, nil)
+		Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("memory limit exceeded"))
+
+		By("timeout")
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer slow.Close()
+		_, err = call(&proto.ScriptAction{Expression: `httpGet(env.ENDPOINT).body`},
+			map[string]string{"ENDPOINT": slow.URL}, ptr.To(int32(1)))
+		Expect(err).Should(Equal(proto.ErrTimedOut))
+	})
+})
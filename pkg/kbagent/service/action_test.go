@@ -30,6 +30,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
@@ -96,24 +97,120 @@ var _ = Describe("action", func() {
 			svc.runningActions["async"] = &runningAction{resultChan: resultChan}
 			req := &proto.ActionRequest{Action: "async"}
 
-			out, err := svc.handleRequestNonBlocking(ctx, req, svc.actions["async"], nil, nil)
+			out, err := svc.handleRequestNonBlocking(ctx, req, svc.actions["async"], nil, nil, nil)
 			Expect(out).Should(BeNil())
 			Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
 
 			resultChan <- &asyncResult{stdout: bytes.NewBufferString("done"), stderr: bytes.NewBuffer(nil)}
-			out, err = svc.handleRequestNonBlocking(ctx, req, svc.actions["async"], nil, nil)
+			out, err = svc.handleRequestNonBlocking(ctx, req, svc.actions["async"], nil, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(string(out)).Should(Equal("done"))
 			Expect(svc.runningActions).ShouldNot(HaveKey("async"))
 		})
 
+		It("lists, fetches output of and cancels running non-blocking actions", func() {
+			svc, err := newActionService(logr.New(nil), []proto.Action{{
+				Name: "async",
+				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo -n partial; sleep 60"}},
+				// no timeout
+				TimeoutSeconds: -1,
+			}})
+			Expect(err).Should(BeNil())
+			opSvc, err := newActionOperationService(logr.New(nil), svc)
+			Expect(err).Should(BeNil())
+			Expect(opSvc.URI()).Should(Equal(proto.ServiceActionOperation.URI))
+
+			operate := func(op proto.ActionOperation, action string) *proto.ActionResponse {
+				req := &proto.ActionRequest{Action: action, Operation: op}
+				payload, err := json.Marshal(req)
+				Expect(err).Should(BeNil())
+				data, err := opSvc.HandleRequest(ctx, payload)
+				Expect(err).Should(BeNil())
+				rsp := &proto.ActionResponse{}
+				Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+				return rsp
+			}
+
+			rsp := operate(proto.ActionOperationList, "")
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Actions).Should(BeEmpty())
+
+			rsp = operate(proto.ActionOperationOutput, "async")
+			Expect(rsp.Error).Should(Equal("preconditionFailed"))
+
+			req := &proto.ActionRequest{Action: "async", NonBlocking: ptr.To(true)}
+			_, err = svc.handleRequest(ctx, req)
+			Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+
+			rsp = operate(proto.ActionOperationList, "async")
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Actions).Should(HaveLen(1))
+			Expect(rsp.Actions[0].Action).Should(Equal("async"))
+			Expect(rsp.Actions[0].StartTime.IsZero()).Should(BeFalse())
+			Expect(rsp.Actions[0].Finished).Should(BeFalse())
+
+			Eventually(func() string {
+				return string(operate(proto.ActionOperationOutput, "async").Output)
+			}).Should(Equal("partial"))
+
+			rsp = operate(proto.ActionOperationCancel, "async")
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(svc.runningActions).ShouldNot(HaveKey("async"))
+
+			// cancel is idempotent
+			rsp = operate(proto.ActionOperationCancel, "async")
+			Expect(rsp.Error).Should(BeEmpty())
+
+			rsp = operate(proto.ActionOperationCancel, "missing")
+			Expect(rsp.Error).Should(Equal("notDefined"))
+
+			rsp = operate("unknown", "async")
+			Expect(rsp.Error).Should(Equal("badRequest"))
+
+			// operations are not accepted by the action service
+			data, err := svc.HandleRequest(ctx, []byte(`{"action":"async","operation":"cancel"}`))
+			Expect(err).Should(BeNil())
+			rsp = &proto.ActionResponse{}
+			Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal("badRequest"))
+		})
+
 		It("rejects runtime arguments for non-exec actions in blocking and non-blocking calls", func() {
 			action := &proto.Action{HTTP: &proto.HTTPAction{Port: "80"}}
-			_, err := callActionWithRetry(ctx, action, nil, [][]string{{"arg"}}, nil, nil)
+			_, err := callActionWithRetry(ctx, action, nil, [][]string{{"arg"}}, nil, nil, nil)
 			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
 
-			_, err = nonBlockingCallActionWithRetry(ctx, action, nil, [][]string{{"arg"}}, nil, nil)
+			_, err = nonBlockingCallActionWithRetry(ctx, action, nil, [][]string{{"arg"}}, nil, nil, nil)
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("passes the dry-run flag to the actions that support it", func() {
+			svc, err := newActionService(logr.Discard(), []proto.Action{
+				{
+					Name:          "switchover",
+					Exec:          &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo -n dry-run=$KB_DRY_RUN"}},
+					SupportDryRun: true,
+				},
+				{
+					Name: "reconfigure",
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo -n unused"}},
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			output, err := svc.handleRequest(ctx, &proto.ActionRequest{Action: "switchover", DryRun: ptr.To(true)})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("dry-run=true"))
+
+			output, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "switchover"})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("dry-run=false"))
+
+			_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "switchover", DryRun: ptr.To(true), NonBlocking: ptr.To(true)})
 			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+
+			_, err = svc.handleRequest(ctx, &proto.ActionRequest{Action: "reconfigure", DryRun: ptr.To(true)})
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
 		})
 
 		It("resolves timeout preference", func() {
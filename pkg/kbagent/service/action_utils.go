@@ -22,6 +22,7 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -29,6 +30,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -67,6 +69,12 @@ const (
 	defaultHTTPScheme = "HTTP"
 	defaultHTTPMethod = "GET"
 	defaultHTTPPath   = "/"
+
+	maxTCPResponseSize = 64 * 1024
+	// tcpResponseTimeout bounds the wait for the first byte of the response, in case the action has no timeout.
+	tcpResponseTimeout = 10 * time.Second
+	// tcpResponseIdleTimeout is the idle time after which the response received is regarded as complete.
+	tcpResponseIdleTimeout = time.Second
 )
 
 var (
@@ -102,8 +110,44 @@ type asyncResult struct {
 	stderr *bytes.Buffer
 }
 
+// outputBuffer is a concurrency-safe buffer that keeps the latest output of a running action.
+type outputBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+	limit int
+}
+
+func newOutputBuffer(limit int) *outputBuffer {
+	return &outputBuffer{limit: limit}
+}
+
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	n, err := b.buf.Write(p)
+	if b.limit > 0 && b.buf.Len() > b.limit {
+		// discard the oldest output
+		b.buf.Next(b.buf.Len() - b.limit)
+	}
+	return n, err
+}
+
+func (b *outputBuffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
+
 func blockingCallAction(ctx context.Context, action *kbaproto.Action, parameters map[string]string, arguments []string, timeout *int32) ([]byte, error) {
-	resultChan, err := nonBlockingCallAction(ctx, action, parameters, arguments, timeout)
+	return blockingCallActionWithOutput(ctx, action, parameters, arguments, timeout, nil)
+}
+
+// blockingCallActionWithOutput calls the action, and copies the stdout to the output writer as it is produced if provided.
+func blockingCallActionWithOutput(ctx context.Context, action *kbaproto.Action, parameters map[string]string, arguments []string, timeout *int32, output io.Writer) ([]byte, error) {
+	resultChan, err := nonBlockingCallActionWithOutput(ctx, action, parameters, arguments, timeout, output)
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +171,17 @@ func blockingCallAction(ctx context.Context, action *kbaproto.Action, parameters
 }
 
 func nonBlockingCallAction(ctx context.Context, action *kbaproto.Action, parameters map[string]string, arguments []string, timeout *int32) (chan *asyncResult, error) {
+	return nonBlockingCallActionWithOutput(ctx, action, parameters, arguments, timeout, nil)
+}
+
+func nonBlockingCallActionWithOutput(ctx context.Context, action *kbaproto.Action, parameters map[string]string, arguments []string, timeout *int32, output io.Writer) (chan *asyncResult, error) {
 	stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
 	stderrBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
-	execErrorChan, err := nonBlockingCallActionX(ctx, action, parameters, arguments, timeout, nil, stdoutBuf, stderrBuf)
+	var stdoutWriter io.Writer = stdoutBuf
+	if output != nil {
+		stdoutWriter = io.MultiWriter(stdoutBuf, output)
+	}
+	execErrorChan, err := nonBlockingCallActionX(ctx, action, parameters, arguments, timeout, nil, stdoutWriter, stderrBuf)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +223,18 @@ func nonBlockingCallActionX(ctx context.Context, action *kbaproto.Action, parame
 			return nil, errors.Wrapf(kbaproto.ErrBadRequest, "runtime arguments are only supported for exec actions")
 		}
 		err = grpcActionCallX(ctx, cancel, action.GRPC, parameters, errChan, stdinReader, stdoutWriter, stderrWriter)
+	case action.TCP != nil:
+		if len(arguments) > 0 {
+			cancel()
+			return nil, errors.Wrapf(kbaproto.ErrBadRequest, "runtime arguments are only supported for exec actions")
+		}
+		err = tcpActionCallX(ctx, cancel, action.TCP, parameters, errChan, stdinReader, stdoutWriter, stderrWriter)
+	case action.Script != nil:
+		if len(arguments) > 0 {
+			cancel()
+			return nil, errors.Wrapf(kbaproto.ErrBadRequest, "runtime arguments are only supported for exec actions")
+		}
+		err = scriptActionCallX(ctx, cancel, action.Script, parameters, errChan, stdinReader, stdoutWriter, stderrWriter)
 	default:
 		cancel() // cancel the context to release the resources
 		err = errors.Wrapf(kbaproto.ErrBadRequest, "invalid action type")
@@ -612,6 +676,147 @@ func getGRPCMessageField(msg *dynamicpb.Message, fieldName string) (string, erro
 	}
 }
 
+func tcpActionCallX(ctx context.Context, cancel context.CancelFunc,
+	action *kbaproto.TCPAction, parameters map[string]string, errChan chan error, _ io.Reader, stdoutWriter, stderrWriter io.Writer) error {
+	payload, err := renderTemplateData("tcp payload", parameters, action.Payload)
+	if err != nil {
+		return err
+	}
+	var expect *regexp.Regexp
+	if len(action.Expect) > 0 {
+		if expect, err = regexp.Compile(action.Expect); err != nil {
+			return errors.Wrapf(kbaproto.ErrBadRequest, "invalid tcp expect pattern: %v", err)
+		}
+	}
+
+	go func() {
+		defer cancel()
+		defer close(errChan)
+
+		handleError := func(err error, msg string) {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				errChan <- kbaproto.ErrTimedOut
+			} else {
+				errChan <- errors.Wrapf(kbaproto.ErrFailed, "%s: %v", msg, err)
+			}
+		}
+
+		conn, err1 := tcpConnection(ctx, action)
+		if err1 != nil {
+			handleError(err1, "failed to connect to tcp server")
+			return
+		}
+		defer safeClose(conn)
+		// unblock the read and write when the action is canceled or timed out
+		stop := context.AfterFunc(ctx, func() { safeClose(conn) })
+		defer stop()
+
+		if len(payload) > 0 {
+			if _, err2 := conn.Write([]byte(payload)); err2 != nil {
+				handleError(err2, "failed to send tcp payload")
+				return
+			}
+		}
+
+		if len(payload) == 0 && expect == nil {
+			// nothing to ask and nothing to match, the connection is all that is checked
+			errChan <- nil
+			return
+		}
+
+		output, matched, err3 := readTCPResponse(conn, expect)
+		if err3 != nil {
+			handleError(err3, "failed to read tcp response")
+			return
+		}
+
+		if expect == nil || matched {
+			if len(output) > 0 && stdoutWriter != nil {
+				_, _ = stdoutWriter.Write(output)
+			}
+			errChan <- nil
+		} else {
+			if len(output) > 0 && stderrWriter != nil {
+				_, _ = stderrWriter.Write(output)
+			}
+			errChan <- errors.Wrapf(kbaproto.ErrFailed, "tcp response does not match the expected pattern: %s", action.Expect)
+		}
+	}()
+
+	return nil
+}
+
+func tcpConnection(ctx context.Context, action *kbaproto.TCPAction) (net.Conn, error) {
+	host := defaultHTTPHost
+	if len(action.Host) > 0 {
+		host = action.Host
+	}
+	// the named port has been resolved to a numeric one by the controller
+	dialer := &net.Dialer{Timeout: defaultConnectTimeout}
+	address := net.JoinHostPort(host, action.Port)
+	if action.TLS == nil {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	serverName := action.TLS.ServerName
+	if len(serverName) == 0 {
+		serverName = host
+	}
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config: &tls.Config{
+			ServerName:         serverName,
+			InsecureSkipVerify: action.TLS.InsecureSkipVerify, // #nosec G402
+			MinVersion:         tls.VersionTLS12,
+		},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", address)
+}
+
+// readTCPResponse reads the response until it matches the expected pattern, the server closes the connection,
+// the server stays idle after it has responded, or the response exceeds the size limit.
+// The servers that keep the connection open, such as Redis, don't signal the end of a response, so the response
+// received is regarded as complete once the server has been idle for a while.
+func readTCPResponse(conn net.Conn, expect *regexp.Regexp) ([]byte, bool, error) {
+	var (
+		output bytes.Buffer
+		buf    = make([]byte, defaultBufferSize)
+	)
+	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
+
+	firstByteTimeout := tcpResponseTimeout
+	if expect == nil {
+		firstByteTimeout = tcpResponseIdleTimeout
+	}
+	for {
+		timeout := tcpResponseIdleTimeout
+		if output.Len() == 0 {
+			timeout = firstByteTimeout
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, false, err
+		}
+		n, err := conn.Read(buf)
+		if n > 0 {
+			output.Write(buf[:n])
+			if expect != nil && expect.Match(output.Bytes()) {
+				return output.Bytes(), true, nil
+			}
+			if output.Len() >= maxTCPResponseSize {
+				return output.Bytes(), false, nil
+			}
+		}
+		if err == io.EOF {
+			return output.Bytes(), false, nil
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) && (output.Len() > 0 || expect == nil) {
+			return output.Bytes(), false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+}
+
 func safeClose(c io.Closer) { _ = c.Close() }
 
 func safeCloseF(c func() error) { _ = c() }
@@ -32,6 +32,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -338,7 +339,7 @@ var _ = Describe("action utils", func() {
 					Args:     []string{"static"},
 				},
 			}
-			_, err = callActionWithRetry(ctx, action, nil, [][]string{{"maxmemory", "1gb"}, {"timeout", "30"}}, nil, nil)
+			_, err = callActionWithRetry(ctx, action, nil, [][]string{{"maxmemory", "1gb"}, {"timeout", "30"}}, nil, nil, nil)
 			Expect(err).Should(BeNil())
 
 			content, err := os.ReadFile(path)
@@ -352,7 +353,7 @@ var _ = Describe("action utils", func() {
 					Commands: []string{"/bin/bash", "-c", `printf "%s=%s\n" "$1" "$2"`, "--"},
 				},
 			}
-			output, err := callActionWithRetry(ctx, action, nil, [][]string{{"maxmemory", "1gb"}, {"timeout", "30"}}, nil, nil)
+			output, err := callActionWithRetry(ctx, action, nil, [][]string{{"maxmemory", "1gb"}, {"timeout", "30"}}, nil, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(output).Should(Equal([]byte("maxmemory=1gb\ntimeout=30\n")))
 		})
@@ -1077,6 +1078,195 @@ message EchoResponse {
 			Expect(err).Should(MatchError(ContainSubstring("unsupported field type")))
 		})
 	})
+
+	Context("tcp", func() {
+		var (
+			listener net.Listener
+			port     string
+		)
+
+		// serve replies the response for each line received, and closes the connection if the response is empty
+		serve := func(responses map[string]string) {
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						defer conn.Close()
+						buf := make([]byte, defaultBufferSize)
+						for {
+							n, err := conn.Read(buf)
+							if err != nil {
+								return
+							}
+							rsp := responses[strings.TrimSpace(string(buf[:n]))]
+							if len(rsp) == 0 {
+								return
+							}
+							_, _ = conn.Write([]byte(rsp))
+						}
+					}()
+				}
+			}()
+		}
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			_, port, err = net.SplitHostPort(listener.Addr().String())
+			Expect(err).Should(BeNil())
+		})
+
+		AfterEach(func() {
+			_ = listener.Close()
+		})
+
+		It("x - matches the expected pattern", func() {
+			serve(map[string]string{"PING": "+PONG\r\n"})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "PING\r\n",
+					Expect:  `^\+PONG`,
+				},
+			}
+			stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, stdoutBuf, nil)
+			Expect(err).Should(BeNil())
+
+			wait(errChan)
+			Expect(stdoutBuf.String()).Should(Equal("+PONG\r\n"))
+		})
+
+		It("x - parameters", func() {
+			serve(map[string]string{"ECHO hello": "hello\n"})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "ECHO {{ .WORD }}\n",
+					Expect:  "hello",
+				},
+			}
+			stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+			errChan, err := nonBlockingCallActionX(ctx, action, map[string]string{"WORD": "hello"}, nil, nil, nil, stdoutBuf, nil)
+			Expect(err).Should(BeNil())
+
+			wait(errChan)
+			Expect(stdoutBuf.String()).Should(Equal("hello\n"))
+		})
+
+		It("x - reads until closed without expected pattern", func() {
+			serve(map[string]string{})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "ruok",
+				},
+			}
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, nil, nil)
+			Expect(err).Should(BeNil())
+
+			wait(errChan)
+		})
+
+		It("x - mismatch", func() {
+			serve(map[string]string{"PING": "-ERR\r\n"})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "PING\r\n",
+					Expect:  `^\+PONG`,
+				},
+			}
+			stderrBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+			timeout := int32(10)
+			start := time.Now()
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, &timeout, nil, nil, stderrBuf)
+			Expect(err).Should(BeNil())
+
+			// the server keeps the connection open, the response is regarded as complete once it is idle
+			err = waitError(errChan)
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("does not match"))
+			Expect(stderrBuf.String()).Should(Equal("-ERR\r\n"))
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It("x - returns the response without expected pattern", func() {
+			serve(map[string]string{"stats": "STAT pid 1\r\nEND\r\n"})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "stats\r\n",
+				},
+			}
+			stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, stdoutBuf, nil)
+			Expect(err).Should(BeNil())
+
+			// the server keeps the connection open, the action doesn't wait for it to be closed
+			wait(errChan)
+			Expect(stdoutBuf.String()).Should(Equal("STAT pid 1\r\nEND\r\n"))
+		})
+
+		It("x - checks the connection only", func() {
+			serve(map[string]string{})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{Port: port},
+			}
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, nil, nil)
+			Expect(err).Should(BeNil())
+
+			wait(errChan)
+		})
+
+		It("x - mismatch on close", func() {
+			serve(map[string]string{})
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:    port,
+					Payload: "PING\r\n",
+					Expect:  `^\+PONG`,
+				},
+			}
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, nil, nil)
+			Expect(err).Should(BeNil())
+
+			err = waitError(errChan)
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("does not match"))
+		})
+
+		It("rejects invalid expected patterns and runtime arguments", func() {
+			action := &proto.Action{
+				TCP: &proto.TCPAction{
+					Port:   port,
+					Expect: "(",
+				},
+			}
+			_, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, nil, nil)
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+
+			_, err = nonBlockingCallActionX(ctx, action, nil, []string{"arg"}, nil, nil, nil, nil)
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("fails to connect", func() {
+			_ = listener.Close()
+			action := &proto.Action{
+				TCP: &proto.TCPAction{Port: port},
+			}
+			errChan, err := nonBlockingCallActionX(ctx, action, nil, nil, nil, nil, nil, nil)
+			Expect(err).Should(BeNil())
+
+			err = waitError(errChan)
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("failed to connect to tcp server"))
+		})
+	})
 })
 
 func dynamicGRPCFieldFile() protoreflect.FileDescriptor {
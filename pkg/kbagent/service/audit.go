@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// auditService serves the queries on the audit log of the action invocations.
+type auditService struct {
+	logger logr.Logger
+	audit  *auditLog
+}
+
+var _ Service = &auditService{}
+
+func newAuditService(logger logr.Logger, audit *auditLog) (*auditService, error) {
+	return &auditService{
+		logger: logger,
+		audit:  audit,
+	}, nil
+}
+
+func (s *auditService) Kind() string {
+	return proto.ServiceAudit.Kind
+}
+
+func (s *auditService) URI() string {
+	return proto.ServiceAudit.URI
+}
+
+func (s *auditService) Start() error {
+	return nil
+}
+
+func (s *auditService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *auditService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	rsp := &proto.AuditResponse{}
+	req := &proto.AuditRequest{}
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, req); err != nil {
+			err = errors.Wrapf(proto.ErrBadRequest, "unmarshal audit request error: %s", err.Error())
+			rsp.Error = proto.Error2Type(err)
+			rsp.Message = err.Error()
+		}
+	}
+	if len(rsp.Error) == 0 {
+		rsp.Records = s.audit.query(req)
+	}
+	data, _ := json.Marshal(rsp)
+	return data, nil
+}
@@ -0,0 +1,249 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultAuditLogSize = 256
+	maxAuditMessageSize = 4096
+
+	auditLogFileName = "audit.log"
+	auditLogFileMode = 0600
+
+	auditRedactedValue = "******"
+)
+
+var (
+	auditSecretParameter = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|statement|private)`)
+
+	defaultAuditLog, _ = newAuditLog("", defaultAuditLogSize)
+)
+
+// SetupAuditLog sets up the audit log of the action invocations, the records are kept in memory only if the dir is empty.
+// It should be called before the services are created.
+func SetupAuditLog(dir string, size int) error {
+	l, err := newAuditLog(dir, size)
+	if err != nil {
+		return err
+	}
+	defaultAuditLog = l
+	return nil
+}
+
+// auditLog keeps the latest records of the action invocations in a ring buffer. The records are also appended to a file
+// in the directory if specified, the file is compacted to the capacity once it holds twice as many records,
+// so that both the memory and disk usage are bounded.
+type auditLog struct {
+	dir      string
+	capacity int
+
+	mutex   sync.Mutex
+	records []proto.AuditRecord
+	seq     int64
+	lines   int // the number of records in the file
+}
+
+func newAuditLog(dir string, capacity int) (*auditLog, error) {
+	if capacity <= 0 {
+		capacity = defaultAuditLogSize
+	}
+	l := &auditLog{dir: dir, capacity: capacity}
+	if len(dir) > 0 {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+		if err := l.load(); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func (l *auditLog) path() string {
+	return filepath.Join(l.dir, auditLogFileName)
+}
+
+// load restores the records from the file, the corrupted lines, e.g. a partial write on crash, are skipped.
+func (l *auditLog) load() error {
+	f, err := os.Open(l.path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer safeClose(f)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, defaultBufferSize), 4*maxAuditMessageSize)
+	for scanner.Scan() {
+		record := proto.AuditRecord{}
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		l.append(record)
+		l.seq = max(l.seq, record.Seq)
+		l.lines++
+	}
+	return scanner.Err()
+}
+
+func (l *auditLog) append(record proto.AuditRecord) {
+	l.records = append(l.records, record)
+	if len(l.records) > l.capacity {
+		l.records = l.records[len(l.records)-l.capacity:]
+	}
+}
+
+// record adds the record of an invocation, the result is taken from the error.
+// Only the size of the output is recorded, and the values of the secret parameters are redacted from the message.
+func (l *auditLog) record(record proto.AuditRecord, err error) {
+	if l == nil {
+		return
+	}
+	record.Elapsed = time.Since(record.StartTime)
+	if err != nil {
+		record.Error = proto.Error2Type(err)
+		record.Message = redactAuditMessage(err.Error(), record.Parameters)
+		if len(record.Message) > maxAuditMessageSize {
+			record.Message = record.Message[:maxAuditMessageSize]
+		}
+	}
+	record.Parameters = redactAuditParameters(record.Parameters)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.seq++
+	record.Seq = l.seq
+	l.append(record)
+	if len(l.dir) > 0 {
+		// the audit log is best-effort, it should never fail the action
+		_ = l.persist(record)
+	}
+}
+
+func (l *auditLog) persist(record proto.AuditRecord) error {
+	if l.lines >= 2*l.capacity {
+		return l.compact()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogFileMode)
+	if err != nil {
+		return err
+	}
+	defer safeClose(f)
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	l.lines++
+	return nil
+}
+
+// compact rewrites the file with the records in memory atomically.
+func (l *auditLog) compact() error {
+	tmp := l.path() + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, auditLogFileMode)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(f)
+	for _, record := range l.records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			safeClose(f)
+			return err
+		}
+		_, _ = writer.Write(append(data, '\n'))
+	}
+	if err = writer.Flush(); err != nil {
+		safeClose(f)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, l.path()); err != nil {
+		return err
+	}
+	l.lines = len(l.records)
+	return nil
+}
+
+func (l *auditLog) query(req *proto.AuditRequest) []proto.AuditRecord {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	records := make([]proto.AuditRecord, 0)
+	for _, record := range l.records {
+		if len(req.Action) > 0 && record.Action != req.Action {
+			continue
+		}
+		if req.Since != nil && record.StartTime.Before(*req.Since) {
+			continue
+		}
+		if req.Until != nil && !record.StartTime.Before(*req.Until) {
+			continue
+		}
+		records = append(records, record)
+	}
+	if req.Limit > 0 && len(records) > req.Limit {
+		records = records[len(records)-req.Limit:]
+	}
+	return records
+}
+
+func redactAuditMessage(message string, parameters map[string]string) string {
+	for k, v := range parameters {
+		if len(v) > 0 && auditSecretParameter.MatchString(k) {
+			message = strings.ReplaceAll(message, v, auditRedactedValue)
+		}
+	}
+	return message
+}
+
+func redactAuditParameters(parameters map[string]string) map[string]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	result := maps.Clone(parameters)
+	for k := range result {
+		if auditSecretParameter.MatchString(k) {
+			result[k] = auditRedactedValue
+		}
+	}
+	return result
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("audit log", func() {
+	var (
+		dir string
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	lines := func() int {
+		data, err := os.ReadFile(filepath.Join(dir, auditLogFileName))
+		Expect(err).Should(BeNil())
+		return bytes.Count(data, []byte("\n"))
+	}
+
+	Context("log", func() {
+		It("record", func() {
+			l, err := newAuditLog("", 8)
+			Expect(err).Should(BeNil())
+
+			l.record(proto.AuditRecord{
+				Action:     "switchover",
+				Parameters: map[string]string{"KB_SWITCHOVER_CANDIDATE_NAME": "pod-1", "KB_ACCOUNT_PASSWORD": "pwd", "KB_ACCOUNT_STATEMENT": "CREATE USER"},
+				StartTime:  time.Now(),
+				OutputSize: 8192,
+			}, errors.Wrap(proto.ErrFailed, "exit code: 1, CREATE USER u IDENTIFIED BY pwd failed"))
+
+			records := l.query(&proto.AuditRequest{})
+			Expect(records).Should(HaveLen(1))
+			Expect(records[0].Seq).Should(Equal(int64(1)))
+			Expect(records[0].Parameters).Should(Equal(map[string]string{
+				"KB_SWITCHOVER_CANDIDATE_NAME": "pod-1",
+				"KB_ACCOUNT_PASSWORD":          auditRedactedValue,
+				"KB_ACCOUNT_STATEMENT":         auditRedactedValue,
+			}))
+			Expect(records[0].OutputSize).Should(Equal(int64(8192)))
+			Expect(records[0].Error).Should(Equal(proto.Error2Type(proto.ErrFailed)))
+			Expect(records[0].Message).Should(HavePrefix("exit code: 1, ****** u IDENTIFIED BY ****** failed"))
+		})
+
+		It("query", func() {
+			l, err := newAuditLog("", 4)
+			Expect(err).Should(BeNil())
+
+			now := time.Now()
+			for i := 0; i < 6; i++ {
+				l.record(proto.AuditRecord{
+					Action:    fmt.Sprintf("action-%d", i%2),
+					StartTime: now.Add(time.Duration(i) * time.Minute),
+				}, nil)
+			}
+
+			By("capacity")
+			records := l.query(&proto.AuditRequest{})
+			Expect(records).Should(HaveLen(4))
+			Expect(records[0].Seq).Should(Equal(int64(3)))
+			Expect(records[3].Seq).Should(Equal(int64(6)))
+
+			By("action")
+			records = l.query(&proto.AuditRequest{Action: "action-1"})
+			Expect(records).Should(HaveLen(2))
+			Expect(records[0].Seq).Should(Equal(int64(4)))
+
+			By("time")
+			records = l.query(&proto.AuditRequest{Since: ptr.To(now.Add(3 * time.Minute)), Until: ptr.To(now.Add(5 * time.Minute))})
+			Expect(records).Should(HaveLen(2))
+			Expect(records[0].Seq).Should(Equal(int64(4)))
+			Expect(records[1].Seq).Should(Equal(int64(5)))
+
+			By("limit")
+			records = l.query(&proto.AuditRequest{Limit: 1})
+			Expect(records).Should(HaveLen(1))
+			Expect(records[0].Seq).Should(Equal(int64(6)))
+		})
+
+		It("persist and compact", func() {
+			l, err := newAuditLog(dir, 4)
+			Expect(err).Should(BeNil())
+			for i := 0; i < 8; i++ {
+				l.record(proto.AuditRecord{Action: "action", StartTime: time.Now()}, nil)
+			}
+			Expect(lines()).Should(Equal(8))
+
+			l.record(proto.AuditRecord{Action: "action", StartTime: time.Now()}, nil)
+			Expect(lines()).Should(Equal(4))
+
+			By("reload")
+			f, err := os.OpenFile(filepath.Join(dir, auditLogFileName), os.O_APPEND|os.O_WRONLY, auditLogFileMode)
+			Expect(err).Should(BeNil())
+			_, _ = f.WriteString(`{"seq": 10, "act`) // partial write
+			Expect(f.Close()).Should(Succeed())
+
+			l, err = newAuditLog(dir, 4)
+			Expect(err).Should(BeNil())
+			records := l.query(&proto.AuditRequest{})
+			Expect(records).Should(HaveLen(4))
+			Expect(records[3].Seq).Should(Equal(int64(9)))
+
+			l.record(proto.AuditRecord{Action: "action", StartTime: time.Now()}, nil)
+			records = l.query(&proto.AuditRequest{})
+			Expect(records[3].Seq).Should(Equal(int64(10)))
+		})
+	})
+
+	Context("services", func() {
+		var (
+			audit *auditLog
+			sa    *actionService
+		)
+
+		BeforeEach(func() {
+			var err error
+			audit, err = newAuditLog(dir, 16)
+			Expect(err).Should(BeNil())
+			sa, err = newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: "echo",
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo -n $KB_VALUE"}},
+				},
+				{
+					Name: "fail",
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "exit 1"}},
+				},
+			})
+			Expect(err).Should(BeNil())
+			sa.audit = audit
+		})
+
+		It("action", func() {
+			_, err := sa.handleRequest(ctx, &proto.ActionRequest{Action: "echo", Parameters: map[string]string{"KB_VALUE": "ok"}})
+			Expect(err).Should(BeNil())
+			_, err = sa.handleRequest(ctx, &proto.ActionRequest{Action: "fail"})
+			Expect(err).ShouldNot(BeNil())
+
+			records := audit.query(&proto.AuditRequest{})
+			Expect(records).Should(HaveLen(2))
+			Expect(records[0].Action).Should(Equal("echo"))
+			Expect(records[0].Source).Should(Equal(proto.ServiceAction.Kind))
+			Expect(records[0].OutputSize).Should(Equal(int64(2)))
+			Expect(records[0].Error).Should(BeEmpty())
+			Expect(records[1].Action).Should(Equal("fail"))
+			Expect(records[1].Error).ShouldNot(BeEmpty())
+		})
+
+		It("internal action", func() {
+			// the probes are issued by kb-agent itself, they are not audited
+			_, err := sa.handleInternalRequest(ctx, &proto.ActionRequest{Action: "echo"})
+			Expect(err).Should(BeNil())
+			_, err = sa.handleInternalRequest(ctx, &proto.ActionRequest{Action: "fail"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(audit.query(&proto.AuditRequest{})).Should(BeEmpty())
+		})
+
+		It("non-blocking action", func() {
+			req := &proto.ActionRequest{Action: "echo", Parameters: map[string]string{"KB_VALUE": "ok"}, NonBlocking: ptr.To(true)}
+			_, _ = sa.handleRequest(ctx, req)
+			Eventually(func() []proto.AuditRecord {
+				return audit.query(&proto.AuditRequest{})
+			}).Should(HaveLen(1))
+
+			// the result collected is not recorded again
+			output, err := sa.handleRequest(ctx, req)
+			Expect(err).Should(BeNil())
+			Expect(output).Should(Equal([]byte("ok")))
+			records := audit.query(&proto.AuditRequest{})
+			Expect(records).Should(HaveLen(1))
+			Expect(records[0].NonBlocking).Should(BeTrue())
+			Expect(records[0].OutputSize).Should(Equal(int64(2)))
+		})
+
+		It("query", func() {
+			_, _ = sa.handleRequest(ctx, &proto.ActionRequest{Action: "echo"})
+			_, _ = sa.handleRequest(ctx, &proto.ActionRequest{Action: "fail"})
+
+			svc, err := newAuditService(logr.New(nil), audit)
+			Expect(err).Should(BeNil())
+			Expect(svc.Kind()).Should(Equal(proto.ServiceAudit.Kind))
+			Expect(svc.URI()).Should(Equal(proto.ServiceAudit.URI))
+
+			payload, _ := json.Marshal(proto.AuditRequest{Action: "fail"})
+			data, err := svc.HandleRequest(ctx, payload)
+			Expect(err).Should(BeNil())
+			rsp := &proto.AuditResponse{}
+			Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+			Expect(rsp.Records).Should(HaveLen(1))
+			Expect(rsp.Records[0].Action).Should(Equal("fail"))
+
+			data, err = svc.HandleRequest(ctx, []byte("{"))
+			Expect(err).Should(BeNil())
+			Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBadRequest)))
+		})
+	})
+})
@@ -21,11 +21,38 @@ package service
 
 import (
 	"encoding/json"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	// maxEventMessageLength is the Kubernetes limit for the Event message field;
+	// creating an Event with a longer message is rejected by the apiserver.
+	maxEventMessageLength = 1024
+
+	// maxStatusMessageLength is the limit for a record in the status object, whose annotations
+	// are limited to 256KiB in total.
+	maxStatusMessageLength = 16 * 1024
 )
 
-// maxEventMessageLength is the Kubernetes limit for the Event message field;
-// creating an Event with a longer message is rejected by the apiserver.
-const maxEventMessageLength = 1024
+// sendReport sends the report through the status object if it is enabled, otherwise, through the event.
+// The key identifies the record of the report in the status object.
+func sendReport(logger *logr.Logger, key, reason, message string, sync bool) error {
+	if util.StatusReportEnabled() {
+		return util.SendStatusWithMessage(logger, key, reason, message, sync)
+	}
+	return util.SendEventWithMessage(logger, reason, message, sync)
+}
+
+// marshalReport marshals the report with the size limit of the report channel.
+func marshalReport(event any, message *string, output *[]byte) ([]byte, error) {
+	if util.StatusReportEnabled() {
+		return marshalWithSizeLimit(event, message, output, maxStatusMessageLength)
+	}
+	return marshalEventWithSizeLimit(event, message, output)
+}
 
 // marshalEventWithSizeLimit marshals an event and, when the encoded form
 // exceeds the Event message limit, shrinks the event's free-text fields —
@@ -34,16 +61,20 @@ const maxEventMessageLength = 1024
 // The message and output pointers must reference fields of the event being
 // marshaled.
 func marshalEventWithSizeLimit(event any, message *string, output *[]byte) ([]byte, error) {
+	return marshalWithSizeLimit(event, message, output, maxEventMessageLength)
+}
+
+func marshalWithSizeLimit(event any, message *string, output *[]byte, limit int) ([]byte, error) {
 	const marker = "...(truncated)"
 	for {
 		msg, err := json.Marshal(event)
 		if err != nil {
 			return nil, err
 		}
-		if len(msg) <= maxEventMessageLength {
+		if len(msg) <= limit {
 			return msg, nil
 		}
-		overflow := len(msg) - maxEventMessageLength
+		overflow := len(msg) - limit
 		switch {
 		case len(*message) > len(marker):
 			cut := min(overflow+len(marker), len(*message))
@@ -0,0 +1,484 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultFileMode = 0644
+
+	// maxFileContentSize is the max size of the content read or written in a request, the larger files should be uploaded.
+	maxFileContentSize = 4 * 1024 * 1024
+
+	fileUploadSuffix = ".kbagent-upload"
+)
+
+var (
+	fileAllowedPaths []string
+)
+
+// SetupFileAllowedPaths sets the paths that the file service is allowed to operate under, the file service is disabled if empty.
+// It should be called before the services are created.
+func SetupFileAllowedPaths(paths []string) error {
+	allowed := make([]string, 0)
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("the allowed path of the file service should be absolute: %s", path)
+		}
+		allowed = append(allowed, filepath.Clean(path))
+	}
+	fileAllowedPaths = allowed
+	return nil
+}
+
+// fileService writes, reads, checksums and deletes the files under the allowed paths. The files are replaced atomically,
+// the content is written to a temporary file in the same directory and then renamed to the target, so that the readers
+// never see a partial file.
+type fileService struct {
+	logger       logr.Logger
+	allowedPaths []string
+
+	mutex sync.Mutex
+	busy  sets.Set[string] // the files being written
+}
+
+var _ Service = &fileService{}
+
+func newFileService(logger logr.Logger, allowedPaths []string) (*fileService, error) {
+	sf := &fileService{
+		logger:       logger,
+		allowedPaths: allowedPaths,
+		busy:         sets.New[string](),
+	}
+	logger.Info(fmt.Sprintf("create service %s", sf.Kind()), "paths", strings.Join(allowedPaths, ","))
+	return sf, nil
+}
+
+func (s *fileService) Kind() string {
+	return proto.ServiceFile.Kind
+}
+
+func (s *fileService) URI() string {
+	return proto.ServiceFile.URI
+}
+
+func (s *fileService) Start() error {
+	return nil
+}
+
+func (s *fileService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *fileService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	rsp, err := s.handleRequest(payload)
+	if err != nil {
+		rsp = &proto.FileResponse{
+			Error:   proto.Error2Type(err),
+			Message: err.Error(),
+		}
+	}
+	data, _ := json.Marshal(rsp)
+	return data, nil
+}
+
+func (s *fileService) handleRequest(payload []byte) (*proto.FileResponse, error) {
+	req := &proto.FileRequest{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal file request error: %s", err.Error())
+	}
+	path, err := s.resolve(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Operation {
+	case proto.FileOperationWrite:
+		return s.write(path, req)
+	case proto.FileOperationRead:
+		return s.read(path, true)
+	case proto.FileOperationChecksum:
+		return s.read(path, false)
+	case proto.FileOperationDelete:
+		return s.delete(path)
+	default:
+		return nil, errors.Wrapf(proto.ErrBadRequest, "unknown file operation: %s", req.Operation)
+	}
+}
+
+// resolve resolves the symlinks of the path, and checks that the file is under one of the allowed paths.
+func (s *fileService) resolve(path string) (string, error) {
+	if len(s.allowedPaths) == 0 {
+		return "", errors.Wrap(proto.ErrNotDefined, "the file service is not enabled")
+	}
+	if !filepath.IsAbs(path) {
+		return "", errors.Wrapf(proto.ErrBadRequest, "the file path should be absolute: %s", path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", errors.Wrapf(proto.ErrFailed, "resolve file path %s error: %s", path, err.Error())
+		}
+		// the file does not exist, resolve its directory instead
+		dir, err1 := filepath.EvalSymlinks(filepath.Dir(path))
+		if err1 != nil {
+			return "", errors.Wrapf(proto.ErrPreconditionFailed, "resolve the directory of file %s error: %s", path, err1.Error())
+		}
+		resolved = filepath.Join(dir, filepath.Base(path))
+	}
+	for _, allowed := range s.allowedPaths {
+		if dir, err1 := filepath.EvalSymlinks(allowed); err1 == nil {
+			allowed = dir
+		}
+		if rel, err1 := filepath.Rel(allowed, resolved); err1 == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return resolved, nil
+		}
+	}
+	return "", errors.Wrapf(proto.ErrBadRequest, "the file path is not allowed: %s", path)
+}
+
+func (s *fileService) acquire(path string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.busy.Has(path) {
+		return errors.Wrapf(proto.ErrBusy, "the file is being written: %s", path)
+	}
+	s.busy.Insert(path)
+	return nil
+}
+
+func (s *fileService) release(path string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.busy.Delete(path)
+}
+
+func (s *fileService) write(path string, req *proto.FileRequest) (*proto.FileResponse, error) {
+	if len(req.Content) > maxFileContentSize {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "the content is too large to write in a request, upload it instead: %d bytes", len(req.Content))
+	}
+	checksum := fileChecksum(req.Content)
+	if len(req.Checksum) > 0 && req.Checksum != checksum {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "the checksum of the content mismatched: expected %s, got %s", req.Checksum, checksum)
+	}
+	mode, err := fileMode(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.acquire(path); err != nil {
+		return nil, err
+	}
+	defer s.release(path)
+
+	if err = atomicWriteFile(path, bytes.NewReader(req.Content), mode); err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "write file %s error: %s", path, err.Error())
+	}
+	s.logger.Info("file written", "path", path, "size", len(req.Content), "checksum", checksum)
+	return &proto.FileResponse{
+		Checksum: checksum,
+		Size:     int64(len(req.Content)),
+	}, nil
+}
+
+func (s *fileService) read(path string, content bool) (*proto.FileResponse, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(proto.ErrPreconditionFailed, "the file does not exist: %s", path)
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "stat file %s error: %s", path, err.Error())
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "not a regular file: %s", path)
+	}
+	if content && info.Size() > maxFileContentSize {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "the file is too large to read in a request: %d bytes", info.Size())
+	}
+
+	// #nosec G304
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "open file %s error: %s", path, err.Error())
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	buf := &bytes.Buffer{}
+	var w io.Writer = hash
+	if content {
+		w = io.MultiWriter(hash, buf)
+	}
+	size, err := io.Copy(w, f)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "read file %s error: %s", path, err.Error())
+	}
+	rsp := &proto.FileResponse{
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+		Size:     size,
+	}
+	if content {
+		rsp.Content = buf.Bytes()
+	}
+	return rsp, nil
+}
+
+func (s *fileService) delete(path string) (*proto.FileResponse, error) {
+	if err := s.acquire(path); err != nil {
+		return nil, err
+	}
+	defer s.release(path)
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &proto.FileResponse{}, nil
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "stat file %s error: %s", path, err.Error())
+	}
+	if info.IsDir() {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "not a regular file: %s", path)
+	}
+	if err = os.Remove(path); err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "delete file %s error: %s", path, err.Error())
+	}
+	if err = syncDir(filepath.Dir(path)); err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "sync the directory of file %s error: %s", path, err.Error())
+	}
+	s.logger.Info("file deleted", "path", path)
+	return &proto.FileResponse{}, nil
+}
+
+// upload receives the content of the file over the streaming connection. The content is appended to a partial file
+// named after the target and the checksum, so that an interrupted upload can be resumed from the bytes received.
+func (s *fileService) upload(conn net.Conn, req *proto.StreamingRequest) error {
+	reply := func(v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = conn.Write(append(data, '\n'))
+		return err
+	}
+
+	upload := req.Upload
+	path, part, offset, err := s.prepareUpload(upload, req.Compression)
+	if err != nil {
+		_ = reply(proto.StreamingResponse{Error: err.Error()})
+		return err
+	}
+	defer s.release(path)
+
+	if err = reply(proto.StreamingResponse{Compression: req.Compression, Offset: offset}); err != nil {
+		return err
+	}
+
+	rsp, err := s.receive(conn, path, part, offset, upload, req.Compression)
+	if err != nil {
+		rsp = &proto.FileResponse{
+			Error:   proto.Error2Type(err),
+			Message: err.Error(),
+		}
+	}
+	if err1 := reply(rsp); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// prepareUpload checks the upload request and returns the target file, the partial file and the offset to resume from.
+func (s *fileService) prepareUpload(upload *proto.FileUpload, compression proto.StreamingCompression) (string, string, int64, error) {
+	if err := checkStreamingCompression(compression); err != nil {
+		return "", "", 0, errors.Wrap(proto.ErrBadRequest, err.Error())
+	}
+	if upload.Size < 0 {
+		return "", "", 0, errors.Wrapf(proto.ErrBadRequest, "invalid upload size: %d", upload.Size)
+	}
+	if _, err := hex.DecodeString(upload.Checksum); err != nil || len(upload.Checksum) != sha256.Size*2 {
+		return "", "", 0, errors.Wrapf(proto.ErrBadRequest, "invalid upload checksum: %s", upload.Checksum)
+	}
+	if _, err := fileMode(upload.Mode); err != nil {
+		return "", "", 0, err
+	}
+	path, err := s.resolve(upload.Path)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if err = s.acquire(path); err != nil {
+		return "", "", 0, err
+	}
+
+	part := uploadPartFile(path, upload.Checksum)
+	removeStaleUploadParts(path, part)
+
+	var offset int64
+	if info, err1 := os.Stat(part); err1 == nil {
+		offset = info.Size()
+	}
+	if offset > upload.Size {
+		_ = os.Remove(part)
+		offset = 0
+	}
+	return path, part, offset, nil
+}
+
+func (s *fileService) receive(conn net.Conn, path, part string, offset int64, upload *proto.FileUpload, compression proto.StreamingCompression) (*proto.FileResponse, error) {
+	reader, err := decompressReader(conn, compression)
+	if err != nil {
+		return nil, errors.Wrap(proto.ErrBadRequest, err.Error())
+	}
+	defer reader.Close()
+
+	// #nosec G304
+	f, err := os.OpenFile(part, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "open the partial file of %s error: %s", path, err.Error())
+	}
+	defer f.Close()
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "seek the partial file of %s error: %s", path, err.Error())
+	}
+
+	received, err := io.CopyN(f, reader, upload.Size-offset)
+	if err != nil {
+		// keep the bytes received to resume the upload
+		_ = f.Sync()
+		return nil, errors.Wrapf(proto.ErrFailed, "receive file %s error at offset %d: %s", path, offset+received, err.Error())
+	}
+
+	hash := sha256.New()
+	// #nosec G304
+	rf, err := os.Open(part)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "open the partial file of %s error: %s", path, err.Error())
+	}
+	_, err = io.Copy(hash, rf)
+	_ = rf.Close()
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "read the partial file of %s error: %s", path, err.Error())
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != upload.Checksum {
+		_ = os.Remove(part)
+		return nil, errors.Wrapf(proto.ErrFailed, "the checksum of the file uploaded mismatched: expected %s, got %s", upload.Checksum, checksum)
+	}
+
+	mode, _ := fileMode(upload.Mode)
+	if err = commitFile(f, path, mode); err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "replace file %s error: %s", path, err.Error())
+	}
+	s.logger.Info("file uploaded", "path", path, "size", upload.Size, "offset", offset, "checksum", upload.Checksum)
+	return &proto.FileResponse{
+		Checksum: upload.Checksum,
+		Size:     upload.Size,
+	}, nil
+}
+
+func uploadPartFile(path, checksum string) string {
+	return filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%s%s", filepath.Base(path), checksum[:16], fileUploadSuffix))
+}
+
+// removeStaleUploadParts removes the partial files of the previous uploads of the file with other checksums.
+func removeStaleUploadParts(path, part string) {
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".*"+fileUploadSuffix))
+	for _, m := range matches {
+		if m != part {
+			_ = os.Remove(m)
+		}
+	}
+}
+
+func fileChecksum(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+func fileMode(mode *int32) (os.FileMode, error) {
+	if mode == nil {
+		return defaultFileMode, nil
+	}
+	if *mode < 0 || *mode > 0777 {
+		return 0, errors.Wrapf(proto.ErrBadRequest, "invalid file mode: %o", *mode)
+	}
+	return os.FileMode(*mode), nil
+}
+
+// atomicWriteFile writes the content to a temporary file in the same directory, and then renames it to the target.
+func atomicWriteFile(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+	defer f.Close()
+
+	if _, err = io.Copy(f, r); err != nil {
+		return err
+	}
+	err = commitFile(f, path, mode)
+	return err
+}
+
+// commitFile flushes the file written and renames it to the target.
+func commitFile(f *os.File, path string, mode os.FileMode) error {
+	if err := f.Chmod(mode); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	// #nosec G304
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
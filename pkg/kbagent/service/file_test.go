@@ -0,0 +1,280 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("file", func() {
+	var (
+		dir string
+		svc *fileService
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		var err error
+		svc, err = newFileService(logr.New(nil), []string{dir})
+		Expect(err).Should(BeNil())
+	})
+
+	request := func(req proto.FileRequest) proto.FileResponse {
+		payload, err := json.Marshal(req)
+		Expect(err).Should(BeNil())
+		data, err := svc.HandleRequest(ctx, payload)
+		Expect(err).Should(BeNil())
+		rsp := proto.FileResponse{}
+		Expect(json.Unmarshal(data, &rsp)).Should(Succeed())
+		return rsp
+	}
+
+	Context("setup", func() {
+		It("requires absolute paths", func() {
+			Expect(SetupFileAllowedPaths([]string{"relative"})).ShouldNot(Succeed())
+			Expect(SetupFileAllowedPaths([]string{"", "/etc/conf/"})).Should(Succeed())
+			Expect(fileAllowedPaths).Should(Equal([]string{"/etc/conf"}))
+			Expect(SetupFileAllowedPaths(nil)).Should(Succeed())
+		})
+
+		It("not enabled", func() {
+			svc, _ = newFileService(logr.New(nil), nil)
+			rsp := request(proto.FileRequest{Operation: proto.FileOperationRead, Path: filepath.Join(dir, "f")})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotDefined)))
+		})
+	})
+
+	Context("request", func() {
+		It("write, read, checksum and delete", func() {
+			path := filepath.Join(dir, "my.cnf")
+			content := []byte("[mysqld]\nport=3306\n")
+
+			rsp := request(proto.FileRequest{Operation: proto.FileOperationWrite, Path: path, Content: content, Mode: ptr.To(int32(0600))})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Checksum).Should(Equal(fileChecksum(content)))
+			Expect(rsp.Size).Should(Equal(int64(len(content))))
+			info, err := os.Stat(path)
+			Expect(err).Should(BeNil())
+			Expect(info.Mode().Perm()).Should(Equal(os.FileMode(0600)))
+
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationRead, Path: path})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Content).Should(Equal(content))
+			Expect(rsp.Checksum).Should(Equal(fileChecksum(content)))
+
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationChecksum, Path: path})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Content).Should(BeEmpty())
+			Expect(rsp.Checksum).Should(Equal(fileChecksum(content)))
+			Expect(checkLocalFileUpToDate(path, rsp.Checksum)).Should(Succeed())
+
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationDelete, Path: path})
+			Expect(rsp.Error).Should(BeEmpty())
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).Should(BeTrue())
+
+			// delete is idempotent
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationDelete, Path: path})
+			Expect(rsp.Error).Should(BeEmpty())
+
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationRead, Path: path})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrPreconditionFailed)))
+		})
+
+		It("replaces the file atomically", func() {
+			path := filepath.Join(dir, "tls.crt")
+			Expect(os.WriteFile(path, []byte("old"), 0644)).Should(Succeed())
+
+			rsp := request(proto.FileRequest{Operation: proto.FileOperationWrite, Path: path, Content: []byte("new"), Checksum: fileChecksum([]byte("other"))})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBadRequest)))
+			Expect(rsp.Message).Should(ContainSubstring("checksum"))
+			Expect(os.ReadFile(path)).Should(Equal([]byte("old")))
+
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationWrite, Path: path, Content: []byte("new"), Checksum: fileChecksum([]byte("new"))})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(os.ReadFile(path)).Should(Equal([]byte("new")))
+
+			entries, err := os.ReadDir(dir)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(1))
+		})
+
+		It("rejects the paths not allowed", func() {
+			other := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(other, "secret"), []byte("secret"), 0644)).Should(Succeed())
+			Expect(os.Symlink(other, filepath.Join(dir, "link"))).Should(Succeed())
+
+			for _, path := range []string{
+				"relative",
+				dir,
+				filepath.Join(other, "secret"),
+				filepath.Join(dir, "..", filepath.Base(other), "secret"),
+				filepath.Join(dir, "link", "secret"),
+			} {
+				rsp := request(proto.FileRequest{Operation: proto.FileOperationRead, Path: path})
+				Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBadRequest)), path)
+			}
+
+			rsp := request(proto.FileRequest{Operation: proto.FileOperationWrite, Path: filepath.Join(dir, "missing", "f")})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrPreconditionFailed)))
+		})
+
+		It("rejects the unknown operations and busy files", func() {
+			path := filepath.Join(dir, "f")
+			rsp := request(proto.FileRequest{Operation: "move", Path: path})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBadRequest)))
+
+			Expect(svc.acquire(path)).Should(Succeed())
+			rsp = request(proto.FileRequest{Operation: proto.FileOperationWrite, Path: path, Content: []byte("data")})
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBusy)))
+			svc.release(path)
+		})
+	})
+
+	Context("upload", func() {
+		var streaming *streamingService
+
+		BeforeEach(func() {
+			actionSvc, err := newActionService(logr.New(nil), nil)
+			Expect(err).Should(BeNil())
+			streaming, err = newStreamingService(logr.New(nil), actionSvc, svc, nil)
+			Expect(err).Should(BeNil())
+		})
+
+		// upload sends the handshake and the content from the offset replied, the content is cut at the limit if set
+		upload := func(req proto.StreamingRequest, content []byte, limit int) (*proto.StreamingResponse, *proto.FileResponse, error) {
+			serverConn, clientConn := net.Pipe()
+			type result struct {
+				negotiated *proto.StreamingResponse
+				rsp        *proto.FileResponse
+			}
+			resultChan := make(chan result, 1)
+			go func() {
+				defer GinkgoRecover()
+				defer clientConn.Close()
+				ret := result{}
+				defer func() { resultChan <- ret }()
+
+				packet, _ := json.Marshal(req)
+				_, err := clientConn.Write(packet)
+				Expect(err).Should(BeNil())
+				reader := bufio.NewReader(clientConn)
+				line, err := reader.ReadBytes('\n')
+				Expect(err).Should(BeNil())
+				ret.negotiated = &proto.StreamingResponse{}
+				Expect(json.Unmarshal(line, ret.negotiated)).Should(Succeed())
+				if len(ret.negotiated.Error) > 0 {
+					return
+				}
+				data := content[ret.negotiated.Offset:]
+				if limit > 0 && limit < len(data) {
+					_, _ = clientConn.Write(data[:limit])
+					return
+				}
+				_, err = clientConn.Write(data)
+				Expect(err).Should(BeNil())
+				line, err = reader.ReadBytes('\n')
+				Expect(err).Should(BeNil())
+				ret.rsp = &proto.FileResponse{}
+				Expect(json.Unmarshal(line, ret.rsp)).Should(Succeed())
+			}()
+			err := streaming.HandleConn(ctx, serverConn)
+			_ = serverConn.Close()
+			ret := <-resultChan
+			return ret.negotiated, ret.rsp, err
+		}
+
+		It("uploads the file", func() {
+			path := filepath.Join(dir, "dump.sql")
+			content := []byte(strings.Repeat("insert into t values (1);\n", 1024))
+			req := proto.StreamingRequest{
+				Upload: &proto.FileUpload{Path: path, Size: int64(len(content)), Checksum: fileChecksum(content)},
+			}
+
+			negotiated, rsp, err := upload(req, content, 0)
+			Expect(err).Should(BeNil())
+			Expect(negotiated.Offset).Should(Equal(int64(0)))
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Size).Should(Equal(int64(len(content))))
+			Expect(os.ReadFile(path)).Should(Equal(content))
+
+			entries, err := os.ReadDir(dir)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(1))
+		})
+
+		It("resumes the interrupted upload", func() {
+			path := filepath.Join(dir, "dump.sql")
+			content := []byte(strings.Repeat("insert into t values (1);\n", 1024))
+			req := proto.StreamingRequest{
+				Upload: &proto.FileUpload{Path: path, Size: int64(len(content)), Checksum: fileChecksum(content)},
+			}
+
+			_, _, err := upload(req, content, 1000)
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).Should(BeTrue())
+
+			negotiated, rsp, err := upload(req, content, 0)
+			Expect(err).Should(BeNil())
+			Expect(negotiated.Offset).Should(Equal(int64(1000)))
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(os.ReadFile(path)).Should(Equal(content))
+		})
+
+		It("rejects the corrupted upload", func() {
+			path := filepath.Join(dir, "dump.sql")
+			content := []byte("data")
+			req := proto.StreamingRequest{
+				Upload: &proto.FileUpload{Path: path, Size: int64(len(content)), Checksum: fileChecksum([]byte("atad"))},
+			}
+
+			_, rsp, err := upload(req, content, 0)
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(rsp.Message).Should(ContainSubstring("checksum"))
+			entries, err := os.ReadDir(dir)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(BeEmpty())
+		})
+
+		It("rejects the invalid upload", func() {
+			req := proto.StreamingRequest{
+				Upload: &proto.FileUpload{Path: filepath.Join(dir, "f"), Size: 4, Checksum: "invalid"},
+			}
+			negotiated, _, err := upload(req, nil, 0)
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+			Expect(negotiated.Error).Should(ContainSubstring("invalid upload checksum"))
+		})
+	})
+})
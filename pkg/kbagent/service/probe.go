@@ -22,6 +22,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net"
 	"os"
 	"path/filepath"
@@ -33,9 +34,10 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	"golang.org/x/time/rate"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/metrics"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
-	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 const (
@@ -88,7 +90,7 @@ func (s *probeService) Start() error {
 		runner := &probeRunner{
 			logger:               s.logger.WithValues("probe", name),
 			actionService:        s.actionService,
-			latestEvent:          make(chan proto.ProbeEvent, 1),
+			latestEvent:          make(chan probeReport, 1),
 			sendEventWithMessage: s.sendEventWithMessage,
 		}
 		go runner.run(s.probes[name])
@@ -112,10 +114,15 @@ type probeRunner struct {
 	succeedCount         int64
 	failedCount          int64
 	latestOutput         []byte
-	latestEvent          chan proto.ProbeEvent
+	latestEvent          chan probeReport
 	sendEventWithMessage func(logger *logr.Logger, reason string, message string, sync bool) error
 }
 
+type probeReport struct {
+	event proto.ProbeEvent
+	force bool // the forced report is neither coalesced nor rate limited
+}
+
 type fileChangeWatch struct {
 	path string
 	dir  bool
@@ -138,7 +145,7 @@ func (r *probeRunner) launchProbeLoop(probe *proto.Probe, forceProbe <-chan stru
 	if probe.PeriodSeconds <= 0 {
 		probe.PeriodSeconds = defaultProbePeriodSeconds
 	}
-	r.ticker = time.NewTicker(time.Duration(probe.PeriodSeconds) * time.Second)
+	r.ticker = time.NewTicker(r.period(probe))
 	defer r.ticker.Stop()
 
 	r.probeLoop(probe, forceProbe)
@@ -146,7 +153,8 @@ func (r *probeRunner) launchProbeLoop(probe *proto.Probe, forceProbe <-chan stru
 
 func (r *probeRunner) probeLoop(probe *proto.Probe, forceProbe <-chan struct{}) {
 	once := func(forceReport bool) {
-		output, err := r.actionService.handleRequest(context.Background(), &proto.ActionRequest{Action: probe.Action})
+		start := time.Now()
+		output, err := r.actionService.handleInternalRequest(context.Background(), &proto.ActionRequest{Action: probe.Action})
 		if err == nil {
 			r.succeedCount++
 			r.failedCount = 0
@@ -154,12 +162,15 @@ func (r *probeRunner) probeLoop(probe *proto.Probe, forceProbe <-chan struct{})
 			r.succeedCount = 0
 			r.failedCount++
 		}
+		metrics.ProbeObserved(probe.Action, time.Since(start), err, r.succeedCount, r.failedCount)
 
 		r.report(probe, output, err, forceReport)
 
 		if succeed, _ := r.succeed(probe); succeed && !reflect.DeepEqual(output, r.latestOutput) {
 			r.latestOutput = output
 		}
+
+		r.ticker.Reset(r.period(probe))
 	}
 
 	// initial run
@@ -193,15 +204,35 @@ func (r *probeRunner) report(probe *proto.Probe, output []byte, err error, force
 	}
 
 	if latestEvent != nil {
+		report := probeReport{event: *latestEvent, force: forceReport}
 		select {
-		case r.latestEvent <- *latestEvent:
+		case r.latestEvent <- report:
 		default:
 			gather(r.latestEvent) // drain the channel
-			r.latestEvent <- *latestEvent
+			r.latestEvent <- report
 		}
 	}
 }
 
+// period returns the duration to the next probe, it is backed off exponentially once the failure threshold is reached,
+// and randomized by the jitter.
+func (r *probeRunner) period(probe *proto.Probe) time.Duration {
+	period := time.Duration(probe.PeriodSeconds) * time.Second
+	if probe.MaxBackoffSeconds > probe.PeriodSeconds && r.fail(probe) {
+		maxBackoff := time.Duration(probe.MaxBackoffSeconds) * time.Second
+		failureThreshold := max(probe.FailureThreshold, 1)
+		for i := int64(failureThreshold); i <= r.failedCount && period < maxBackoff; i++ {
+			period *= 2
+		}
+		period = min(period, maxBackoff)
+	}
+	if probe.JitterPercent > 0 {
+		jitter := float64(period) * float64(min(probe.JitterPercent, 100)) / 100
+		period += time.Duration(jitter * (2*rand.Float64() - 1))
+	}
+	return max(period, time.Millisecond)
+}
+
 func (r *probeRunner) succeed(probe *proto.Probe) (bool, bool) {
 	if r.succeedCount > 0 {
 		successThreshold := probe.SuccessThreshold
@@ -366,6 +397,10 @@ func (r *probeRunner) buildEvent(instance, probe string, code int32, output []by
 	}
 }
 
+func sameProbeEvent(e1, e2 *proto.ProbeEvent) bool {
+	return e1.Code == e2.Code && e1.Message == e2.Message && reflect.DeepEqual(e1.Output, e2.Output)
+}
+
 func (r *probeRunner) launchReportLoop(probe *proto.Probe) {
 	go func() {
 		var reportChan <-chan time.Time
@@ -381,6 +416,13 @@ func (r *probeRunner) launchReportLoop(probe *proto.Probe) {
 		retryTicker := time.NewTicker(retrySendEventInterval)
 		defer retryTicker.Stop()
 
+		var limiter *rate.Limiter
+		if probe.MaxEventsPerMinute > 0 {
+			limiter = rate.NewLimiter(rate.Limit(float64(probe.MaxEventsPerMinute)/60), int(probe.MaxEventsPerMinute))
+		}
+		// to send the event delayed by the rate limit
+		var delayChan <-chan time.Time
+
 		var event proto.ProbeEvent
 		var hasEvent bool
 
@@ -399,19 +441,42 @@ func (r *probeRunner) launchReportLoop(probe *proto.Probe) {
 			}
 		}
 
-		trySend := func(retry, periodically bool) bool {
+		allow := func() bool {
+			if limiter == nil {
+				return true
+			}
+			reservation := limiter.Reserve()
+			delay := reservation.Delay()
+			if delay == 0 {
+				return true
+			}
+			reservation.Cancel()
+			if delayChan == nil {
+				delayChan = time.After(delay)
+			}
+			r.logger.Info("the probe event is rate limited, will send it later", "probe", probe.Action, "delay", delay.String())
+			return false
+		}
+
+		trySend := func(retry, periodically, force bool) bool {
 			if !hasEvent {
 				return true
 			}
+			if !force && !allow() {
+				return false
+			}
 
-			msg, err := marshalEventWithSizeLimit(&event, &event.Message, &event.Output)
+			sending := event // the event may be truncated when marshaling
+			msg, err := marshalReport(&sending, &sending.Message, &sending.Output)
 			if err != nil {
 				log(err, "failed to marshal the probe event", retry, periodically)
 				return true
 			}
 
 			if r.sendEventWithMessage == nil {
-				r.sendEventWithMessage = util.SendEventWithMessage
+				r.sendEventWithMessage = func(logger *logr.Logger, reason string, message string, sync bool) error {
+					return sendReport(logger, reason, reason, message, sync)
+				}
 			}
 			err = r.sendEventWithMessage(&r.logger, event.Probe, string(msg), true)
 			if err == nil {
@@ -426,16 +491,29 @@ func (r *probeRunner) launchReportLoop(probe *proto.Probe) {
 		for {
 			select {
 			case latest := <-r.latestEvent:
-				event = latest
+				if !latest.force && hasEvent && sameProbeEvent(&event, &latest.event) {
+					// coalesce the identical events, the result is not changed, it will be sent with the count periodically,
+					// or on retry if the previous send is failed
+					event.Count++
+					continue
+				}
+				event = latest.event
+				event.Count = 1
 				hasEvent = true
-				needsRetry = !trySend(false, false)
+				needsRetry = !trySend(false, false, latest.force)
 
 			case <-reportChan:
-				needsRetry = !trySend(false, true)
+				needsRetry = !trySend(false, true, false)
+
+			case <-delayChan:
+				delayChan = nil
+				if needsRetry {
+					needsRetry = !trySend(true, false, false)
+				}
 
 			case <-retryTicker.C:
 				if needsRetry {
-					needsRetry = !trySend(true, false)
+					needsRetry = !trySend(true, false, false)
 				}
 			}
 		}
@@ -284,6 +284,103 @@ var _ = Describe("probe", func() {
 			Eventually(event.Output).Should(Equal([]byte("leader")))
 		})
 
+		It("backs off and jitters the probe period", func() {
+			probe := &proto.Probe{
+				Action:            probeName,
+				PeriodSeconds:     1,
+				FailureThreshold:  2,
+				MaxBackoffSeconds: 5,
+			}
+			r := &probeRunner{}
+			Expect(r.period(probe)).Should(Equal(time.Second))
+
+			r.failedCount = 1
+			Expect(r.period(probe)).Should(Equal(time.Second))
+			r.failedCount = 2
+			Expect(r.period(probe)).Should(Equal(2 * time.Second))
+			r.failedCount = 3
+			Expect(r.period(probe)).Should(Equal(4 * time.Second))
+			r.failedCount = 10
+			Expect(r.period(probe)).Should(Equal(5 * time.Second))
+
+			r.failedCount = 0
+			probe.JitterPercent = 20
+			for i := 0; i < 100; i++ {
+				period := r.period(probe)
+				Expect(period).Should(BeNumerically(">=", 800*time.Millisecond))
+				Expect(period).Should(BeNumerically("<=", 1200*time.Millisecond))
+			}
+		})
+
+		It("coalesces the identical events", func() {
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: probeName,
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n failed >&2; exit 1"},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+			probesWithReport := append([]proto.Probe(nil), probes...)
+			probesWithReport[0].ReportPeriodSeconds = 4
+			service, err := newProbeService(logr.New(nil), actionSvc, probesWithReport)
+			Expect(err).Should(BeNil())
+
+			events := make(chan proto.ProbeEvent, 128)
+			service.sendEventWithMessage = func(_ *logr.Logger, reason string, message string, _ bool) error {
+				var event proto.ProbeEvent
+				Expect(json.Unmarshal([]byte(message), &event)).Should(Succeed())
+				events <- event
+				return nil
+			}
+			retrySendEventInterval = 1 * time.Second
+			defer func() { retrySendEventInterval = defaultRetrySendEventInterval }()
+
+			Expect(service.Start()).Should(Succeed())
+
+			var event proto.ProbeEvent
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Code).Should(Equal(int32(-1)))
+			Expect(event.Count).Should(Equal(int32(1)))
+
+			// the identical events are not re-sent on retry
+			Consistently(events, 2*retrySendEventInterval).ShouldNot(Receive())
+
+			// the failures in the report period are sent as one event
+			Eventually(events, 4*time.Second).Should(Receive(&event))
+			Expect(event.Code).Should(Equal(int32(-1)))
+			Expect(event.Count).Should(BeNumerically(">", 1))
+		})
+
+		It("rate limits the events", func() {
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: probeName,
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "date +%s%N"},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+			probesWithLimit := append([]proto.Probe(nil), probes...)
+			probesWithLimit[0].MaxEventsPerMinute = 1
+			service, err := newProbeService(logr.New(nil), actionSvc, probesWithLimit)
+			Expect(err).Should(BeNil())
+
+			events := make(chan string, 128)
+			service.sendEventWithMessage = func(_ *logr.Logger, reason string, message string, _ bool) error {
+				events <- message
+				return nil
+			}
+
+			Expect(service.Start()).Should(Succeed())
+
+			Eventually(events).Should(Receive())
+			// the output changes on each probe, but the events are limited
+			Consistently(events, 3*time.Second).ShouldNot(Receive())
+		})
+
 		// TODO: more test cases
 	})
 
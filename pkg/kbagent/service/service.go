@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 type Service interface {
@@ -44,22 +45,45 @@ func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, strea
 	if err != nil {
 		return nil, err
 	}
+	sao, err := newActionOperationService(logger, sa)
+	if err != nil {
+		return nil, err
+	}
+	sab, err := newActionBatchService(logger, sa)
+	if err != nil {
+		return nil, err
+	}
 	sp, err := newProbeService(logger, sa, probes)
 	if err != nil {
 		return nil, err
 	}
-	ss, err := newStreamingService(logger, sa, streaming)
+	sf, err := newFileService(logger, fileAllowedPaths)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := newStreamingService(logger, sa, sf, streaming)
 	if err != nil {
 		return nil, err
 	}
-	return []Service{sa, sp, ss}, nil
+	sau, err := newAuditService(logger, sa.audit)
+	if err != nil {
+		return nil, err
+	}
+	return []Service{sa, sao, sab, sp, sf, ss, sau}, nil
 }
 
-func RunTasks(logger logr.Logger, service Service, tasks []proto.Task) error {
+// RunTasks runs the tasks one by one, the state of tasks is kept in the journal directory if specified.
+func RunTasks(logger logr.Logger, service Service, tasks []proto.Task, cred *util.Credential, journalDir string) error {
+	journal, err := newTaskJournal(journalDir)
+	if err != nil {
+		return err
+	}
 	st := &taskService{
 		logger:        logger,
 		actionService: service.(*actionService),
 		tasks:         tasks,
+		cred:          cred,
+		journal:       journal,
 	}
 	return st.runTasks(context.Background())
 }
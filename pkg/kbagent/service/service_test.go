@@ -33,10 +33,14 @@ var _ = Describe("service", func() {
 		It("empty", func() {
 			services, err := New(logr.New(nil), nil, nil, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(7))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
+			Expect(services[4]).ShouldNot(BeNil())
+			Expect(services[5]).ShouldNot(BeNil())
+			Expect(services[6]).ShouldNot(BeNil())
 		})
 
 		It("action", func() {
@@ -47,10 +51,14 @@ var _ = Describe("service", func() {
 			}
 			services, err := New(logr.New(nil), actions, nil, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(7))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
+			Expect(services[4]).ShouldNot(BeNil())
+			Expect(services[5]).ShouldNot(BeNil())
+			Expect(services[6]).ShouldNot(BeNil())
 		})
 
 		It("probe", func() {
@@ -66,10 +74,14 @@ var _ = Describe("service", func() {
 			}
 			services, err := New(logr.New(nil), actions, probes, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(7))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
+			Expect(services[4]).ShouldNot(BeNil())
+			Expect(services[5]).ShouldNot(BeNil())
+			Expect(services[6]).ShouldNot(BeNil())
 		})
 
 		It("streaming", func() {
@@ -83,10 +95,14 @@ var _ = Describe("service", func() {
 			}
 			services, err := New(logr.New(nil), actions, nil, streamingActions)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(7))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
+			Expect(services[4]).ShouldNot(BeNil())
+			Expect(services[5]).ShouldNot(BeNil())
+			Expect(services[6]).ShouldNot(BeNil())
 		})
 
 		It("probe which has no action", func() {
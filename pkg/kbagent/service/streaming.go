@@ -23,8 +23,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -35,12 +37,15 @@ import (
 
 const (
 	maxStreamingHandshakePacketSize = 4096
+	streamingNegotiateTimeout       = 30 * time.Second
 )
 
-func newStreamingService(logger logr.Logger, actionService *actionService, streamingActions []string) (*streamingService, error) {
+func newStreamingService(logger logr.Logger, actionService *actionService, fileService *fileService, streamingActions []string) (*streamingService, error) {
 	ss := &streamingService{
 		logger:           logger,
 		streamingActions: make(map[string]*proto.Action),
+		audit:            actionService.audit,
+		file:             fileService,
 	}
 	for _, a := range streamingActions {
 		if _, ok := actionService.actions[a]; !ok {
@@ -56,6 +61,8 @@ func newStreamingService(logger logr.Logger, actionService *actionService, strea
 type streamingService struct {
 	logger           logr.Logger
 	streamingActions map[string]*proto.Action
+	audit            *auditLog
+	file             *fileService
 }
 
 var _ Service = &streamingService{}
@@ -78,30 +85,91 @@ func (s *streamingService) HandleConn(ctx context.Context, conn net.Conn) error
 		return err
 	}
 
+	if req.Upload != nil {
+		if s.file == nil {
+			return errors.Wrap(proto.ErrNotImplemented, "file upload is not supported")
+		}
+		return s.file.upload(conn, req)
+	}
+
 	action, ok := s.streamingActions[req.Action]
 	if !ok {
 		return fmt.Errorf("%s is not supported", req.Action)
 	}
 
-	return s.streaming(ctx, conn, action, req)
+	record := proto.AuditRecord{
+		Action:     req.Action,
+		Source:     s.Kind(),
+		Parameters: req.Parameters,
+		StartTime:  time.Now(),
+	}
+	counter := &countingWriter{}
+	err = s.streaming(ctx, conn, action, req, counter)
+	record.OutputSize = counter.n
+	s.audit.record(record, err)
+	return err
 }
 
 func (s *streamingService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
 	return nil, errors.Wrapf(proto.ErrNotImplemented, "service %s does not support request handling", s.Kind())
 }
 
-func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto.ActionRequest, error) {
-	// reuse the action request as the handshake packet, define a new one when needed
-	req := &proto.ActionRequest{}
+func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto.StreamingRequest, error) {
+	req := &proto.StreamingRequest{}
 	decoder := json.NewDecoder(conn)
 	if err := decoder.Decode(req); err != nil {
 		return nil, errors.Wrapf(proto.ErrBadRequest, "read and unmarshal action request error: %s", err.Error())
 	}
+	// the upload is replied with the offset to resume from by the file service
+	if req.Upload == nil && req.Negotiable() {
+		if err := s.negotiate(conn, req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
-func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action *proto.Action, req *proto.ActionRequest) error {
-	errChan, err1 := nonBlockingCallActionX(ctx, action, req.Parameters, nil, &action.TimeoutSeconds, nil, conn, nil)
+// negotiate replies the streaming options accepted, the data is sent after the response.
+func (s *streamingService) negotiate(conn net.Conn, req *proto.StreamingRequest) error {
+	rsp := proto.StreamingResponse{
+		Compression: req.Compression,
+		Offset:      req.Offset,
+	}
+	err := checkStreamingCompression(req.Compression)
+	if err == nil && req.Offset < 0 {
+		err = fmt.Errorf("invalid streaming offset: %d", req.Offset)
+	}
+	if err != nil {
+		rsp = proto.StreamingResponse{Error: err.Error()}
+	}
+	data, err1 := json.Marshal(rsp)
+	if err1 != nil {
+		return err1
+	}
+	if _, err1 = conn.Write(append(data, '\n')); err1 != nil {
+		return err1
+	}
+	if err != nil {
+		return errors.Wrap(proto.ErrBadRequest, err.Error())
+	}
+	return nil
+}
+
+// streaming streams the output of the action to the connection, the size of the output is counted by the counter.
+func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action *proto.Action, req *proto.StreamingRequest, counter *countingWriter) error {
+	writer, err := compressWriter(conn, req.Compression)
+	if err != nil {
+		return err
+	}
+	var stdout io.Writer = writer
+	if req.Offset > 0 {
+		stdout = &skippingWriter{writer: writer, skip: req.Offset}
+	}
+	if counter != nil {
+		stdout = io.MultiWriter(counter, stdout)
+	}
+
+	errChan, err1 := nonBlockingCallActionX(ctx, action, req.Parameters, nil, &action.TimeoutSeconds, nil, stdout, nil)
 	if err1 != nil {
 		return err1
 	}
@@ -109,5 +177,9 @@ func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action
 	if !ok {
 		err2 = errors.New("runtime error: error chan closed unexpectedly")
 	}
-	return err2
+	if err2 != nil {
+		return err2
+	}
+	// flush the compressed data
+	return writer.Close()
 }
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"encoding/json"
+	"io"
 	"net"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -39,7 +41,7 @@ var _ = Describe("streaming", func() {
 				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "cat"}},
 			}})
 			Expect(err).Should(BeNil())
-			svc, err := newStreamingService(logr.New(nil), actionSvc, []string{"dump"})
+			svc, err := newStreamingService(logr.New(nil), actionSvc, nil, []string{"dump"})
 			Expect(err).Should(BeNil())
 
 			Expect(svc.Kind()).Should(Equal(proto.ServiceStreaming.Kind))
@@ -56,7 +58,7 @@ var _ = Describe("streaming", func() {
 				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "cat"}},
 			}})
 			Expect(err).Should(BeNil())
-			svc, err := newStreamingService(logr.New(nil), actionSvc, []string{"dump"})
+			svc, err := newStreamingService(logr.New(nil), actionSvc, nil, []string{"dump"})
 			Expect(err).Should(BeNil())
 
 			serverConn, clientConn := net.Pipe()
@@ -85,13 +87,40 @@ var _ = Describe("streaming", func() {
 			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
 		})
 
+		It("replies the streaming options negotiated", func() {
+			svc := &streamingService{}
+			negotiate := func(packet string) (*proto.StreamingResponse, error) {
+				serverConn, clientConn := net.Pipe()
+				defer clientConn.Close()
+				rspChan := make(chan *proto.StreamingResponse, 1)
+				go func() {
+					defer GinkgoRecover()
+					_, _ = clientConn.Write([]byte(packet))
+					rsp := &proto.StreamingResponse{}
+					Expect(json.NewDecoder(clientConn).Decode(rsp)).Should(Succeed())
+					rspChan <- rsp
+				}()
+				_, err := svc.handshake(ctx, serverConn)
+				return <-rspChan, err
+			}
+
+			rsp, err := negotiate(`{"action":"dump","compression":"zstd","offset":10}`)
+			Expect(err).Should(BeNil())
+			Expect(rsp.Compression).Should(Equal(proto.StreamingCompressionZstd))
+			Expect(rsp.Offset).Should(Equal(int64(10)))
+
+			rsp, err = negotiate(`{"action":"dump","compression":"lz4"}`)
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+			Expect(rsp.Error).Should(ContainSubstring("unsupported streaming compression"))
+		})
+
 		It("handles supported streaming actions", func() {
 			actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
 				Name: "dump",
 				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "true"}},
 			}})
 			Expect(err).Should(BeNil())
-			svc, err := newStreamingService(logr.New(nil), actionSvc, []string{"dump"})
+			svc, err := newStreamingService(logr.New(nil), actionSvc, nil, []string{"dump"})
 			Expect(err).Should(BeNil())
 
 			serverConn, clientConn := net.Pipe()
@@ -104,5 +133,34 @@ var _ = Describe("streaming", func() {
 			Expect(svc.HandleConn(ctx, serverConn)).Should(Succeed())
 			Expect(serverConn.Close()).Should(Succeed())
 		})
+
+		It("records the streaming actions in the audit log", func() {
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+				Name: "dump",
+				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo -n data"}},
+			}})
+			Expect(err).Should(BeNil())
+			actionSvc.audit, err = newAuditLog("", 4)
+			Expect(err).Should(BeNil())
+			svc, err := newStreamingService(logr.New(nil), actionSvc, nil, []string{"dump"})
+			Expect(err).Should(BeNil())
+
+			serverConn, clientConn := net.Pipe()
+			defer clientConn.Close()
+			go func() {
+				defer GinkgoRecover()
+				_, _ = clientConn.Write([]byte(`{"action":"dump","parameters":{"KB_TOKEN":"secret"}}`))
+				_, _ = io.ReadAll(clientConn)
+			}()
+
+			Expect(svc.HandleConn(ctx, serverConn)).Should(Succeed())
+			Expect(serverConn.Close()).Should(Succeed())
+
+			records := actionSvc.audit.query(&proto.AuditRequest{Action: "dump"})
+			Expect(records).Should(HaveLen(1))
+			Expect(records[0].Source).Should(Equal(proto.ServiceStreaming.Kind))
+			Expect(records[0].OutputSize).Should(Equal(int64(4)))
+			Expect(records[0].Parameters).Should(HaveKeyWithValue("KB_TOKEN", auditRedactedValue))
+		})
 	})
 })
@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/time/rate"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	maxThrottledReadSize = 1024 * 1024
+)
+
+func checkStreamingCompression(compression proto.StreamingCompression) error {
+	switch compression {
+	case proto.StreamingCompressionNone, proto.StreamingCompressionGzip, proto.StreamingCompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported streaming compression: %s", compression)
+	}
+}
+
+// compressWriter wraps the writer to compress the data written, the returned writer should be closed to flush the data.
+func compressWriter(w io.Writer, compression proto.StreamingCompression) (io.WriteCloser, error) {
+	switch compression {
+	case proto.StreamingCompressionNone:
+		return nopWriteCloser{w}, nil
+	case proto.StreamingCompressionGzip:
+		return gzip.NewWriter(w), nil
+	case proto.StreamingCompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, checkStreamingCompression(compression)
+	}
+}
+
+// decompressReader wraps the reader to decompress the data read.
+func decompressReader(r io.Reader, compression proto.StreamingCompression) (io.ReadCloser, error) {
+	switch compression {
+	case proto.StreamingCompressionNone:
+		return io.NopCloser(r), nil
+	case proto.StreamingCompressionGzip:
+		return gzip.NewReader(r)
+	case proto.StreamingCompressionZstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, checkStreamingCompression(compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// throttledReader limits the rate of bytes read from the underlying reader.
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func newThrottledReader(ctx context.Context, r io.Reader, bytesPerSecond int64) io.Reader {
+	if bytesPerSecond <= 0 {
+		return r
+	}
+	burst := int(min(bytesPerSecond, maxThrottledReadSize))
+	return &throttledReader{
+		ctx:     ctx,
+		reader:  r,
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+	}
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if err1 := r.limiter.WaitN(r.ctx, n); err1 != nil {
+			return n, err1
+		}
+	}
+	return n, err
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	reader io.Reader
+	count  *atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
+
+// skippingWriter discards the first bytes written, it is used to resume the streaming from an offset.
+type skippingWriter struct {
+	writer io.Writer
+	skip   int64
+}
+
+func (w *skippingWriter) Write(p []byte) (int, error) {
+	if w.skip >= int64(len(p)) {
+		w.skip -= int64(len(p))
+		return len(p), nil
+	}
+	skipped := int(w.skip)
+	w.skip = 0
+	n, err := w.writer.Write(p[skipped:])
+	return skipped + n, err
+}
+
+// countingWriter counts the bytes written and discards them.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
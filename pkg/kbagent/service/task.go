@@ -30,14 +30,21 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/metrics"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
+var (
+	taskCheckpointPeriod = 10 * time.Second
+)
+
 type taskService struct {
 	logger        logr.Logger
 	actionService *actionService
 	tasks         []proto.Task
+	cred          *util.Credential
+	journal       *taskJournal
 }
 
 type task interface {
@@ -45,6 +52,14 @@ type task interface {
 	status(ctx context.Context, event *proto.TaskEvent)
 }
 
+// resumableTask is a task that can continue from its checkpoint after the kb-agent restarts.
+type resumableTask interface {
+	task
+	resumable() bool
+	checkpoint() map[string]string
+	resume(ctx context.Context, checkpoint map[string]string) (chan error, error)
+}
+
 func (s *taskService) runTasks(ctx context.Context) error {
 	for _, task := range s.tasks {
 		// run tasks one by one
@@ -61,84 +76,220 @@ func (s *taskService) runTasks(ctx context.Context) error {
 }
 
 func (s *taskService) runTask(ctx context.Context, task proto.Task) error {
-	t := s.newTask(task)
+	entry, err := s.journal.load(task.UID)
+	if err != nil {
+		return err
+	}
+
+	t, err := s.newTask(task)
+	if err != nil {
+		if entry == nil {
+			entry = &taskJournalEntry{UID: task.UID, Task: task.Task, StartTime: time.Now()}
+		}
+		s.finish(entry, err)
+		return s.recover(task, entry, err)
+	}
 	if t == nil {
+		s.logger.Info(fmt.Sprintf("unknown kind of task, skip it: %v", task))
 		return nil
 	}
 
+	if entry != nil && entry.finished() {
+		// the task has been finished before the restart, don't run it again
+		return s.recover(task, entry, entry.error())
+	}
+
+	var resuming resumableTask
+	if entry != nil && entry.State == taskStateRunning {
+		// the task was interrupted by the restart
+		rt, ok := t.(resumableTask)
+		if !ok || !rt.resumable() {
+			err = fmt.Errorf("task is interrupted by the restart of kb-agent, attempt: %d", entry.Attempts)
+			s.finish(entry, err)
+			return s.recover(task, entry, err)
+		}
+		if len(entry.Checkpoint) > 0 {
+			resuming = rt
+			s.logger.Info(fmt.Sprintf("resume the task from checkpoint: %v, attempt: %d", task, entry.Attempts))
+		}
+	}
+	if resuming == nil {
+		attempts := int32(1)
+		if entry != nil {
+			attempts = entry.Attempts + 1
+		}
+		entry = &taskJournalEntry{
+			UID:       task.UID,
+			Task:      task.Task,
+			State:     taskStateRunning,
+			Attempts:  attempts,
+			StartTime: time.Now(),
+		}
+	}
+	if err = s.journal.save(entry); err != nil {
+		return err
+	}
+
 	event := proto.TaskEvent{
 		Instance:  task.Instance,
 		Task:      task.Task,
 		UID:       task.UID,
 		Replica:   util.PodName(),
-		StartTime: time.Now(),
+		StartTime: entry.StartTime,
 	}
 
+	done := metrics.TaskStarted(task.Task)
+
 	notify := func(err error, exit, exited chan struct{}) error {
+		done(err)
 		if exit != nil && exited != nil {
 			close(exit)
 			<-exited
 		}
-		if task.NotifyAtFinish {
-			event.EndTime = time.Now()
-			if err == nil {
-				event.Code = 0
-			} else {
-				event.Code = -1
-				event.Message = err.Error()
-			}
-			err1 := s.notify(task, event, true)
-			if err == nil { // the run error takes precedence
-				err = err1
-			}
-		}
-		return err
+		s.finish(entry, err)
+		return s.notifyFinished(task, entry, event, err)
 	}
 
-	ch, err1 := t.run(ctx)
-	if err1 != nil {
-		return notify(err1, nil, nil)
+	var ch chan error
+	if resuming != nil {
+		ch, err = resuming.resume(ctx, entry.Checkpoint)
+	} else {
+		ch, err = t.run(ctx)
+	}
+	if err != nil {
+		return notify(err, nil, nil)
 	}
 
-	exit, exited := s.report(ctx, task, t, event)
+	exit, exited := s.report(ctx, task, t, event, entry)
 
 	return notify(s.wait(ch), exit, exited)
 }
 
-func (s *taskService) newTask(task proto.Task) task {
-	if task.NewReplica != nil {
-		return &newReplicaTask{
-			logger:        s.logger,
-			actionService: s.actionService,
-			task:          task.NewReplica,
+// recover handles the task that has been finished in the journal, the final event is re-sent if it is missed.
+func (s *taskService) recover(task proto.Task, entry *taskJournalEntry, err error) error {
+	s.logger.Info(fmt.Sprintf("task has been finished, skip it: %v, state: %s", task, entry.State))
+	if entry.Notified {
+		return err
+	}
+	event := proto.TaskEvent{
+		Instance:  task.Instance,
+		Task:      task.Task,
+		UID:       task.UID,
+		Replica:   util.PodName(),
+		StartTime: entry.StartTime,
+	}
+	return s.notifyFinished(task, entry, event, err)
+}
+
+func (s *taskService) finish(entry *taskJournalEntry, err error) {
+	entry.EndTime = time.Now()
+	if err == nil {
+		entry.State = taskStateSucceeded
+		entry.Message = ""
+	} else {
+		entry.State = taskStateFailed
+		entry.Message = err.Error()
+	}
+	if err1 := s.journal.save(entry); err1 != nil {
+		s.logger.Error(err1, fmt.Sprintf("failed to save the task journal, task: %s", entry.UID))
+	}
+}
+
+func (s *taskService) notifyFinished(task proto.Task, entry *taskJournalEntry, event proto.TaskEvent, err error) error {
+	if !task.NotifyAtFinish {
+		return err
+	}
+	event.EndTime = entry.EndTime
+	if err == nil {
+		event.Code = 0
+	} else {
+		event.Code = -1
+		event.Message = err.Error()
+	}
+	err1 := s.notify(task, event, true)
+	if err1 == nil {
+		entry.Notified = true
+		if err2 := s.journal.save(entry); err2 != nil {
+			s.logger.Error(err2, fmt.Sprintf("failed to save the task journal, task: %s", entry.UID))
 		}
 	}
-	return nil
+	if err == nil { // the run error takes precedence
+		err = err1
+	}
+	return err
 }
 
-func (s *taskService) report(ctx context.Context, task proto.Task, t task, event proto.TaskEvent) (chan struct{}, chan struct{}) {
+// newTask creates the task by its kind, it returns nil if the kind is unknown.
+func (s *taskService) newTask(task proto.Task) (task, error) {
+	kind := lookupTaskKind(task)
+	if kind == nil {
+		return nil, nil
+	}
+	return kind.new(s, task)
+}
+
+func (s *taskService) report(ctx context.Context, task proto.Task, t task, event proto.TaskEvent, entry *taskJournalEntry) (chan struct{}, chan struct{}) {
+	var reportTicker, checkpointTicker *time.Ticker
 	if task.ReportPeriodSeconds > 0 {
-		exit, exited := make(chan struct{}), make(chan struct{})
-		go func() {
-			defer close(exited)
-			ticker := time.NewTicker(time.Duration(task.ReportPeriodSeconds) * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-exit:
-					return
-				case <-ticker.C:
-					eventCopy := event
-					t.status(ctx, &event)
-					if !reflect.DeepEqual(event, eventCopy) {
-						_ = s.notify(task, event, false)
-					}
+		reportTicker = time.NewTicker(time.Duration(task.ReportPeriodSeconds) * time.Second)
+	}
+	// the checkpoint doesn't depend on the report, a resumable task is checkpointed even if it is not reported
+	if rt, ok := t.(resumableTask); ok && rt.resumable() && s.journal.enabled() {
+		checkpointTicker = time.NewTicker(taskCheckpointPeriod)
+	}
+	if reportTicker == nil && checkpointTicker == nil {
+		return nil, nil
+	}
+
+	tickerC := func(ticker *time.Ticker) <-chan time.Time {
+		if ticker == nil {
+			return nil // blocks forever
+		}
+		return ticker.C
+	}
+	exit, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		for _, ticker := range []*time.Ticker{reportTicker, checkpointTicker} {
+			if ticker != nil {
+				defer ticker.Stop()
+			}
+		}
+		for {
+			select {
+			case <-exit:
+				return
+			case <-tickerC(checkpointTicker):
+				s.checkpoint(t, event, entry)
+			case <-tickerC(reportTicker):
+				eventCopy := event
+				t.status(ctx, &event)
+				s.checkpoint(t, event, entry)
+				if !reflect.DeepEqual(event, eventCopy) {
+					_ = s.notify(task, event, false)
 				}
 			}
-		}()
-		return exit, exited
+		}
+	}()
+	return exit, exited
+}
+
+// checkpoint records the progress and checkpoint of the running task into the journal.
+func (s *taskService) checkpoint(t task, event proto.TaskEvent, entry *taskJournalEntry) {
+	if !s.journal.enabled() {
+		return
+	}
+	progress, checkpoint := event.Message, entry.Checkpoint
+	if rt, ok := t.(resumableTask); ok {
+		checkpoint = rt.checkpoint()
+	}
+	if progress == entry.Progress && reflect.DeepEqual(checkpoint, entry.Checkpoint) {
+		return
+	}
+	entry.Progress, entry.Checkpoint = progress, checkpoint
+	if err := s.journal.save(entry); err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to save the task journal, task: %s", entry.UID))
 	}
-	return nil, nil
 }
 
 func (s *taskService) wait(ch chan error) error {
@@ -153,9 +304,9 @@ func (s *taskService) wait(ch chan error) error {
 }
 
 func (s *taskService) notify(task proto.Task, event proto.TaskEvent, sync bool) error {
-	msg, err := marshalEventWithSizeLimit(&event, &event.Message, &event.Output)
+	msg, err := marshalReport(&event, &event.Message, &event.Output)
 	if err == nil {
-		return util.SendEventWithMessage(&s.logger, "task", string(msg), sync)
+		return sendReport(&s.logger, "task."+event.UID, "task", string(msg), sync)
 	} else {
 		s.logger.Error(err, fmt.Sprintf("failed to marshal task event, task: %v", task))
 		return err
@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type taskState string
+
+const (
+	taskStateRunning   taskState = "Running"
+	taskStateSucceeded taskState = "Succeeded"
+	taskStateFailed    taskState = "Failed"
+
+	taskJournalFileMode = 0600
+)
+
+var taskJournalUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// taskJournalEntry is the persisted state of a task.
+type taskJournalEntry struct {
+	UID        string            `json:"UID"`
+	Task       string            `json:"task"`
+	State      taskState         `json:"state"`
+	Attempts   int32             `json:"attempts"`
+	StartTime  time.Time         `json:"startTime"`
+	EndTime    time.Time         `json:"endTime"`
+	Progress   string            `json:"progress,omitempty"`
+	Checkpoint map[string]string `json:"checkpoint,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Notified   bool              `json:"notified,omitempty"` // whether the final event has been sent
+}
+
+// finished checks whether the task has been run to the end, the finished task is never run again.
+func (e *taskJournalEntry) finished() bool {
+	return e.State == taskStateSucceeded || e.State == taskStateFailed
+}
+
+// error returns the error of the failed task.
+func (e *taskJournalEntry) error() error {
+	if e.State != taskStateFailed {
+		return nil
+	}
+	return errors.New(e.Message)
+}
+
+// taskJournal keeps the state of tasks in a local directory, so that they can be resumed or failed cleanly
+// after the kb-agent restarts. All methods are no-op if the directory is not specified.
+type taskJournal struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+func newTaskJournal(dir string) (*taskJournal, error) {
+	if len(dir) > 0 {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+	}
+	return &taskJournal{dir: dir}, nil
+}
+
+func (j *taskJournal) enabled() bool {
+	return j != nil && len(j.dir) > 0
+}
+
+func (j *taskJournal) path(uid string) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%s.json", taskJournalUnsafeChars.ReplaceAllString(uid, "_")))
+}
+
+// load returns the entry of the task, or nil if the task has no journal.
+func (j *taskJournal) load(uid string) (*taskJournalEntry, error) {
+	if !j.enabled() {
+		return nil, nil
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	data, err := os.ReadFile(j.path(uid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &taskJournalEntry{}
+	if err = json.Unmarshal(data, entry); err != nil {
+		return nil, fmt.Errorf("corrupted task journal %s: %s", j.path(uid), err.Error())
+	}
+	return entry, nil
+}
+
+// save writes the entry atomically.
+func (j *taskJournal) save(entry *taskJournalEntry) error {
+	if !j.enabled() {
+		return nil
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	path := j.path(entry.UID)
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, taskJournalFileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
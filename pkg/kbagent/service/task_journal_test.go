@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("task journal", func() {
+	It("is a no-op without directory", func() {
+		journal, err := newTaskJournal("")
+		Expect(err).Should(BeNil())
+		Expect(journal.enabled()).Should(BeFalse())
+		Expect(journal.save(&taskJournalEntry{UID: "u1"})).Should(Succeed())
+		entry, err := journal.load("u1")
+		Expect(err).Should(BeNil())
+		Expect(entry).Should(BeNil())
+	})
+
+	It("saves and loads entries", func() {
+		dir := GinkgoT().TempDir()
+		journal, err := newTaskJournal(filepath.Join(dir, "journal"))
+		Expect(err).Should(BeNil())
+
+		entry, err := journal.load("../u1")
+		Expect(err).Should(BeNil())
+		Expect(entry).Should(BeNil())
+
+		Expect(journal.save(&taskJournalEntry{
+			UID:        "../u1",
+			Task:       "newReplica",
+			State:      taskStateRunning,
+			Attempts:   1,
+			Checkpoint: map[string]string{"offset": "1024"},
+		})).Should(Succeed())
+		entry, err = journal.load("../u1")
+		Expect(err).Should(BeNil())
+		Expect(entry.State).Should(Equal(taskStateRunning))
+		Expect(entry.Checkpoint).Should(HaveKeyWithValue("offset", "1024"))
+
+		// the UID is sanitized to stay in the journal directory
+		files, err := os.ReadDir(filepath.Join(dir, "journal"))
+		Expect(err).Should(BeNil())
+		Expect(files).Should(HaveLen(1))
+		Expect(files[0].Name()).Should(Equal(".._u1.json"))
+	})
+
+	It("reports corrupted entries", func() {
+		dir := GinkgoT().TempDir()
+		journal, err := newTaskJournal(dir)
+		Expect(err).Should(BeNil())
+		Expect(os.WriteFile(journal.path("u1"), []byte("{"), 0600)).Should(Succeed())
+		_, err = journal.load("u1")
+		Expect(err).Should(MatchError(ContainSubstring("corrupted task journal")))
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// taskKind defines a kind of the tasks, the parameters of each kind are carried by its own payload in the task.
+type taskKind struct {
+	name string
+	// carried returns whether the task carries the payload of the kind.
+	carried func(task proto.Task) bool
+	// new validates the payload of the kind and creates the task.
+	new func(s *taskService, task proto.Task) (task, error)
+}
+
+// taskKinds is the registry of the task kinds, in the order of registration.
+var taskKinds []*taskKind
+
+func registerTaskKind(kind *taskKind) {
+	taskKinds = append(taskKinds, kind)
+}
+
+// lookupTaskKind looks up the kind of the task by its name, and then by the payload carried.
+func lookupTaskKind(task proto.Task) *taskKind {
+	for _, kind := range taskKinds {
+		if kind.name == task.Task && kind.carried(task) {
+			return kind
+		}
+	}
+	for _, kind := range taskKinds {
+		if kind.carried(task) {
+			return kind
+		}
+	}
+	return nil
+}
+
+func init() {
+	registerTaskKind(&taskKind{
+		name:    proto.TaskKindNewReplica,
+		carried: func(task proto.Task) bool { return task.NewReplica != nil },
+		new: func(s *taskService, task proto.Task) (task, error) {
+			return &newReplicaTask{
+				logger:        s.logger,
+				actionService: s.actionService,
+				task:          task.NewReplica,
+				cred:          s.cred,
+			}, nil
+		},
+	})
+	registerTaskKind(&taskKind{
+		name:    proto.TaskKindResyncReplica,
+		carried: func(task proto.Task) bool { return task.ResyncReplica != nil },
+		new:     newResyncReplicaTask,
+	})
+	registerTaskKind(&taskKind{
+		name:    proto.TaskKindRebuildReplica,
+		carried: func(task proto.Task) bool { return task.RebuildReplica != nil },
+		new:     newRebuildReplicaTask,
+	})
+	registerTaskKind(&taskKind{
+		name:    proto.TaskKindVerifyChecksum,
+		carried: func(task proto.Task) bool { return task.VerifyChecksum != nil },
+		new:     newVerifyChecksumTask,
+	})
+	registerTaskKind(&taskKind{
+		name:    proto.TaskKindCompactStorage,
+		carried: func(task proto.Task) bool { return task.CompactStorage != nil },
+		new:     newCompactStorageTask,
+	})
+}
@@ -20,13 +20,23 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"net"
+	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
@@ -37,47 +47,206 @@ const (
 	newReplicaDataLoad = "dataLoad"
 
 	targetPodNameEnv = "KB_TARGET_POD_NAME"
+	dataOffsetEnv    = "KB_DATA_OFFSET"
+	dataCommitEnv    = "KB_DATA_COMMIT_FILE"
+
+	newReplicaCheckpointOffset = "offset"
 )
 
 type newReplicaTask struct {
 	logger        logr.Logger
 	actionService *actionService
 	task          *proto.NewReplicaTask
+	cred          *util.Credential
+
+	startTime  time.Time
+	offset     int64        // the offset of the data that the transfer started from
+	commitFile string       // the file that the data load writes the committed offset to
+	loaded     atomic.Int64 // bytes of the data fed to the data load since the transfer started
+	received   atomic.Int64 // bytes received on the wire since the transfer started
 }
 
-var _ task = &newReplicaTask{}
+var _ resumableTask = &newReplicaTask{}
 
 func (s *newReplicaTask) run(ctx context.Context) (chan error, error) {
+	return s.transfer(ctx, 0)
+}
+
+func (s *newReplicaTask) resume(ctx context.Context, checkpoint map[string]string) (chan error, error) {
+	offset, err := strconv.ParseInt(checkpoint[newReplicaCheckpointOffset], 10, 64)
+	if err != nil || offset < 0 {
+		return nil, fmt.Errorf("invalid checkpoint of the new replica task: %v", checkpoint)
+	}
+	return s.transfer(ctx, offset)
+}
+
+func (s *newReplicaTask) resumable() bool {
+	return s.task.Resumable
+}
+
+// checkpoint returns the offset of the data that has been committed by the data load, if the transfer is resumable.
+// The bytes fed to the data load may not be applied yet, so they are never checkpointed.
+func (s *newReplicaTask) checkpoint() map[string]string {
+	if !s.resumable() || s.startTime.IsZero() {
+		return nil
+	}
+	committed, ok := s.committed()
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		newReplicaCheckpointOffset: strconv.FormatInt(committed, 10),
+	}
+}
+
+// committed reads the offset committed by the data load, the offset should be within the data transferred.
+func (s *newReplicaTask) committed() (int64, bool) {
+	if len(s.commitFile) == 0 {
+		return 0, false
+	}
+	data, err := os.ReadFile(s.commitFile)
+	if err != nil {
+		return 0, false
+	}
+	committed, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || committed < s.offset || committed > s.offset+s.loaded.Load() {
+		return 0, false
+	}
+	return committed, true
+}
+
+func (s *newReplicaTask) transfer(ctx context.Context, offset int64) (chan error, error) {
 	action, ok := s.actionService.actions[newReplicaDataLoad]
 	if !ok {
 		return nil, fmt.Errorf("%s is not supported", newReplicaDataLoad)
 	}
+	if offset > 0 && !s.task.Resumable {
+		return nil, fmt.Errorf("the transfer is not resumable")
+	}
+	if err := checkStreamingCompression(s.task.Compression); err != nil {
+		return nil, err
+	}
+
+	// the task timeout takes precedence over the action timeout, and it covers the whole transfer
+	timeout := &action.TimeoutSeconds
+	cancel := func() {}
+	if ptr.Deref(s.task.TimeoutSeconds, 0) > 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*s.task.TimeoutSeconds)*time.Second)
+		timeout = ptr.To[int32](-1)
+	}
+
+	conn, reader, err := s.handshake(ctx, offset)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s.startTime, s.offset = time.Now(), offset
+	s.loaded.Store(0)
+	s.received.Store(0)
+
+	reader = newThrottledReader(ctx, &countingReader{reader: reader, count: &s.received}, s.task.RateLimitBytesPerSecond)
+	decompressed, err := decompressReader(reader, s.task.Compression)
+	if err != nil {
+		cancel()
+		_ = conn.Close()
+		return nil, err
+	}
 
-	conn, err := s.handshake(ctx)
+	parameters := s.task.Parameters
+	if s.task.Resumable {
+		if err = s.newCommitFile(offset); err != nil {
+			cancel()
+			_ = decompressed.Close()
+			_ = conn.Close()
+			return nil, err
+		}
+		parameters = maps.Clone(s.task.Parameters)
+		if parameters == nil {
+			parameters = make(map[string]string)
+		}
+		parameters[dataOffsetEnv] = strconv.FormatInt(offset, 10)
+		parameters[dataCommitEnv] = s.commitFile
+	}
+	stdin := &countingReader{reader: decompressed, count: &s.loaded}
+	errChan, err := nonBlockingCallActionX(ctx, action, parameters, nil, timeout, stdin, nil, nil)
 	if err != nil {
+		cancel()
+		_ = decompressed.Close()
+		_ = conn.Close()
 		return nil, err
 	}
 
-	return nonBlockingCallActionX(ctx, action, s.task.Parameters, nil, &action.TimeoutSeconds, conn, nil, nil)
+	// unblock the data load reading from the remote when the transfer is canceled or timed out
+	stop := context.AfterFunc(ctx, func() { safeClose(conn) })
+
+	ch := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer stop()
+		defer safeClose(conn)
+		defer safeClose(decompressed)
+		err, ok := <-errChan
+		if !ok {
+			err = errors.New("runtime error: error chan closed unexpectedly")
+		}
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("the transfer is timed out after %ds: %s", ptr.Deref(s.task.TimeoutSeconds, 0), err.Error())
+		}
+		if err == nil && len(s.commitFile) > 0 {
+			// the transfer is done, there is nothing to resume
+			_ = os.Remove(s.commitFile)
+		}
+		ch <- err
+	}()
+	return ch, nil
+}
+
+// newCommitFile creates the file for the data load to commit the offset, it is initialized with the offset resumed from.
+func (s *newReplicaTask) newCommitFile(offset int64) error {
+	if len(s.commitFile) > 0 {
+		_ = os.Remove(s.commitFile)
+	}
+	f, err := os.CreateTemp("", "kbagent-data-commit-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s.commitFile = f.Name()
+	_, err = f.WriteString(strconv.FormatInt(offset, 10))
+	return err
 }
 
 func (s *newReplicaTask) status(ctx context.Context, event *proto.TaskEvent) {
-	// TODO: query the progress
 	event.Code = 0
 	event.Output = nil
 	event.Message = ""
+	if !s.startTime.IsZero() {
+		elapsed := time.Since(s.startTime).Truncate(time.Second)
+		event.Message = fmt.Sprintf("loaded: %d bytes, offset: %d, received: %d bytes, elapsed: %s",
+			s.offset+s.loaded.Load(), s.offset, s.received.Load(), elapsed)
+	}
 }
 
-func (s *newReplicaTask) handshake(ctx context.Context) (net.Conn, error) {
+// handshake connects to the remote and requests the data dump, it returns the connection and the reader of the data.
+func (s *newReplicaTask) handshake(ctx context.Context, offset int64) (net.Conn, io.Reader, error) {
 	conn, err := s.connectToRemote(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err = s.authenticate(conn); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
 	}
 
-	// reuse the action request as the handshake packet, define a new one when needed
-	req := proto.ActionRequest{
-		Action:     newReplicaDataDump,
-		Parameters: s.task.Parameters,
+	req := proto.StreamingRequest{
+		ActionRequest: proto.ActionRequest{
+			Action:     newReplicaDataDump,
+			Parameters: s.task.Parameters,
+		},
+		Compression: s.task.Compression,
+		Offset:      offset,
 	}
 	if req.Parameters == nil {
 		req.Parameters = make(map[string]string)
@@ -85,21 +254,58 @@ func (s *newReplicaTask) handshake(ctx context.Context) (net.Conn, error) {
 	req.Parameters[targetPodNameEnv] = util.PodName()
 	data, err := json.Marshal(req)
 	if err != nil {
-		return nil, err
+		_ = conn.Close()
+		return nil, nil, err
 	}
 	if len(data) > maxStreamingHandshakePacketSize {
-		return nil, fmt.Errorf("handshake packet size is too large: %d", len(data))
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("handshake packet size is too large: %d", len(data))
 	}
 
 	ret, err := conn.Write(data)
 	if err != nil {
-		return nil, err
+		_ = conn.Close()
+		return nil, nil, err
 	}
 	if ret != len(data) {
-		return nil, fmt.Errorf("write streaming handshake request to remote error")
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("write streaming handshake request to remote error")
+	}
+
+	if !req.Negotiable() {
+		return conn, conn, nil
+	}
+	reader, err := s.negotiate(conn, &req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
 	}
+	return conn, reader, nil
+}
+
+// negotiate reads the streaming response to make sure the options requested are accepted by the remote.
+func (s *newReplicaTask) negotiate(conn net.Conn, req *proto.StreamingRequest) (io.Reader, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(streamingNegotiateTimeout))
+	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
 
-	return conn, nil
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read streaming response error: %s", err.Error())
+	}
+	rsp := proto.StreamingResponse{}
+	if err = json.Unmarshal(line, &rsp); err != nil {
+		// the remote kb-agent may not support the streaming options and send the data directly
+		return nil, fmt.Errorf("unmarshal streaming response error, the remote may not support the streaming options: %s", err.Error())
+	}
+	if len(rsp.Error) > 0 {
+		return nil, fmt.Errorf("streaming options are rejected by the remote: %s", rsp.Error)
+	}
+	if rsp.Compression != req.Compression || rsp.Offset != req.Offset {
+		return nil, fmt.Errorf("streaming options are not accepted by the remote, compression: %s, offset: %d",
+			rsp.Compression, rsp.Offset)
+	}
+	return reader, nil
 }
 
 func (s *newReplicaTask) connectToRemote(ctx context.Context) (net.Conn, error) {
@@ -112,5 +318,25 @@ func (s *newReplicaTask) connectToRemote(ctx context.Context) (net.Conn, error)
 	dialer := &net.Dialer{
 		Timeout: defaultConnectTimeout,
 	}
-	return dialer.Dial("tcp", net.JoinHostPort(s.task.Remote, strconv.Itoa(int(s.task.Port))))
+	address := net.JoinHostPort(s.task.Remote, strconv.Itoa(int(s.task.Port)))
+	config, err := s.cred.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		return tls.DialWithDialer(dialer, "tcp", address, config)
+	}
+	return dialer.Dial("tcp", address)
+}
+
+func (s *newReplicaTask) authenticate(conn net.Conn) error {
+	if !s.cred.TokenEnabled() {
+		return nil
+	}
+	data, err := json.Marshal(proto.StreamingAuthRequest{Token: s.cred.Token})
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(data, '\n'))
+	return err
 }
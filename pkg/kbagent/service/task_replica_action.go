@@ -0,0 +1,219 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	primaryPodFQDNEnv = "KB_PRIMARY_POD_FQDN"
+	backupNameEnv     = "KB_BACKUP_NAME"
+	peerPodFQDNEnv    = "KB_PEER_POD_FQDN"
+)
+
+// replicaActionTask is a replica task carried out by an action, the kinds differ in the parameters passed to the action
+// and the check of the action output.
+type replicaActionTask struct {
+	logger        logr.Logger
+	actionService *actionService
+	kind          string
+	spec          proto.ReplicaActionTask
+	parameters    map[string]string                              // the parameters of the kind passed to the action
+	check         func(ctx context.Context, output []byte) error // the check of the output, optional
+
+	mutex     sync.Mutex
+	startTime time.Time
+	stage     string
+}
+
+var _ task = &replicaActionTask{}
+
+func newReplicaActionTask(s *taskService, kind string, spec proto.ReplicaActionTask, parameters map[string]string) (*replicaActionTask, error) {
+	if len(spec.Action) == 0 {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "the action of the %s task is required", kind)
+	}
+	if ptr.Deref(spec.TimeoutSeconds, 0) < 0 {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid timeout of the %s task: %d", kind, *spec.TimeoutSeconds)
+	}
+	for k, v := range parameters {
+		if len(v) == 0 {
+			return nil, errors.Wrapf(proto.ErrBadRequest, "the parameter %s of the %s task is required", k, kind)
+		}
+	}
+	return &replicaActionTask{
+		logger:        s.logger,
+		actionService: s.actionService,
+		kind:          kind,
+		spec:          spec,
+		parameters:    parameters,
+	}, nil
+}
+
+func newResyncReplicaTask(s *taskService, task proto.Task) (task, error) {
+	spec := task.ResyncReplica
+	return newReplicaActionTask(s, proto.TaskKindResyncReplica, spec.ReplicaActionTask, map[string]string{
+		primaryPodFQDNEnv: spec.Primary,
+	})
+}
+
+func newRebuildReplicaTask(s *taskService, task proto.Task) (task, error) {
+	spec := task.RebuildReplica
+	return newReplicaActionTask(s, proto.TaskKindRebuildReplica, spec.ReplicaActionTask, map[string]string{
+		backupNameEnv: spec.Backup,
+	})
+}
+
+func newVerifyChecksumTask(s *taskService, task proto.Task) (task, error) {
+	spec := task.VerifyChecksum
+	if spec.Port <= 0 {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid port of the peer: %d", spec.Port)
+	}
+	t, err := newReplicaActionTask(s, proto.TaskKindVerifyChecksum, spec.ReplicaActionTask, map[string]string{
+		peerPodFQDNEnv: spec.Peer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.check = func(ctx context.Context, output []byte) error {
+		t.setStage("verifying against the peer")
+		peer, err := peerChecksum(ctx, s.cred, spec)
+		if err != nil {
+			return err
+		}
+		local := bytes.TrimSpace(output)
+		if !bytes.Equal(local, peer) {
+			return fmt.Errorf("the checksum mismatched with the peer %s: local %q, peer %q", spec.Peer, local, peer)
+		}
+		return nil
+	}
+	return t, nil
+}
+
+func newCompactStorageTask(s *taskService, task proto.Task) (task, error) {
+	return newReplicaActionTask(s, proto.TaskKindCompactStorage, task.CompactStorage.ReplicaActionTask, nil)
+}
+
+// peerChecksum calls the checksum action on the peer through its kb-agent.
+func peerChecksum(ctx context.Context, cred *util.Credential, spec *proto.VerifyChecksumTask) ([]byte, error) {
+	cli, err := client.NewClient(func() (string, int32, error) {
+		return spec.Peer, spec.Port, nil
+	}, cred)
+	if err != nil {
+		return nil, err
+	}
+	if cli == nil {
+		return nil, fmt.Errorf("the peer is required")
+	}
+	defer cli.Close()
+
+	rsp, err := cli.Action(ctx, proto.ActionRequest{
+		Action:         spec.Action,
+		Parameters:     spec.Parameters,
+		TimeoutSeconds: spec.TimeoutSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("call the checksum action on the peer %s error: %s", spec.Peer, err.Error())
+	}
+	if len(rsp.Error) > 0 {
+		return nil, fmt.Errorf("call the checksum action on the peer %s error: %s, %s", spec.Peer, rsp.Error, rsp.Message)
+	}
+	return bytes.TrimSpace(rsp.Output), nil
+}
+
+func (s *replicaActionTask) run(ctx context.Context) (chan error, error) {
+	action, ok := s.actionService.actions[s.spec.Action]
+	if !ok {
+		return nil, fmt.Errorf("%s is not supported", s.spec.Action)
+	}
+
+	// the task timeout takes precedence over the action timeout
+	timeout := &action.TimeoutSeconds
+	cancel := func() {}
+	if ptr.Deref(s.spec.TimeoutSeconds, 0) > 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*s.spec.TimeoutSeconds)*time.Second)
+		timeout = ptr.To[int32](-1)
+	}
+
+	parameters := maps.Clone(s.spec.Parameters)
+	if parameters == nil {
+		parameters = make(map[string]string)
+	}
+	maps.Copy(parameters, s.parameters)
+
+	s.mutex.Lock()
+	s.startTime, s.stage = time.Now(), "running the action "+s.spec.Action
+	s.mutex.Unlock()
+
+	output := &bytes.Buffer{}
+	errChan, err := nonBlockingCallActionX(ctx, action, parameters, nil, timeout, nil, output, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch := make(chan error, 1)
+	go func() {
+		defer cancel()
+		err, ok := <-errChan
+		if !ok {
+			err = errors.New("runtime error: error chan closed unexpectedly")
+		}
+		if err == nil && s.check != nil {
+			err = s.check(ctx, output.Bytes())
+		}
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("the %s task is timed out after %ds: %s", s.kind, ptr.Deref(s.spec.TimeoutSeconds, 0), err.Error())
+		}
+		ch <- err
+	}()
+	return ch, nil
+}
+
+func (s *replicaActionTask) status(ctx context.Context, event *proto.TaskEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	event.Code = 0
+	event.Output = nil
+	event.Message = ""
+	if !s.startTime.IsZero() {
+		elapsed := time.Since(s.startTime).Truncate(time.Second)
+		event.Message = fmt.Sprintf("%s, elapsed: %s", s.stage, elapsed)
+	}
+}
+
+func (s *replicaActionTask) setStage(stage string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stage = stage
+}
@@ -0,0 +1,216 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("replica action task", func() {
+	newTaskService := func(commands string) *taskService {
+		actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+			Name: "task",
+			Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", commands}},
+		}})
+		Expect(err).Should(BeNil())
+		return &taskService{logger: logr.New(nil), actionService: actionSvc}
+	}
+
+	runTask := func(svc *taskService, task proto.Task) error {
+		t, err := svc.newTask(task)
+		if err != nil {
+			return err
+		}
+		ch, err := t.run(ctx)
+		if err != nil {
+			return err
+		}
+		return svc.wait(ch)
+	}
+
+	Context("kind", func() {
+		It("looks up the kind by name and payload", func() {
+			Expect(lookupTaskKind(proto.Task{})).Should(BeNil())
+			Expect(lookupTaskKind(proto.Task{Task: proto.TaskKindCompactStorage})).Should(BeNil())
+
+			kind := lookupTaskKind(proto.Task{Task: proto.TaskKindCompactStorage, CompactStorage: &proto.CompactStorageTask{}})
+			Expect(kind).ShouldNot(BeNil())
+			Expect(kind.name).Should(Equal(proto.TaskKindCompactStorage))
+
+			// the kind is derived from the payload if the name is not registered
+			kind = lookupTaskKind(proto.Task{Task: "new-replica", NewReplica: &proto.NewReplicaTask{}})
+			Expect(kind).ShouldNot(BeNil())
+			Expect(kind.name).Should(Equal(proto.TaskKindNewReplica))
+		})
+
+		It("validates the payload", func() {
+			svc := newTaskService("true")
+			for _, task := range []proto.Task{
+				{Task: proto.TaskKindCompactStorage, CompactStorage: &proto.CompactStorageTask{}},
+				{Task: proto.TaskKindResyncReplica, ResyncReplica: &proto.ResyncReplicaTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task"},
+				}},
+				{Task: proto.TaskKindRebuildReplica, RebuildReplica: &proto.RebuildReplicaTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task", TimeoutSeconds: ptr.To(int32(-1))},
+					Backup:            "backup",
+				}},
+				{Task: proto.TaskKindVerifyChecksum, VerifyChecksum: &proto.VerifyChecksumTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task"},
+					Peer:              "pod-1",
+				}},
+			} {
+				_, err := svc.newTask(task)
+				Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue(), task.Task)
+			}
+		})
+
+		It("fails the invalid task", func() {
+			GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
+			svc := newTaskService("true")
+			err := svc.runTask(ctx, proto.Task{
+				Instance:       "inst",
+				Task:           proto.TaskKindResyncReplica,
+				UID:            "u1",
+				ResyncReplica:  &proto.ResyncReplicaTask{},
+				NotifyAtFinish: false,
+			})
+			Expect(err).Should(MatchError(ContainSubstring("the action of the resyncReplica task is required")))
+		})
+	})
+
+	Context("run", func() {
+		It("passes the parameters of the kind to the action", func() {
+			svc := newTaskService(`[ "$KB_PRIMARY_POD_FQDN" == "pod-0.svc" ] && [ "$DB" == "db" ]`)
+			Expect(runTask(svc, proto.Task{
+				Task: proto.TaskKindResyncReplica,
+				ResyncReplica: &proto.ResyncReplicaTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task", Parameters: map[string]string{"DB": "db"}},
+					Primary:           "pod-0.svc",
+				},
+			})).Should(Succeed())
+
+			svc = newTaskService(`[ "$KB_BACKUP_NAME" == "backup-1" ]`)
+			Expect(runTask(svc, proto.Task{
+				Task: proto.TaskKindRebuildReplica,
+				RebuildReplica: &proto.RebuildReplicaTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task"},
+					Backup:            "backup-2",
+				},
+			})).ShouldNot(Succeed())
+		})
+
+		It("reports the progress", func() {
+			svc := newTaskService("sleep 1")
+			t, err := svc.newTask(proto.Task{
+				Task:           proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{ReplicaActionTask: proto.ReplicaActionTask{Action: "task"}},
+			})
+			Expect(err).Should(BeNil())
+
+			event := &proto.TaskEvent{}
+			t.status(ctx, event)
+			Expect(event.Message).Should(BeEmpty())
+
+			ch, err := t.run(ctx)
+			Expect(err).Should(BeNil())
+			t.status(ctx, event)
+			Expect(event.Message).Should(HavePrefix("running the action task, elapsed:"))
+			Expect(svc.wait(ch)).Should(Succeed())
+		})
+
+		It("fails the undefined action and the timed out task", func() {
+			svc := newTaskService("sleep 5")
+			err := runTask(svc, proto.Task{
+				Task:           proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{ReplicaActionTask: proto.ReplicaActionTask{Action: "undefined"}},
+			})
+			Expect(err).Should(MatchError("undefined is not supported"))
+
+			err = runTask(svc, proto.Task{
+				Task: proto.TaskKindCompactStorage,
+				CompactStorage: &proto.CompactStorageTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task", TimeoutSeconds: ptr.To(int32(1))},
+				},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("the compactStorage task is timed out after 1s")))
+		})
+	})
+
+	Context("verify checksum", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockClient *client.MockClient
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockClient = client.NewMockClient(mockCtrl)
+			client.SetMockClient(mockClient, nil)
+		})
+
+		AfterEach(func() {
+			client.UnsetMockClient()
+			mockCtrl.Finish()
+		})
+
+		verify := func(peer proto.ActionResponse) error {
+			mockClient.EXPECT().Action(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+				Expect(req.Action).Should(Equal("task"))
+				return peer, nil
+			})
+			svc := newTaskService("echo abc")
+			return runTask(svc, proto.Task{
+				Task: proto.TaskKindVerifyChecksum,
+				VerifyChecksum: &proto.VerifyChecksumTask{
+					ReplicaActionTask: proto.ReplicaActionTask{Action: "task"},
+					Peer:              "pod-1.svc",
+					Port:              3501,
+				},
+			})
+		}
+
+		It("matched", func() {
+			Expect(verify(proto.ActionResponse{Output: []byte("abc\n")})).Should(Succeed())
+		})
+
+		It("mismatched", func() {
+			err := verify(proto.ActionResponse{Output: []byte("abd")})
+			Expect(err).Should(HaveOccurred())
+			Expect(strings.Contains(err.Error(), "the checksum mismatched with the peer pod-1.svc")).Should(BeTrue())
+		})
+
+		It("peer failed", func() {
+			err := verify(proto.ActionResponse{Error: proto.Error2Type(proto.ErrFailed), Message: "exit 1"})
+			Expect(err).Should(MatchError(ContainSubstring("call the checksum action on the peer pod-1.svc error: failed, exit 1")))
+		})
+	})
+})
@@ -25,12 +25,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
@@ -49,13 +52,34 @@ func (t fakeTask) status(_ context.Context, _ *proto.TaskEvent) {
 	t.statusCalled <- struct{}{}
 }
 
+type fakeResumableTask struct {
+	fakeTask
+	checkpointCalled chan struct{}
+}
+
+func (t fakeResumableTask) resumable() bool {
+	return true
+}
+
+func (t fakeResumableTask) checkpoint() map[string]string {
+	select {
+	case t.checkpointCalled <- struct{}{}:
+	default:
+	}
+	return map[string]string{newReplicaCheckpointOffset: "1"}
+}
+
+func (t fakeResumableTask) resume(ctx context.Context, _ map[string]string) (chan error, error) {
+	return t.run(ctx)
+}
+
 var _ = Describe("task", func() {
 	Context("task", func() {
 		It("runs no-op task lists through exported helper", func() {
 			GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
 			actionSvc, err := newActionService(logr.New(nil), nil)
 			Expect(err).Should(BeNil())
-			Expect(RunTasks(logr.New(nil), actionSvc, []proto.Task{{Replicas: "pod-1"}})).Should(Succeed())
+			Expect(RunTasks(logr.New(nil), actionSvc, []proto.Task{{Replicas: "pod-1"}}, nil, "")).Should(Succeed())
 		})
 
 		It("handles wait channel states", func() {
@@ -119,7 +143,7 @@ var _ = Describe("task", func() {
 		It("reports task status until stopped", func() {
 			svc := &taskService{logger: logr.New(nil)}
 			fake := fakeTask{statusCalled: make(chan struct{}, 1)}
-			exit, exited := svc.report(ctx, proto.Task{ReportPeriodSeconds: 1}, fake, proto.TaskEvent{})
+			exit, exited := svc.report(ctx, proto.Task{ReportPeriodSeconds: 1}, fake, proto.TaskEvent{}, &taskJournalEntry{})
 			Expect(exit).ShouldNot(BeNil())
 			Expect(exited).ShouldNot(BeNil())
 
@@ -131,11 +155,33 @@ var _ = Describe("task", func() {
 			close(exit)
 			Eventually(exited).Should(BeClosed())
 
-			exit, exited = svc.report(ctx, proto.Task{}, fake, proto.TaskEvent{})
+			exit, exited = svc.report(ctx, proto.Task{}, fake, proto.TaskEvent{}, &taskJournalEntry{})
 			Expect(exit).Should(BeNil())
 			Expect(exited).Should(BeNil())
 		})
 
+		It("checkpoints the resumable task without the report", func() {
+			journal, err := newTaskJournal(GinkgoT().TempDir())
+			Expect(err).Should(BeNil())
+			svc := &taskService{logger: logr.New(nil), journal: journal}
+
+			period := taskCheckpointPeriod
+			taskCheckpointPeriod = 100 * time.Millisecond
+			defer func() { taskCheckpointPeriod = period }()
+
+			fake := fakeResumableTask{checkpointCalled: make(chan struct{}, 1)}
+			entry := &taskJournalEntry{UID: "u1", State: taskStateRunning}
+			exit, exited := svc.report(ctx, proto.Task{}, fake, proto.TaskEvent{}, entry)
+			Expect(exit).ShouldNot(BeNil())
+			Eventually(fake.checkpointCalled).Should(Receive())
+			close(exit)
+			Eventually(exited).Should(BeClosed())
+
+			saved, err := journal.load(entry.UID)
+			Expect(err).Should(BeNil())
+			Expect(saved.Checkpoint).Should(HaveKeyWithValue(newReplicaCheckpointOffset, "1"))
+		})
+
 		It("keeps the run error when finish notification also fails", func() {
 			GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
 			GinkgoT().Setenv("KUBECONFIG", "/path/to/missing/kubeconfig")
@@ -156,6 +202,55 @@ var _ = Describe("task", func() {
 			Expect(err).Should(MatchError("remote server is required"))
 		})
 
+		It("journals the task state across restarts", func() {
+			GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+				Name: newReplicaDataLoad,
+				Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "cat"}},
+			}})
+			Expect(err).Should(BeNil())
+			journal, err := newTaskJournal(GinkgoT().TempDir())
+			Expect(err).Should(BeNil())
+			svc := &taskService{logger: logr.New(nil), actionService: actionSvc, journal: journal}
+			task := proto.Task{
+				Instance:   "inst",
+				Task:       "new-replica",
+				UID:        "u1",
+				NewReplica: &proto.NewReplicaTask{Port: 3502},
+			}
+
+			By("failed task is recorded")
+			Expect(svc.runTask(ctx, task)).Should(MatchError("remote server is required"))
+			entry, err := journal.load(task.UID)
+			Expect(err).Should(BeNil())
+			Expect(entry.State).Should(Equal(taskStateFailed))
+			Expect(entry.Attempts).Should(Equal(int32(1)))
+			Expect(entry.Message).Should(Equal("remote server is required"))
+
+			By("failed task is not run again")
+			endTime := entry.EndTime
+			Expect(svc.runTask(ctx, task)).Should(MatchError("remote server is required"))
+			entry, err = journal.load(task.UID)
+			Expect(err).Should(BeNil())
+			Expect(entry.State).Should(Equal(taskStateFailed))
+			Expect(entry.Attempts).Should(Equal(int32(1)))
+			Expect(entry.EndTime).Should(BeTemporally("==", endTime))
+
+			By("interrupted task that can't be resumed is failed")
+			entry.State = taskStateRunning
+			Expect(journal.save(entry)).Should(Succeed())
+			Expect(svc.runTask(ctx, task)).Should(MatchError(ContainSubstring("interrupted by the restart")))
+			entry, err = journal.load(task.UID)
+			Expect(err).Should(BeNil())
+			Expect(entry.State).Should(Equal(taskStateFailed))
+			Expect(entry.Attempts).Should(Equal(int32(1)))
+
+			By("succeeded task is skipped")
+			entry.State = taskStateSucceeded
+			Expect(journal.save(entry)).Should(Succeed())
+			Expect(svc.runTask(ctx, task)).Should(Succeed())
+		})
+
 		It("writes a new-replica handshake packet to the remote server", func() {
 			GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
 			listener, err := net.Listen("tcp", "127.0.0.1:0")
@@ -186,7 +281,7 @@ var _ = Describe("task", func() {
 					Parameters: map[string]string{"foo": "bar"},
 				},
 			}
-			conn, err := task.handshake(ctx)
+			conn, _, err := task.handshake(ctx, 0)
 			Expect(err).Should(BeNil())
 			Expect(conn.Close()).Should(Succeed())
 
@@ -202,6 +297,156 @@ var _ = Describe("task", func() {
 			Expect(event.Output).Should(BeNil())
 		})
 
+		Context("transfer data", func() {
+			var (
+				listener net.Listener
+				port     int32
+				out      string
+			)
+
+			serve := func(dump string) {
+				actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+					Name:           newReplicaDataDump,
+					Exec:           &proto.ExecAction{Commands: []string{"/bin/bash", "-c", dump}},
+					TimeoutSeconds: -1,
+				}})
+				Expect(err).Should(BeNil())
+				streamingSvc, err := newStreamingService(logr.New(nil), actionSvc, nil, []string{newReplicaDataDump})
+				Expect(err).Should(BeNil())
+				go func() {
+					for {
+						conn, err := listener.Accept()
+						if err != nil {
+							return
+						}
+						go func() {
+							defer conn.Close()
+							_ = streamingSvc.HandleConn(ctx, conn)
+						}()
+					}
+				}()
+			}
+
+			newTask := func(task *proto.NewReplicaTask) *newReplicaTask {
+				actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+					Name: newReplicaDataLoad,
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c",
+						`echo -n "[${KB_DATA_OFFSET}]" >> "$0"; cat >> "$0"`, out}},
+				}})
+				Expect(err).Should(BeNil())
+				task.Remote, task.Port = "127.0.0.1", port
+				return &newReplicaTask{logger: logr.New(nil), actionService: actionSvc, task: task}
+			}
+
+			transfer := func(task *newReplicaTask, offset int64) error {
+				var (
+					ch  chan error
+					err error
+				)
+				if offset == 0 {
+					ch, err = task.run(ctx)
+				} else {
+					ch, err = task.resume(ctx, map[string]string{newReplicaCheckpointOffset: fmt.Sprintf("%d", offset)})
+				}
+				if err != nil {
+					return err
+				}
+				return <-ch
+			}
+
+			BeforeEach(func() {
+				GinkgoT().Setenv("KB_AGENT_POD_NAME", "pod-0")
+				var err error
+				listener, err = net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).Should(BeNil())
+				port = int32(listener.Addr().(*net.TCPAddr).Port)
+				out = filepath.Join(GinkgoT().TempDir(), "out")
+			})
+
+			AfterEach(func() {
+				_ = listener.Close()
+			})
+
+			It("transfers compressed data and reports the progress", func() {
+				serve("printf 0123456789")
+				for _, compression := range []proto.StreamingCompression{proto.StreamingCompressionGzip, proto.StreamingCompressionZstd} {
+					Expect(os.RemoveAll(out)).Should(Succeed())
+					task := newTask(&proto.NewReplicaTask{Compression: compression, Resumable: true})
+					Expect(transfer(task, 0)).Should(Succeed())
+					data, err := os.ReadFile(out)
+					Expect(err).Should(BeNil())
+					Expect(string(data)).Should(Equal("[0]0123456789"))
+
+					// the transfer is done, there is nothing to resume
+					Expect(task.checkpoint()).Should(BeNil())
+					event := &proto.TaskEvent{}
+					task.status(ctx, event)
+					Expect(event.Message).Should(ContainSubstring("loaded: 10 bytes"))
+				}
+			})
+
+			It("resumes the transfer from the offset", func() {
+				serve("printf 0123456789")
+				task := newTask(&proto.NewReplicaTask{Resumable: true})
+				Expect(transfer(task, 4)).Should(Succeed())
+				data, err := os.ReadFile(out)
+				Expect(err).Should(BeNil())
+				Expect(string(data)).Should(Equal("[4]456789"))
+
+				task = newTask(&proto.NewReplicaTask{})
+				Expect(task.checkpoint()).Should(BeNil())
+				Expect(transfer(task, 4)).Should(MatchError("the transfer is not resumable"))
+			})
+
+			It("checkpoints the offset committed by the data load", func() {
+				serve("printf 0123456789")
+				actionSvc, err := newActionService(logr.New(nil), []proto.Action{{
+					Name: newReplicaDataLoad,
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c",
+						`head -c 3 > /dev/null; echo 7 > "${KB_DATA_COMMIT_FILE}"; sleep 60`}},
+				}})
+				Expect(err).Should(BeNil())
+				task := &newReplicaTask{logger: logr.New(nil), actionService: actionSvc,
+					task: &proto.NewReplicaTask{Remote: "127.0.0.1", Port: port, Resumable: true}}
+				transferCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				ch, err := task.resume(transferCtx, map[string]string{newReplicaCheckpointOffset: "4"})
+				Expect(err).Should(BeNil())
+				Eventually(task.checkpoint).Should(HaveKeyWithValue(newReplicaCheckpointOffset, "7"))
+
+				By("the offset beyond the data fed is not checkpointed")
+				Expect(os.WriteFile(task.commitFile, []byte("20"), 0600)).Should(Succeed())
+				Expect(task.checkpoint()).Should(BeNil())
+
+				By("the malformed offset is not checkpointed")
+				Expect(os.WriteFile(task.commitFile, []byte("foo"), 0600)).Should(Succeed())
+				Expect(task.checkpoint()).Should(BeNil())
+
+				cancel()
+				Eventually(ch, 5*time.Second).Should(Receive(HaveOccurred()))
+			})
+
+			It("throttles the transfer", func() {
+				serve("head -c 3000 /dev/zero")
+				task := newTask(&proto.NewReplicaTask{RateLimitBytesPerSecond: 1000})
+				start := time.Now()
+				Expect(transfer(task, 0)).Should(Succeed())
+				Expect(time.Since(start)).Should(BeNumerically(">=", time.Second))
+				Expect(task.received.Load()).Should(Equal(int64(3000)))
+			})
+
+			It("times out the transfer", func() {
+				serve("sleep 60")
+				task := newTask(&proto.NewReplicaTask{TimeoutSeconds: ptr.To[int32](1)})
+				Expect(transfer(task, 0)).Should(MatchError(ContainSubstring("timed out")))
+			})
+
+			It("rejects unsupported compression", func() {
+				task := newTask(&proto.NewReplicaTask{Compression: "lz4"})
+				Expect(transfer(task, 0)).Should(MatchError(ContainSubstring("unsupported streaming compression")))
+			})
+		})
+
 		It("validates remote connection settings", func() {
 			task := &newReplicaTask{task: &proto.NewReplicaTask{Remote: "127.0.0.1"}}
 			conn, err := task.connectToRemote(ctx)
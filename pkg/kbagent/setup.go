@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -42,9 +43,17 @@ const (
 
 	DefaultHTTPPortName      = "http"
 	DefaultStreamingPortName = "streaming"
+	DefaultMetricsPortName   = "metrics"
 
 	DefaultHTTPPort      = 3501
 	DefaultStreamingPort = 3502
+	DefaultMetricsPort   = 3503
+
+	TaskJournalVolumeName = "kbagent-task-journal"
+	TaskJournalMountPath  = "/var/lib/kubeblocks/kbagent-task-journal"
+
+	AuditLogVolumeName = "kbagent-audit-log"
+	AuditLogMountPath  = "/var/lib/kubeblocks/kbagent-audit-log"
 
 	actionEnvName    = "KB_AGENT_ACTION"
 	probeEnvName     = "KB_AGENT_PROBE"
@@ -126,9 +135,35 @@ func UpdateEnv4Worker(envVars map[string]string, f func(proto.Task) *proto.Task)
 	}, nil
 }
 
+// MergeEnv4Worker merges the task into the tasks of the worker, the task of the same kind is replaced,
+// and the tasks of other kinds are kept.
+func MergeEnv4Worker(envVars map[string]string, task proto.Task) (*corev1.EnvVar, error) {
+	tasks := make([]proto.Task, 0)
+	if dt, ok := envVars[taskEnvName]; ok && len(dt) > 0 {
+		var err error
+		if tasks, err = deserializeTask(dt); err != nil {
+			return nil, err
+		}
+	}
+	tasks = slices.DeleteFunc(tasks, func(t proto.Task) bool {
+		return t.Task == task.Task
+	})
+	return BuildEnv4Worker(append(tasks, task))
+}
+
 func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	envVars := util.EnvL2M(os.Environ())
 
+	if err := util.SetReportChannel(config.ReportChannel); err != nil {
+		return false, err
+	}
+	if err := service.SetupAuditLog(config.AuditLogDir, config.AuditLogSize); err != nil {
+		return false, errors.Wrap(err, "failed to set up the audit log")
+	}
+	if err := service.SetupFileAllowedPaths(config.FileAllowedPaths); err != nil {
+		return false, errors.Wrap(err, "failed to set up the file service")
+	}
+
 	// initialize kb-agent
 	services, err := initialize(logger, envVars)
 	if err != nil {
@@ -137,7 +172,7 @@ func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	if config.Server {
 		return true, runAsServer(logger, config, services)
 	}
-	return false, runAsWorker(logger, services, envVars)
+	return false, runAsWorker(logger, config, services, envVars)
 }
 
 func initialize(logger logr.Logger, envVars map[string]string) ([]service.Service, error) {
@@ -230,7 +265,7 @@ func runAsServer(logger logr.Logger, config server.Config, services []service.Se
 	return nil
 }
 
-func runAsWorker(logger logr.Logger, services []service.Service, envVars map[string]string) error {
+func runAsWorker(logger logr.Logger, config server.Config, services []service.Service, envVars map[string]string) error {
 	dt, ok := envVars[taskEnvName]
 	if !ok || len(dt) == 0 {
 		return nil // has no task
@@ -243,7 +278,12 @@ func runAsWorker(logger logr.Logger, services []service.Service, envVars map[str
 		return err
 	}
 
-	if err := service.RunTasks(logger, actionService(services), tasks); err != nil {
+	cred, err := config.Credential()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the credential")
+	}
+
+	if err := service.RunTasks(logger, actionService(services), tasks, cred, config.TaskJournalDir); err != nil {
 		return errors.Wrap(err, "failed to run as worker")
 	}
 	return nil
@@ -130,6 +130,41 @@ func TestBuildAndUpdateEnv4Worker(t *testing.T) {
 	}
 }
 
+func TestMergeEnv4Worker(t *testing.T) {
+	taskEnv, err := BuildEnv4Worker([]proto.Task{
+		{Instance: "inst", Task: proto.TaskKindNewReplica, UID: "u1", Replicas: "pod-2"},
+		{Instance: "inst", Task: proto.TaskKindCompactStorage, UID: "u2", Replicas: "pod-0"},
+	})
+	if err != nil {
+		t.Fatalf("BuildEnv4Worker() error = %v", err)
+	}
+
+	merged, err := MergeEnv4Worker(map[string]string{taskEnvName: taskEnv.Value},
+		proto.Task{Instance: "inst", Task: proto.TaskKindCompactStorage, UID: "u3", Replicas: "pod-1"})
+	if err != nil {
+		t.Fatalf("MergeEnv4Worker() error = %v", err)
+	}
+	tasks, err := deserializeTask(merged.Value)
+	if err != nil {
+		t.Fatalf("deserialize merged tasks: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].UID != "u1" || tasks[1].UID != "u3" {
+		t.Fatalf("unexpected merged tasks: %#v", tasks)
+	}
+
+	merged, err = MergeEnv4Worker(nil, proto.Task{Instance: "inst", Task: proto.TaskKindVerifyChecksum, UID: "u4"})
+	if err != nil {
+		t.Fatalf("MergeEnv4Worker(nil) error = %v", err)
+	}
+	if tasks, err = deserializeTask(merged.Value); err != nil || len(tasks) != 1 || tasks[0].UID != "u4" {
+		t.Fatalf("unexpected merged tasks: %#v, %v", tasks, err)
+	}
+
+	if merged, err = MergeEnv4Worker(map[string]string{taskEnvName: "{"}, proto.Task{}); merged != nil || err == nil {
+		t.Fatalf("expected invalid task env error, got %#v, %v", merged, err)
+	}
+}
+
 func TestInitializeAndEnvAccessors(t *testing.T) {
 	logger := ktesting.NewLogger(t, ktesting.NewConfig())
 	actionsEnv, _, err := serializeActionNProbe([]proto.Action{{Name: "dump", Exec: &proto.ExecAction{Commands: []string{"echo"}}}}, nil)
@@ -210,10 +245,10 @@ func TestRunAsServerStartsHTTPWithNoStreamingService(t *testing.T) {
 
 func TestRunAsWorkerStableBranches(t *testing.T) {
 	logger := ktesting.NewLogger(t, ktesting.NewConfig())
-	if err := runAsWorker(logger, nil, nil); err != nil {
+	if err := runAsWorker(logger, server.Config{}, nil, nil); err != nil {
 		t.Fatalf("runAsWorker(nil env) error = %v", err)
 	}
-	if err := runAsWorker(logger, nil, map[string]string{taskEnvName: "{"}); err == nil {
+	if err := runAsWorker(logger, server.Config{}, nil, map[string]string{taskEnvName: "{"}); err == nil {
 		t.Fatalf("expected invalid task error")
 	}
 }
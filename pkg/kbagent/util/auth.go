@@ -0,0 +1,349 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	AuthTokenKey  = "token"
+	AuthCAFileKey = "ca.crt"
+	AuthCertKey   = "tls.crt"
+	AuthKeyKey    = "tls.key"
+	// AuthCAKeyKey holds the private key of the CA, it is kept in the secret to renew the certificate
+	// but is never mounted into the pods.
+	AuthCAKeyKey = "ca.key"
+
+	// TLSServerName is the name that the kb-agent serving certificate is issued for.
+	// Clients connect to kb-agent by pod IP, so they verify the certificate against this name instead.
+	TLSServerName = "kbagent"
+
+	BearerPrefix = "Bearer "
+
+	authTokenLength = 32
+
+	authCAValidity    = 10 * 365 * 24 * time.Hour
+	authCertValidity  = 365 * 24 * time.Hour
+	authCertRenewLead = 30 * 24 * time.Hour
+)
+
+// Credential holds the shared secrets used by kb-agent servers and their clients.
+// All fields are optional, an empty token disables the token check, and an empty cert/key disables TLS.
+type Credential struct {
+	Token  string
+	CACert []byte
+	CAKey  []byte
+	Cert   []byte
+	Key    []byte
+}
+
+func (c *Credential) TokenEnabled() bool {
+	return c != nil && len(c.Token) > 0
+}
+
+func (c *Credential) TLSEnabled() bool {
+	return c != nil && len(c.Cert) > 0 && len(c.Key) > 0
+}
+
+// VerifyToken checks the token in constant time, it always succeeds if the token check is disabled.
+func (c *Credential) VerifyToken(token string) bool {
+	if !c.TokenEnabled() {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(c.Token), []byte(token)) == 1
+}
+
+// VerifyBearer checks the value of an HTTP Authorization header.
+func (c *Credential) VerifyBearer(header string) bool {
+	if !c.TokenEnabled() {
+		return true
+	}
+	if !strings.HasPrefix(header, BearerPrefix) {
+		return false
+	}
+	return c.VerifyToken(strings.TrimPrefix(header, BearerPrefix))
+}
+
+// ServerTLSConfig returns the TLS config for servers, client certificates are required if the CA is provided.
+func (c *Credential) ServerTLSConfig() (*tls.Config, error) {
+	if !c.TLSEnabled() {
+		return nil, nil
+	}
+	cert, err := tls.X509KeyPair(c.Cert, c.Key)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(c.CACert) > 0 {
+		pool, err := c.certPool()
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ClientTLSConfig returns the TLS config for clients, the certificate is presented to the server for mTLS.
+func (c *Credential) ClientTLSConfig() (*tls.Config, error) {
+	if !c.TLSEnabled() {
+		return nil, nil
+	}
+	cert, err := tls.X509KeyPair(c.Cert, c.Key)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   TLSServerName,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(c.CACert) > 0 {
+		pool, err := c.certPool()
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func (c *Credential) certPool() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(c.CACert) {
+		return nil, fmt.Errorf("failed to parse the CA certificate")
+	}
+	return pool, nil
+}
+
+// LoadCredential loads the credential from files, empty paths are skipped.
+func LoadCredential(tokenFile, caFile, certFile, keyFile string) (*Credential, error) {
+	read := func(path string) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, nil
+		}
+		return os.ReadFile(path)
+	}
+	cred := &Credential{}
+	token, err := read(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	cred.Token = strings.TrimSpace(string(token))
+	if cred.CACert, err = read(caFile); err != nil {
+		return nil, err
+	}
+	if cred.Cert, err = read(certFile); err != nil {
+		return nil, err
+	}
+	if cred.Key, err = read(keyFile); err != nil {
+		return nil, err
+	}
+	if (len(cred.Cert) == 0) != (len(cred.Key) == 0) {
+		return nil, fmt.Errorf("the TLS certificate and key should be provided together")
+	}
+	return cred, nil
+}
+
+// CredentialFromData builds the credential from the data of the kb-agent auth secret.
+func CredentialFromData(data map[string][]byte) *Credential {
+	return &Credential{
+		Token:  strings.TrimSpace(string(data[AuthTokenKey])),
+		CACert: data[AuthCAFileKey],
+		CAKey:  data[AuthCAKeyKey],
+		Cert:   data[AuthCertKey],
+		Key:    data[AuthKeyKey],
+	}
+}
+
+// Data returns the credential as the data of the kb-agent auth secret.
+func (c *Credential) Data() map[string][]byte {
+	data := map[string][]byte{
+		AuthTokenKey:  []byte(c.Token),
+		AuthCAFileKey: c.CACert,
+		AuthCertKey:   c.Cert,
+		AuthKeyKey:    c.Key,
+	}
+	if len(c.CAKey) > 0 {
+		data[AuthCAKeyKey] = c.CAKey
+	}
+	return data
+}
+
+// GenerateCredential generates a random token, a self-signed CA and a certificate signed by it.
+// The certificate is shared by the servers and clients of a component, it is valid for both server and client auth.
+func GenerateCredential(commonName string) (*Credential, error) {
+	token := make([]byte, authTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	cred := &Credential{Token: hex.EncodeToString(token)}
+	if err := cred.issueCA(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := cred.issueCert(commonName, time.Now()); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// RenewCredential renews the certificate if it is about to expire, and returns nil if no renewal is needed.
+// The certificate is re-issued by the kept CA, the token and CA are preserved so that the servers and clients
+// can pick up the new certificate independently. The CA is re-generated as well if its key is not kept
+// or the CA itself is about to expire.
+func RenewCredential(cred *Credential, commonName string, now time.Time) (*Credential, error) {
+	ca, _, caErr := cred.ca()
+	cert, certErr := parseCertificate(cred.Cert)
+	switch {
+	case caErr == nil && certErr == nil && now.Add(authCertRenewLead).Before(cert.NotAfter):
+		return nil, nil
+	case caErr == nil && now.Add(authCertValidity).Before(ca.NotAfter):
+		renewed := *cred
+		if err := renewed.issueCert(commonName, now); err != nil {
+			return nil, err
+		}
+		return &renewed, nil
+	default:
+		renewed := &Credential{Token: cred.Token}
+		if err := renewed.issueCA(now); err != nil {
+			return nil, err
+		}
+		if err := renewed.issueCert(commonName, now); err != nil {
+			return nil, err
+		}
+		return renewed, nil
+	}
+}
+
+func (c *Credential) issueCA(now time.Time) error {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	notBefore := now.Add(-time.Hour)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          newSerialNumber(),
+		Subject:               pkix.Name{CommonName: "KubeBlocks kb-agent CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(authCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	caKeyDER, err := x509.MarshalECPrivateKey(caKey)
+	if err != nil {
+		return err
+	}
+	c.CACert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	c.CAKey = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: caKeyDER})
+	return nil
+}
+
+func (c *Credential) issueCert(commonName string, now time.Time) error {
+	ca, caKey, err := c.ca()
+	if err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	notBefore := now.Add(-time.Hour)
+	notAfter := notBefore.Add(authCertValidity)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: newSerialNumber(),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{TLSServerName, "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	c.Cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	c.Key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return nil
+}
+
+func (c *Credential) ca() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	ca, err := parseCertificate(c.CACert)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(c.CAKey)
+	if block == nil {
+		return nil, nil, fmt.Errorf("failed to parse the CA key")
+	}
+	caKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, caKey, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse the certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newSerialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return serial
+}
@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCredentialToken(t *testing.T) {
+	var nilCred *Credential
+	if nilCred.TokenEnabled() || nilCred.TLSEnabled() {
+		t.Fatalf("nil credential should disable token and TLS")
+	}
+	if !nilCred.VerifyBearer("") {
+		t.Fatalf("nil credential should accept any request")
+	}
+
+	cred := &Credential{Token: "secret"}
+	if !cred.VerifyBearer("Bearer secret") {
+		t.Fatalf("expected the bearer token to be accepted")
+	}
+	for _, header := range []string{"", "secret", "Bearer other", "Basic secret"} {
+		if cred.VerifyBearer(header) {
+			t.Fatalf("expected header %q to be rejected", header)
+		}
+	}
+}
+
+func TestGenerateCredentialMTLS(t *testing.T) {
+	cred, err := GenerateCredential("test-comp")
+	if err != nil {
+		t.Fatalf("GenerateCredential() error = %v", err)
+	}
+	if len(cred.Token) != 2*authTokenLength || !cred.TLSEnabled() || len(cred.CACert) == 0 {
+		t.Fatalf("unexpected credential: %+v", cred)
+	}
+
+	restored := CredentialFromData(cred.Data())
+	if restored.Token != cred.Token || string(restored.Cert) != string(cred.Cert) {
+		t.Fatalf("credential is not restored from the secret data")
+	}
+
+	serverConfig, err := cred.ServerTLSConfig()
+	if err != nil {
+		t.Fatalf("ServerTLSConfig() error = %v", err)
+	}
+	if serverConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatalf("client certificates should be required")
+	}
+	clientConfig, err := cred.ClientTLSConfig()
+	if err != nil {
+		t.Fatalf("ClientTLSConfig() error = %v", err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatalf("listen error = %v", err)
+	}
+	defer listener.Close()
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			accepted <- err
+			return
+		}
+		defer conn.Close()
+		accepted <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err != nil {
+		t.Fatalf("dial with the client certificate error = %v", err)
+	}
+	_ = conn.Close()
+	if err = <-accepted; err != nil {
+		t.Fatalf("server handshake error = %v", err)
+	}
+
+	// the server rejects clients without certificates
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	noCertConfig := clientConfig.Clone()
+	noCertConfig.Certificates = nil
+	raw, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial error = %v", err)
+	}
+	defer raw.Close()
+	tlsConn := tls.Client(raw, noCertConfig)
+	if err = tlsConn.Handshake(); err == nil {
+		buf := make([]byte, 1)
+		if _, err = tlsConn.Read(buf); err == nil {
+			t.Fatalf("expected the connection without client certificate to be rejected")
+		}
+	}
+}
+
+func TestLoadCredential(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("write file error = %v", err)
+		}
+		return path
+	}
+
+	cred, err := LoadCredential(write(AuthTokenKey, "token\n"), "", "", "")
+	if err != nil {
+		t.Fatalf("LoadCredential() error = %v", err)
+	}
+	if cred.Token != "token" || cred.TLSEnabled() {
+		t.Fatalf("unexpected credential: %+v", cred)
+	}
+
+	if _, err = LoadCredential("", "", write(AuthCertKey, "cert"), ""); err == nil {
+		t.Fatalf("expected error when the TLS key is missing")
+	}
+	if _, err = LoadCredential(filepath.Join(dir, "missing"), "", "", ""); err == nil {
+		t.Fatalf("expected error when the token file is missing")
+	}
+}
+
+func TestRenewCredential(t *testing.T) {
+	cred, err := GenerateCredential("test-comp")
+	if err != nil {
+		t.Fatalf("GenerateCredential() error = %v", err)
+	}
+	cert, err := parseCertificate(cred.Cert)
+	if err != nil {
+		t.Fatalf("parse certificate error = %v", err)
+	}
+	if cert.NotAfter.Sub(cert.NotBefore) > authCertValidity {
+		t.Fatalf("certificate validity %v exceeds %v", cert.NotAfter.Sub(cert.NotBefore), authCertValidity)
+	}
+
+	now := time.Now()
+	if renewed, err := RenewCredential(cred, "test-comp", now); err != nil || renewed != nil {
+		t.Fatalf("expected no renewal, renewed = %v, error = %v", renewed, err)
+	}
+
+	// the certificate is about to expire, it is re-issued by the same CA
+	renewed, err := RenewCredential(cred, "test-comp", cert.NotAfter.Add(-time.Hour))
+	if err != nil || renewed == nil {
+		t.Fatalf("expected the certificate to be renewed, error = %v", err)
+	}
+	if renewed.Token != cred.Token || string(renewed.CACert) != string(cred.CACert) || string(renewed.CAKey) != string(cred.CAKey) {
+		t.Fatalf("the token and CA should be kept")
+	}
+	if string(renewed.Cert) == string(cred.Cert) {
+		t.Fatalf("the certificate should be re-issued")
+	}
+	pool, err := cred.certPool()
+	if err != nil {
+		t.Fatalf("cert pool error = %v", err)
+	}
+	renewedCert, _ := parseCertificate(renewed.Cert)
+	if _, err = renewedCert.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		DNSName:     TLSServerName,
+		CurrentTime: cert.NotAfter,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		t.Fatalf("the renewed certificate is not verified by the CA: %v", err)
+	}
+
+	// the CA key is not kept, both the CA and certificate are re-generated
+	legacy := &Credential{Token: cred.Token, CACert: cred.CACert, Cert: cred.Cert, Key: cred.Key}
+	renewed, err = RenewCredential(legacy, "test-comp", now)
+	if err != nil || renewed == nil {
+		t.Fatalf("expected the credential to be re-generated, error = %v", err)
+	}
+	if renewed.Token != cred.Token || string(renewed.CACert) == string(cred.CACert) || len(renewed.CAKey) == 0 {
+		t.Fatalf("expected a new CA with the token kept")
+	}
+	if _, ok := renewed.Data()[AuthCAKeyKey]; !ok {
+		t.Fatalf("the CA key should be kept in the secret data")
+	}
+}
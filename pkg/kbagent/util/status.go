@@ -0,0 +1,272 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var reportChannel = proto.ReportChannelEvent
+
+// SetReportChannel sets the channel to report the probe and task results, events are used by default.
+func SetReportChannel(channel string) error {
+	switch channel {
+	case "", proto.ReportChannelEvent:
+		reportChannel = proto.ReportChannelEvent
+	case proto.ReportChannelStatus:
+		reportChannel = proto.ReportChannelStatus
+	default:
+		return fmt.Errorf("unknown report channel: %s", channel)
+	}
+	return nil
+}
+
+func StatusReportEnabled() bool {
+	return reportChannel == proto.ReportChannelStatus
+}
+
+// SendStatusWithMessage records the message as the latest record of the key in the status object of the pod.
+// Unlike events, the records are neither aggregated nor deduplicated, and the latest one of each key is always kept.
+func SendStatusWithMessage(logger *logr.Logger, key, reason, message string, sync bool) error {
+	return statusQueue.send(logger, key, reason, message, sync)
+}
+
+var statusQueue = newStatusSendQueue(patchStatusRecord)
+
+// statusSendQueue serializes the sends of each key, so that a stale record never overwrites a newer one.
+// The records of a key that are waiting to be sent are coalesced, and only the latest one is sent.
+type statusSendQueue struct {
+	patch   func(key, reason, message string, retryInterval time.Duration, retryAttempts int32) error
+	mu      sync.Mutex
+	pending map[string]*statusSend
+	running map[string]bool
+}
+
+type statusSend struct {
+	logger  *logr.Logger
+	reason  string
+	message string
+	sync    bool
+	waiters []chan error
+}
+
+func newStatusSendQueue(patch func(string, string, string, time.Duration, int32) error) *statusSendQueue {
+	return &statusSendQueue{
+		patch:   patch,
+		pending: make(map[string]*statusSend),
+		running: make(map[string]bool),
+	}
+}
+
+func (q *statusSendQueue) send(logger *logr.Logger, key, reason, message string, sync bool) error {
+	s := &statusSend{logger: logger, reason: reason, message: message, sync: sync}
+	var ch chan error
+	if sync {
+		ch = make(chan error, 1)
+		s.waiters = append(s.waiters, ch)
+	}
+
+	q.mu.Lock()
+	if prev, ok := q.pending[key]; ok {
+		// the previous record is superseded, its waiters get the result of the latest one
+		s.waiters = append(prev.waiters, s.waiters...)
+		s.sync = s.sync || prev.sync
+	}
+	q.pending[key] = s
+	if !q.running[key] {
+		q.running[key] = true
+		go q.drain(key)
+	}
+	q.mu.Unlock()
+
+	if ch == nil {
+		return nil
+	}
+	return <-ch
+}
+
+func (q *statusSendQueue) drain(key string) {
+	for {
+		q.mu.Lock()
+		s, ok := q.pending[key]
+		if !ok {
+			delete(q.running, key)
+			q.mu.Unlock()
+			return
+		}
+		delete(q.pending, key)
+		q.mu.Unlock()
+
+		if sent, err := q.sendWithRetry(key, s); sent {
+			for _, ch := range s.waiters {
+				ch <- err
+			}
+		}
+	}
+}
+
+// sendWithRetry patches the record with retries, it gives up once a newer record of the key is pending,
+// and the waiters are handed over to the newer one.
+func (q *statusSendQueue) sendWithRetry(key string, s *statusSend) (bool, error) {
+	retryInterval, retryAttempts := defaultRetryInterval, int32(defaultMaxRetryAttempts)
+	if s.sync {
+		retryInterval, retryAttempts = syncRetryInterval, syncMaxRetryAttempts
+	}
+	var err error
+	for i := int32(0); i < retryAttempts; i++ {
+		if err = q.patch(key, s.reason, s.message, 0, 1); err == nil {
+			return true, nil
+		}
+		if s.logger != nil {
+			s.logger.Error(err, "failed to patch status record", "key", key, "reason", s.reason, "message", s.message)
+		}
+		if q.handOver(key, s) {
+			return false, nil
+		}
+		if i < retryAttempts-1 {
+			time.Sleep(retryInterval)
+		}
+	}
+	return true, err
+}
+
+func (q *statusSendQueue) handOver(key string, s *statusSend) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	next, ok := q.pending[key]
+	if ok {
+		next.waiters = append(s.waiters, next.waiters...)
+		next.sync = next.sync || s.sync
+	}
+	return ok
+}
+
+func patchStatusRecord(key, reason, message string, retryInterval time.Duration, retryAttempts int32) error {
+	clientSet, err := getK8sClientSet()
+	if err != nil {
+		return err
+	}
+	return patchStatusRecordWithClient(clientSet, key, reason, message, retryInterval, retryAttempts)
+}
+
+func patchStatusRecordWithClient(cli kubernetes.Interface, key, reason, message string, retryInterval time.Duration, retryAttempts int32) error {
+	record, err := json.Marshal(proto.StatusRecord{
+		Reason:  reason,
+		Message: message,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	annotations := map[string]string{
+		proto.StatusRecordAnnotationPrefix + statusRecordKey(key): string(record),
+	}
+	// merge patch only touches the record of the key, the records of others and the controller are kept
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": annotations,
+		},
+		"spec": map[string]any{
+			"renewTime": metav1.NowMicro(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	leasesClient := cli.CoordinationV1().Leases(namespace())
+	name := proto.StatusObjectName(podName())
+	attempts := max(retryAttempts, 1)
+	for i := int32(0); i < attempts; i++ {
+		_, err = leasesClient.Patch(context.Background(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = leasesClient.Create(context.Background(), newStatusObject(name, annotations), metav1.CreateOptions{})
+		}
+		if err == nil {
+			return nil
+		}
+		if retryInterval > 0 && i < attempts-1 {
+			time.Sleep(retryInterval)
+		}
+	}
+	return errors.Wrapf(err, "failed to patch status record after %d attempts", retryAttempts)
+}
+
+// statusRecordKey makes the key a valid name of annotation.
+func statusRecordKey(key string) string {
+	const maxLength = 63
+	name := []byte(key)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			name[i] = '-'
+		}
+	}
+	if len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return strings.Trim(string(name), "-_.")
+}
+
+func newStatusObject(name string, annotations map[string]string) *coordinationv1.Lease {
+	holder := podName()
+	now := metav1.NowMicro()
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace(),
+			Labels: map[string]string{
+				proto.StatusObjectLabelKey: holder,
+			},
+			Annotations: annotations,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: &holder,
+			AcquireTime:    &now,
+			RenewTime:      &now,
+		},
+	}
+	// the status object is garbage collected with the pod
+	if len(podUID()) > 0 {
+		lease.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       holder,
+				UID:        types.UID(podUID()),
+			},
+		}
+	}
+	return lease
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func TestSetReportChannel(t *testing.T) {
+	defer func() { _ = SetReportChannel(proto.ReportChannelEvent) }()
+
+	if err := SetReportChannel(""); err != nil || StatusReportEnabled() {
+		t.Fatalf("expected the event channel by default, err: %v", err)
+	}
+	if err := SetReportChannel(proto.ReportChannelStatus); err != nil || !StatusReportEnabled() {
+		t.Fatalf("expected the status channel, err: %v", err)
+	}
+	if err := SetReportChannel("unknown"); err == nil {
+		t.Fatalf("expected unknown report channel error")
+	}
+}
+
+func TestPatchStatusRecord(t *testing.T) {
+	t.Setenv(kbEnvNamespace, "ns")
+	t.Setenv(kbEnvPodName, "pod")
+	t.Setenv(kbEnvPodUID, "uid")
+
+	cli := fake.NewSimpleClientset()
+	if err := patchStatusRecordWithClient(cli, "roleProbe", "roleProbe", "leader", 0, 1); err != nil {
+		t.Fatalf("failed to create the status object: %v", err)
+	}
+	if err := patchStatusRecordWithClient(cli, "task.u1", "task", "done", 0, 1); err != nil {
+		t.Fatalf("failed to patch the status object: %v", err)
+	}
+	if err := patchStatusRecordWithClient(cli, "roleProbe", "roleProbe", "follower", 0, 1); err != nil {
+		t.Fatalf("failed to patch the status object: %v", err)
+	}
+
+	lease, err := cli.CoordinationV1().Leases("ns").Get(context.Background(), proto.StatusObjectName("pod"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the status object: %v", err)
+	}
+	if lease.Labels[proto.StatusObjectLabelKey] != "pod" || *lease.Spec.HolderIdentity != "pod" {
+		t.Fatalf("unexpected status object: %#v", lease.ObjectMeta)
+	}
+	if len(lease.OwnerReferences) != 1 || lease.OwnerReferences[0].Kind != "Pod" || lease.OwnerReferences[0].UID != "uid" {
+		t.Fatalf("unexpected owner references: %#v", lease.OwnerReferences)
+	}
+
+	records := map[string]string{}
+	for key, reason := range map[string]string{"roleProbe": "roleProbe", "task.u1": "task"} {
+		record := &proto.StatusRecord{}
+		if err := json.Unmarshal([]byte(lease.Annotations[proto.StatusRecordAnnotationPrefix+key]), record); err != nil {
+			t.Fatalf("failed to unmarshal the record of %s: %v", key, err)
+		}
+		if record.Reason != reason || record.Time.IsZero() {
+			t.Fatalf("unexpected record of %s: %#v", key, record)
+		}
+		records[key] = record.Message
+	}
+	if records["roleProbe"] != "follower" || records["task.u1"] != "done" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+
+	if key := statusRecordKey("task./u1:"); key != "task.-u1" {
+		t.Fatalf("unexpected record key: %s", key)
+	}
+}
+
+func TestStatusSendQueue(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		sent    []string
+		started = make(chan struct{})
+		blocked = make(chan struct{})
+		failed  = true
+	)
+	q := newStatusSendQueue(func(key, reason, message string, _ time.Duration, _ int32) error {
+		if message == "m0" {
+			close(started)
+			<-blocked
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if message == "m-fail" && failed {
+			failed = false
+			return errors.New("conflict")
+		}
+		sent = append(sent, key+"="+message)
+		return nil
+	})
+
+	// the sends of a key are serialized, the records waiting are coalesced to the latest one
+	if err := q.send(nil, "roleProbe", "roleProbe", "m0", false); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	<-started
+	for _, message := range []string{"m1", "m2", "m3"} {
+		if err := q.send(nil, "roleProbe", "roleProbe", message, false); err != nil {
+			t.Fatalf("send error: %v", err)
+		}
+	}
+	done := make(chan error, 1)
+	go func() { done <- q.send(nil, "roleProbe", "roleProbe", "m4", true) }()
+	// the sends of other keys are not blocked
+	if err := q.send(nil, "task.u1", "task", "done", true); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	for pending := ""; pending != "m4"; time.Sleep(10 * time.Millisecond) {
+		q.mu.Lock()
+		if s, ok := q.pending["roleProbe"]; ok {
+			pending = s.message
+		}
+		q.mu.Unlock()
+	}
+	close(blocked)
+	if err := <-done; err != nil {
+		t.Fatalf("sync send error: %v", err)
+	}
+
+	mu.Lock()
+	got := slices.Clone(sent)
+	mu.Unlock()
+	if !slices.Equal(got, []string{"task.u1=done", "roleProbe=m0", "roleProbe=m4"}) {
+		t.Fatalf("unexpected sends: %v", got)
+	}
+
+	// the failed send is retried
+	if err := q.send(nil, "roleProbe", "roleProbe", "m-fail", true); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if sent[len(sent)-1] != "roleProbe=m-fail" {
+		t.Fatalf("unexpected sends: %v", sent)
+	}
+}
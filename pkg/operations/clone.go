@@ -0,0 +1,465 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/sharding"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+const cloneRequeueAfter = 5 * time.Second
+
+// cloneSteps are the steps of the Clone operation, in the order they are performed.
+var cloneSteps = []opsv1alpha1.CloneStepName{
+	opsv1alpha1.CloneStepResolveBackup,
+	opsv1alpha1.CloneStepCreateCluster,
+	opsv1alpha1.CloneStepRenderParameters,
+	opsv1alpha1.CloneStepRestoreData,
+	opsv1alpha1.CloneStepClusterReady,
+}
+
+// cloneStepHandler performs a step of the Clone operation, it returns true if the step is done.
+type cloneStepHandler func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error)
+
+type cloneOpsHandler struct{}
+
+var _ OpsHandler = cloneOpsHandler{}
+
+func init() {
+	cloneBehaviour := OpsBehaviour{
+		OpsHandler: cloneOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.CloneType, cloneBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the clone request.
+func (c cloneOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewCloneCondition(opsRes.OpsRequest), nil
+}
+
+// Action initializes the steps of the clone, the steps are performed in ReconcileAction.
+func (c cloneOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Spec.Clone == nil {
+		return intctrlutil.NewFatalError("spec.clone can not be empty")
+	}
+	c.initCloneStatus(opsRes.OpsRequest)
+	return nil
+}
+
+// ReconcileAction performs the steps of the clone one by one and records the progress of each step.
+func (c cloneOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	oldOpsRequestStatus := opsRequest.Status.DeepCopy()
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+
+	c.initCloneStatus(opsRequest)
+	phase, err := c.reconcileSteps(reqCtx, cli, opsRes)
+
+	var completedCount int
+	for _, step := range opsRequest.Status.Clone.Steps {
+		if step.Status == opsv1alpha1.SucceedProgressStatus {
+			completedCount++
+		}
+	}
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, len(opsRequest.Status.Clone.Steps))
+	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
+		if patchErr := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); patchErr != nil {
+			return opsv1alpha1.OpsRunningPhase, 0, patchErr
+		}
+	}
+	if err != nil || phase != opsv1alpha1.OpsRunningPhase {
+		return phase, 0, err
+	}
+	return phase, cloneRequeueAfter, nil
+}
+
+// SaveLastConfiguration saves last configuration to the OpsRequest.status.lastConfiguration
+func (c cloneOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+func (c cloneOpsHandler) initCloneStatus(opsRequest *opsv1alpha1.OpsRequest) {
+	if opsRequest.Status.Clone == nil {
+		opsRequest.Status.Clone = &opsv1alpha1.CloneStatus{}
+	}
+	if len(opsRequest.Status.Clone.Steps) > 0 {
+		return
+	}
+	for _, name := range cloneSteps {
+		opsRequest.Status.Clone.Steps = append(opsRequest.Status.Clone.Steps, opsv1alpha1.CloneStep{
+			Name:   name,
+			Status: opsv1alpha1.PendingProgressStatus,
+		})
+	}
+}
+
+func (c cloneOpsHandler) stepHandler(name opsv1alpha1.CloneStepName) cloneStepHandler {
+	switch name {
+	case opsv1alpha1.CloneStepResolveBackup:
+		return c.resolveBackup
+	case opsv1alpha1.CloneStepCreateCluster:
+		return c.createCluster
+	case opsv1alpha1.CloneStepRenderParameters:
+		return c.renderParameters
+	case opsv1alpha1.CloneStepRestoreData:
+		return c.waitForDataRestored
+	case opsv1alpha1.CloneStepClusterReady:
+		return c.waitForClusterReady
+	}
+	return nil
+}
+
+func (c cloneOpsHandler) reconcileSteps(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, error) {
+	steps := opsRes.OpsRequest.Status.Clone.Steps
+	for i := range steps {
+		step := &steps[i]
+		switch step.Status {
+		case opsv1alpha1.SucceedProgressStatus:
+			continue
+		case opsv1alpha1.FailedProgressStatus:
+			return opsv1alpha1.OpsFailedPhase, intctrlutil.NewFatalError(fmt.Sprintf("clone step %s failed: %s", step.Name, step.Message))
+		case opsv1alpha1.PendingProgressStatus:
+			step.Status = opsv1alpha1.ProcessingProgressStatus
+			step.StartTime = metav1.Now()
+		}
+		handler := c.stepHandler(step.Name)
+		if handler == nil {
+			return opsv1alpha1.OpsFailedPhase, intctrlutil.NewFatalError(fmt.Sprintf("unknown clone step: %s", step.Name))
+		}
+		done, err := handler(reqCtx, cli, opsRes)
+		if err != nil {
+			step.Message = err.Error()
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				step.Status = opsv1alpha1.FailedProgressStatus
+				step.EndTime = metav1.Now()
+				return opsv1alpha1.OpsFailedPhase, err
+			}
+			return opsv1alpha1.OpsRunningPhase, err
+		}
+		if !done {
+			return opsv1alpha1.OpsRunningPhase, nil
+		}
+		step.Status = opsv1alpha1.SucceedProgressStatus
+		step.Message = ""
+		step.EndTime = metav1.Now()
+	}
+	return opsv1alpha1.OpsSucceedPhase, nil
+}
+
+// resolveBackup resolves the Backup of the source Cluster to clone the data from.
+func (c cloneOpsHandler) resolveBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	opsRequest := opsRes.OpsRequest
+	cloneSpec := opsRequest.Spec.Clone
+	var backup *dpv1alpha1.Backup
+	if cloneSpec.BackupName != "" {
+		backup = &dpv1alpha1.Backup{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: cloneSpec.BackupName, Namespace: opsRequest.Namespace}, backup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s not found in namespace %s", cloneSpec.BackupName, opsRequest.Namespace))
+			}
+			return false, err
+		}
+		if backup.Labels[constant.AppInstanceLabelKey] != opsRequest.Spec.GetClusterName() {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s does not belong to the cluster %s", backup.Name, opsRequest.Spec.GetClusterName()))
+		}
+	} else {
+		var err error
+		if backup, err = c.getLatestBackup(reqCtx, cli, opsRequest); err != nil {
+			return false, err
+		}
+	}
+
+	restorePointInTime := ""
+	if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+		if cloneSpec.RestorePointInTime == "" {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s is a continuous backup, spec.clone.restorePointInTime is required", backup.Name))
+		}
+		restoreTime, err := restore.FormatRestoreTimeAndValidate(cloneSpec.RestorePointInTime, backup)
+		if err != nil {
+			return false, intctrlutil.NewFatalError(err.Error())
+		}
+		restorePointInTime = restoreTime
+	} else {
+		if cloneSpec.RestorePointInTime != "" {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s is not a continuous backup, it can not be used to clone at a point in time", backup.Name))
+		}
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s status is %s, only completed backup can be used to clone", backup.Name, backup.Status.Phase))
+		}
+	}
+	opsRequest.Status.Clone.BackupName = backup.Name
+	opsRequest.Status.Clone.RestorePointInTime = restorePointInTime
+	return true, nil
+}
+
+// getLatestBackup gets the continuous Backup of the source Cluster that covers the restore point in time if specified,
+// or the latest completed Backup otherwise.
+func (c cloneOpsHandler) getLatestBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *opsv1alpha1.OpsRequest) (*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backupList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRequest.Spec.GetClusterName()}); err != nil {
+		return nil, err
+	}
+	restorePointInTime := opsRequest.Spec.Clone.RestorePointInTime
+	var candidates []*dpv1alpha1.Backup
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if !backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		isContinuous := backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous)
+		if restorePointInTime != "" {
+			if !isContinuous {
+				continue
+			}
+			if _, err := restore.FormatRestoreTimeAndValidate(restorePointInTime, backup); err != nil {
+				continue
+			}
+			candidates = append(candidates, backup)
+			continue
+		}
+		if !isContinuous && backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted && backup.Status.CompletionTimestamp != nil {
+			candidates = append(candidates, backup)
+		}
+	}
+	if len(candidates) == 0 {
+		if restorePointInTime != "" {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf("no continuous backup of the cluster %s covers the point in time %s", opsRequest.Spec.GetClusterName(), restorePointInTime))
+		}
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("no completed backup found for the cluster %s", opsRequest.Spec.GetClusterName()))
+	}
+	if restorePointInTime == "" {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[j].Status.CompletionTimestamp.Before(candidates[i].Status.CompletionTimestamp)
+		})
+	}
+	return candidates[0], nil
+}
+
+// createCluster creates the target Cluster from the snapshot of the source Cluster recorded in the Backup.
+func (c cloneOpsHandler) createCluster(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	opsRequest := opsRes.OpsRequest
+	backup := &dpv1alpha1.Backup{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Status.Clone.BackupName, Namespace: opsRequest.Namespace}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf("backup %s not found in namespace %s", opsRequest.Status.Clone.BackupName, opsRequest.Namespace))
+		}
+		return false, err
+	}
+	restoreSpec := &opsv1alpha1.Restore{
+		BackupName:         backup.Name,
+		BackupNamespace:    backup.Namespace,
+		RestorePointInTime: opsRequest.Status.Clone.RestorePointInTime,
+	}
+	cluster, err := RestoreOpsHandler{}.buildClusterFromBackup(backup, opsRequest.Spec.Clone.TargetClusterName, opsRequest.Namespace, restoreSpec)
+	if err != nil {
+		return false, err
+	}
+	if err = c.applyComponentOverrides(cluster, opsRequest.Spec.Clone.Components); err != nil {
+		return false, err
+	}
+	markRestoreClusterWithOps(cluster, opsRequest)
+
+	if err = cli.Create(reqCtx.Ctx, cluster); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		existing := &appsv1.Cluster{}
+		if err = cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(cluster), existing); err != nil {
+			return false, err
+		}
+		if err = validateRestoreClusterForOps(existing, cluster, opsRequest); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// applyComponentOverrides applies the overrides of replicas and resources to the Components and Shardings of the target Cluster.
+func (c cloneOpsHandler) applyComponentOverrides(cluster *appsv1.Cluster, overrides []opsv1alpha1.CloneComponent) error {
+	for _, override := range overrides {
+		compSpec := cluster.Spec.GetComponentByName(override.ComponentName)
+		if compSpec == nil {
+			if shardingSpec := cluster.Spec.GetShardingByName(override.ComponentName); shardingSpec != nil {
+				compSpec = &shardingSpec.Template
+			}
+		}
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf("component %s not found in the backup snapshot of the cluster", override.ComponentName))
+		}
+		if override.Replicas != nil {
+			compSpec.Replicas = *override.Replicas
+		}
+		if override.Resources != nil {
+			compSpec.Resources = *override.Resources.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// renderParameters re-renders the ComponentParameters of the target Cluster with the parameters of the source Cluster.
+func (c cloneOpsHandler) renderParameters(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	target, err := c.getTargetCluster(reqCtx, cli, opsRes.OpsRequest)
+	if err != nil {
+		return false, err
+	}
+	source := opsRes.Cluster
+	for _, compSpec := range target.Spec.ComponentSpecs {
+		done, err := c.copyComponentParameters(reqCtx, cli, source, compSpec.Name, target, []string{compSpec.Name})
+		if err != nil || !done {
+			return false, err
+		}
+	}
+	for _, shardingSpec := range target.Spec.Shardings {
+		sourceShards, err := sharding.ListShardingComponents(reqCtx.Ctx, cli, source, shardingSpec.Name)
+		if err != nil {
+			return false, err
+		}
+		if len(sourceShards) == 0 {
+			continue
+		}
+		sourceCompName, err := component.ShortName(source.Name, sourceShards[0].Name)
+		if err != nil {
+			return false, err
+		}
+		// wait for all the shards of the target Cluster to be created
+		targetShards, err := sharding.ListShardingComponents(reqCtx.Ctx, cli, target, shardingSpec.Name)
+		if err != nil {
+			return false, err
+		}
+		if len(targetShards) != int(shardingSpec.Shards) {
+			return false, nil
+		}
+		targetCompNames := make([]string, 0, len(targetShards))
+		for _, shard := range targetShards {
+			shortName, err := component.ShortName(target.Name, shard.Name)
+			if err != nil {
+				return false, err
+			}
+			targetCompNames = append(targetCompNames, shortName)
+		}
+		done, err := c.copyComponentParameters(reqCtx, cli, source, sourceCompName, target, targetCompNames)
+		if err != nil || !done {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// copyComponentParameters copies the desired parameters of the source Component to the target Components,
+// it returns false if the ComponentParameters of the target Components are not created yet.
+func (c cloneOpsHandler) copyComponentParameters(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	source *appsv1.Cluster, sourceCompName string, target *appsv1.Cluster, targetCompNames []string) (bool, error) {
+	reAction := reconfigureAction{}
+	sourceParam, err := reAction.getRunningComponentParameter(reqCtx.Ctx, cli, source.Namespace, source.Name, sourceCompName)
+	if err != nil {
+		// the component has no parameters to render
+		return true, client.IgnoreNotFound(err)
+	}
+	if sourceParam.Spec.Desired == nil {
+		return true, nil
+	}
+	for _, compName := range targetCompNames {
+		targetParam, err := reAction.getRunningComponentParameter(reqCtx.Ctx, cli, target.Namespace, target.Name, compName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if reflect.DeepEqual(targetParam.Spec.Desired, sourceParam.Spec.Desired) {
+			continue
+		}
+		patch := client.MergeFrom(targetParam.DeepCopy())
+		targetParam.Spec.Desired = sourceParam.Spec.Desired.DeepCopy()
+		if err = cli.Patch(reqCtx.Ctx, targetParam, patch); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// waitForDataRestored waits for the data of the target Cluster to be restored.
+func (c cloneOpsHandler) waitForDataRestored(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	target, err := c.getTargetCluster(reqCtx, cli, opsRes.OpsRequest)
+	if err != nil {
+		return false, err
+	}
+	restoreCond := meta.FindStatusCondition(target.Status.Conditions, appsv1.ConditionTypeRestore)
+	if restoreCond == nil || restoreCond.Status == metav1.ConditionUnknown {
+		return false, nil
+	}
+	if restoreCond.Status == metav1.ConditionFalse {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf("restore failed: %s", restoreCond.Message))
+	}
+	return true, nil
+}
+
+// waitForClusterReady waits for the target Cluster to be running.
+func (c cloneOpsHandler) waitForClusterReady(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	target, err := c.getTargetCluster(reqCtx, cli, opsRes.OpsRequest)
+	if err != nil {
+		return false, err
+	}
+	switch target.Status.Phase {
+	case appsv1.RunningClusterPhase:
+		return true, nil
+	case appsv1.FailedClusterPhase:
+		return false, intctrlutil.NewFatalError(fmt.Sprintf("target cluster %s/%s failed", target.Namespace, target.Name))
+	}
+	return false, nil
+}
+
+func (c cloneOpsHandler) getTargetCluster(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *opsv1alpha1.OpsRequest) (*appsv1.Cluster, error) {
+	target := &appsv1.Cluster{}
+	key := client.ObjectKey{Name: opsRequest.Spec.Clone.TargetClusterName, Namespace: opsRequest.Namespace}
+	if err := cli.Get(reqCtx.Ctx, key, target); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf("target cluster %s/%s not found", key.Namespace, key.Name))
+		}
+		return nil, err
+	}
+	if target.IsDeleting() {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("target cluster %s/%s is being deleted", target.Namespace, target.Name))
+	}
+	if err := validateRestoreClusterOpsUID(target, opsRequest); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
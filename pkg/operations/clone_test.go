@@ -0,0 +1,241 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	parameterscore "github.com/apecloud/kubeblocks/pkg/parameters/core"
+)
+
+var _ = Describe("Clone OpsRequest", func() {
+	var (
+		randomStr         = testCtx.GetRandomStr()
+		sourceClusterName = "source-cluster"
+		targetClusterName = "clone-cluster-" + randomStr
+		cloneOpsName      = "clone-ops-" + randomStr
+		reqCtx            intctrlutil.RequestCtx
+		cloneHandler      = cloneOpsHandler{}
+	)
+
+	BeforeEach(func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+	})
+
+	newSourceCluster := func() *appsv1.Cluster {
+		return &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sourceClusterName,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1.ClusterSpec{
+				ComponentSpecs: []appsv1.ClusterComponentSpec{{
+					Name:         "mysql",
+					ComponentDef: "mysql",
+					Replicas:     1,
+				}},
+			},
+		}
+	}
+
+	newBackup := func(name string, completionTime time.Time) *dpv1alpha1.Backup {
+		backup := newRestoreOpsBackup(name, map[string]string{constant.AppInstanceLabelKey: sourceClusterName})
+		backup.Status.CompletionTimestamp = &metav1.Time{Time: completionTime}
+		return backup
+	}
+
+	newComponentParameter := func(clusterName, compName string, desired *parametersv1alpha1.ParameterInputs) *parametersv1alpha1.ComponentParameter {
+		return &parametersv1alpha1.ComponentParameter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      parameterscore.GenerateComponentConfigurationName(clusterName, compName),
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: parametersv1alpha1.ComponentParameterSpec{
+				ClusterName:   clusterName,
+				ComponentName: compName,
+				Desired:       desired,
+			},
+		}
+	}
+
+	newCloneFakeClient := func(objects ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(dpv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(parametersv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&opsv1alpha1.OpsRequest{}, &appsv1.Cluster{}).Build()
+	}
+
+	createCloneOpsObj := func(clone *opsv1alpha1.Clone) *opsv1alpha1.OpsRequest {
+		return &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cloneOpsName,
+				Namespace: testCtx.DefaultNamespace,
+				UID:       types.UID(cloneOpsName + "-uid"),
+			},
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName: sourceClusterName,
+				Type:        opsv1alpha1.CloneType,
+				SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+					Clone: clone,
+				},
+			},
+		}
+	}
+
+	stepStatus := func(opsRequest *opsv1alpha1.OpsRequest, name opsv1alpha1.CloneStepName) opsv1alpha1.ProgressStatus {
+		for _, step := range opsRequest.Status.Clone.Steps {
+			if step.Name == name {
+				return step.Status
+			}
+		}
+		return ""
+	}
+
+	It("initializes the clone steps", func() {
+		opsRequest := createCloneOpsObj(&opsv1alpha1.Clone{TargetClusterName: targetClusterName})
+		cli := newCloneFakeClient(opsRequest)
+
+		Expect(cloneHandler.Action(reqCtx, cli, &OpsResource{OpsRequest: opsRequest})).Should(Succeed())
+
+		Expect(opsRequest.Status.Clone).ShouldNot(BeNil())
+		Expect(opsRequest.Status.Clone.Steps).Should(HaveLen(len(cloneSteps)))
+		for _, step := range opsRequest.Status.Clone.Steps {
+			Expect(step.Status).Should(Equal(opsv1alpha1.PendingProgressStatus))
+		}
+	})
+
+	It("clones the Cluster from the latest completed backup", func() {
+		opsRequest := createCloneOpsObj(&opsv1alpha1.Clone{
+			TargetClusterName: targetClusterName,
+			Components: []opsv1alpha1.CloneComponent{{
+				ComponentName: "mysql",
+				Replicas:      ptr.To[int32](3),
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}},
+		})
+		sourceCluster := newSourceCluster()
+		value := "1000"
+		sourceParam := newComponentParameter(sourceClusterName, "mysql", &parametersv1alpha1.ParameterInputs{
+			Assignments: map[string]*string{"max_connections": &value},
+		})
+		cli := newCloneFakeClient(opsRequest, sourceCluster, sourceParam,
+			newBackup("backup-old-"+randomStr, time.Now().Add(-time.Hour)),
+			newBackup("backup-new-"+randomStr, time.Now()))
+		opsRes := &OpsResource{OpsRequest: opsRequest, Cluster: sourceCluster}
+		Expect(cloneHandler.Action(reqCtx, cli, opsRes)).Should(Succeed())
+
+		By("creating the target Cluster and waiting for its ComponentParameter")
+		phase, _, err := cloneHandler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(opsv1alpha1.OpsRunningPhase))
+		Expect(opsRequest.Status.Clone.BackupName).Should(Equal("backup-new-" + randomStr))
+		Expect(stepStatus(opsRequest, opsv1alpha1.CloneStepCreateCluster)).Should(Equal(opsv1alpha1.SucceedProgressStatus))
+		Expect(stepStatus(opsRequest, opsv1alpha1.CloneStepRenderParameters)).Should(Equal(opsv1alpha1.ProcessingProgressStatus))
+		Expect(opsRequest.Status.Progress).Should(Equal("2/5"))
+
+		target := &appsv1.Cluster{}
+		Expect(cli.Get(reqCtx.Ctx, client.ObjectKey{Name: targetClusterName, Namespace: opsRequest.Namespace}, target)).Should(Succeed())
+		Expect(target.Spec.Restore).ShouldNot(BeNil())
+		Expect(target.Spec.Restore.Source.Name).Should(Equal("backup-new-" + randomStr))
+		Expect(target.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(3))
+		Expect(target.Spec.ComponentSpecs[0].Resources.Limits.Cpu().String()).Should(Equal("2"))
+		Expect(target.Labels).Should(HaveKeyWithValue(constant.AppInstanceLabelKey, targetClusterName))
+		Expect(target.Labels).Should(HaveKeyWithValue(constant.OpsRequestTypeLabelKey, string(opsv1alpha1.CloneType)))
+
+		By("re-rendering the parameters and waiting for the target Cluster to be running")
+		Expect(cli.Create(reqCtx.Ctx, newComponentParameter(targetClusterName, "mysql", nil))).Should(Succeed())
+		target.Status.Phase = appsv1.RunningClusterPhase
+		target.Status.Conditions = []metav1.Condition{{
+			Type:               appsv1.ConditionTypeRestore,
+			Status:             metav1.ConditionTrue,
+			Reason:             "test",
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(cli.Status().Update(reqCtx.Ctx, target)).Should(Succeed())
+
+		phase, _, err = cloneHandler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(opsv1alpha1.OpsSucceedPhase))
+		Expect(opsRequest.Status.Progress).Should(Equal("5/5"))
+
+		targetParam := &parametersv1alpha1.ComponentParameter{}
+		Expect(cli.Get(reqCtx.Ctx, client.ObjectKey{
+			Name:      parameterscore.GenerateComponentConfigurationName(targetClusterName, "mysql"),
+			Namespace: opsRequest.Namespace,
+		}, targetParam)).Should(Succeed())
+		Expect(targetParam.Spec.Desired).Should(Equal(sourceParam.Spec.Desired))
+	})
+
+	It("fails when the source Cluster has no completed backup", func() {
+		opsRequest := createCloneOpsObj(&opsv1alpha1.Clone{TargetClusterName: targetClusterName})
+		sourceCluster := newSourceCluster()
+		cli := newCloneFakeClient(opsRequest, sourceCluster)
+		opsRes := &OpsResource{OpsRequest: opsRequest, Cluster: sourceCluster}
+		Expect(cloneHandler.Action(reqCtx, cli, opsRes)).Should(Succeed())
+
+		phase, _, err := cloneHandler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).Should(HaveOccurred())
+		Expect(phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(stepStatus(opsRequest, opsv1alpha1.CloneStepResolveBackup)).Should(Equal(opsv1alpha1.FailedProgressStatus))
+	})
+
+	It("fails when the target Cluster already exists and is not created by the clone", func() {
+		opsRequest := createCloneOpsObj(&opsv1alpha1.Clone{TargetClusterName: targetClusterName})
+		sourceCluster := newSourceCluster()
+		existing := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      targetClusterName,
+				Namespace: opsRequest.Namespace,
+			},
+		}
+		cli := newCloneFakeClient(opsRequest, sourceCluster, existing, newBackup("backup-"+randomStr, time.Now()))
+		opsRes := &OpsResource{OpsRequest: opsRequest, Cluster: sourceCluster}
+		Expect(cloneHandler.Action(reqCtx, cli, opsRes)).Should(Succeed())
+
+		phase, _, err := cloneHandler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).Should(HaveOccurred())
+		Expect(phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(stepStatus(opsRequest, opsv1alpha1.CloneStepCreateCluster)).Should(Equal(opsv1alpha1.FailedProgressStatus))
+	})
+})
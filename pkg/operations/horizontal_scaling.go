@@ -46,7 +46,11 @@ import (
 
 type horizontalScalingOpsHandler struct{}
 
+// horizontalScalingActions are the lifecycle actions involved in the horizontal scaling.
+var horizontalScalingActions = []string{"memberJoin", "memberLeave", "dataDump", "dataLoad"}
+
 var _ OpsHandler = horizontalScalingOpsHandler{}
+var _ actionAborter = horizontalScalingOpsHandler{}
 
 func init() {
 	hsHandler := horizontalScalingOpsHandler{}
@@ -423,6 +427,7 @@ func (hs horizontalScalingOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli
 			return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, "does not support cancellation of shard count changes during horizontal scaling.")
 		}
 	}
+	hs.abortActions(reqCtx, opsRes)
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList)
 	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
 		comp.Replicas = *lastConfig.Replicas
@@ -431,6 +436,20 @@ func (hs horizontalScalingOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli
 	})
 }
 
+// abortActions aborts the in-flight member and data actions of the scaling, the result of them is not expected anymore.
+// It is a best-effort attempt, the cancellation is not blocked by the failure.
+func (hs horizontalScalingOpsHandler) abortActions(reqCtx intctrlutil.RequestCtx, opsRes *OpsResource) {
+	for _, v := range opsRes.OpsRequest.Spec.HorizontalScalingList {
+		runtime, err := opsRes.GetRuntime(v.ComponentName)
+		if err == nil {
+			err = runtime.AbortActions(reqCtx.Ctx, opsRes.Cluster.Namespace, opsRes.Cluster.Name, v.ComponentName, horizontalScalingActions...)
+		}
+		if err != nil {
+			reqCtx.Log.Info("failed to abort the in-flight actions of horizontal scaling", "component", v.ComponentName, "error", err.Error())
+		}
+	}
+}
+
 // checkIntersectionWithEarlierOps checks if the pod deleted by the current ops is a pod created by another ops
 func (hs horizontalScalingOpsHandler) checkIntersectionWithEarlierOps(opsRes *OpsResource, earlierOps *opsv1alpha1.OpsRequest,
 	currOpsHScaling, earlierOpsHScaling opsv1alpha1.HorizontalScaling) error {
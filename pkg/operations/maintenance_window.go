@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// maintenanceWindowRequeueInterval is the max interval to check the maintenance window again,
+// so that the changes of the window can be observed by the waiting OpsRequests.
+const maintenanceWindowRequeueInterval = 5 * time.Minute
+
+var _ error = &WaitForMaintenanceWindowErr{}
+
+// WaitForMaintenanceWindowErr indicates that the OpsRequest waits for the maintenance window of the cluster to open.
+type WaitForMaintenanceWindowErr struct {
+	clusterName string
+	opensAt     time.Time
+}
+
+func (e *WaitForMaintenanceWindowErr) Error() string {
+	return fmt.Sprintf("wait for the maintenance window of cluster %s to open at %s", e.clusterName, e.opensAt.UTC().Format(time.RFC3339))
+}
+
+func (e *WaitForMaintenanceWindowErr) requeueAfter() time.Duration {
+	return min(max(time.Until(e.opensAt), time.Second), maintenanceWindowRequeueInterval)
+}
+
+// validateMaintenanceWindow checks whether the OpsRequest that recreates pods is allowed to start by the maintenance window
+// of the cluster. The OpsRequest waits in the Pending phase until the window opens, unless it is forced.
+// Once started, the OpsRequest bypasses the window for the spec changes it makes, so that it can run to completion.
+func validateMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) error {
+	if !opsBehaviour.RecreatePods {
+		return nil
+	}
+	window, err := intctrlutil.GetMaintenanceWindow(reqCtx.Ctx, cli, opsRes.Cluster)
+	if errors.Is(err, intctrlutil.ErrInvalidNamespaceMaintenanceWindow) {
+		// the invalid window annotated on the namespace is treated as no window.
+		if opsRes.Recorder != nil {
+			opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeWarning, constant.ReasonInvalidMaintenanceWindow, err.Error())
+		}
+		return nil
+	}
+	if err != nil || window == nil {
+		return err
+	}
+	opsRequest := opsRes.OpsRequest
+	if !opsRequest.Force() {
+		open, opensAt, err := intctrlutil.CheckMaintenanceWindow(window, time.Now())
+		if err != nil {
+			return intctrlutil.NewFatalError(err.Error())
+		}
+		if !open {
+			if err = patchMaintenanceWindowCondition(reqCtx.Ctx, cli, opsRes,
+				opsv1alpha1.NewWaitForMaintenanceWindowCondition(opsRequest, opensAt)); err != nil {
+				return err
+			}
+			return &WaitForMaintenanceWindowErr{clusterName: opsRes.Cluster.Name, opensAt: opensAt}
+		}
+	}
+	if err = bypassMaintenanceWindowForOps(reqCtx.Ctx, cli, opsRes); err != nil {
+		return err
+	}
+	return patchMaintenanceWindowCondition(reqCtx.Ctx, cli, opsRes, opsv1alpha1.NewMaintenanceWindowPassedCondition(opsRequest))
+}
+
+// bypassMaintenanceWindowForOps annotates the cluster to bypass the maintenance window for the OpsRequest,
+// the annotation is removed when the OpsRequest is dequeued.
+func bypassMaintenanceWindowForOps(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
+	cluster := opsRes.Cluster
+	if len(cluster.Annotations[constant.BypassMaintenanceWindowAnnotationKey]) > 0 {
+		return nil
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.BypassMaintenanceWindowAnnotationKey] = opsRes.OpsRequest.Name
+	return cli.Patch(ctx, cluster, patch)
+}
+
+func patchMaintenanceWindowCondition(ctx context.Context, cli client.Client, opsRes *OpsResource, condition *metav1.Condition) error {
+	opsRequest := opsRes.OpsRequest
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if !meta.SetStatusCondition(&opsRequest.Status.Conditions, *condition) {
+		return nil
+	}
+	if opsRes.Recorder != nil {
+		opsRes.Recorder.Event(opsRequest, corev1.EventTypeNormal, condition.Reason, condition.Message)
+	}
+	return cli.Status().Patch(ctx, opsRequest, patch)
+}
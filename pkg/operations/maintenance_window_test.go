@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2022-2026 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("OpsRequest maintenance window", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "mw-cluster-" + randomStr
+		opsName     = "mw-ops-" + randomStr
+		reqCtx      intctrlutil.RequestCtx
+		behaviour   = OpsBehaviour{RecreatePods: true}
+	)
+
+	BeforeEach(func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+	})
+
+	newFakeClient := func(objects ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&opsv1alpha1.OpsRequest{}, &appsv1.Cluster{}).Build()
+	}
+
+	// closedWindow returns a daily window which opens two hours later.
+	closedWindow := func() *appsv1.MaintenanceWindow {
+		opensAt := time.Now().UTC().Add(2 * time.Hour)
+		return &appsv1.MaintenanceWindow{
+			Schedule: fmt.Sprintf("%d %d * * *", opensAt.Minute(), opensAt.Hour()),
+			Duration: metav1.Duration{Duration: time.Hour},
+		}
+	}
+
+	openWindow := func() *appsv1.MaintenanceWindow {
+		return &appsv1.MaintenanceWindow{
+			Schedule: "* * * * *",
+			Duration: metav1.Duration{Duration: 2 * time.Minute},
+		}
+	}
+
+	newOpsResource := func(window *appsv1.MaintenanceWindow, force bool) (*OpsResource, client.Client) {
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1.ClusterSpec{
+				MaintenanceWindow: window,
+			},
+		}
+		opsRequest := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      opsName,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName: clusterName,
+				Type:        opsv1alpha1.RestartType,
+				Force:       force,
+			},
+		}
+		cli := newFakeClient(cluster, opsRequest)
+		return &OpsResource{Cluster: cluster, OpsRequest: opsRequest}, cli
+	}
+
+	It("waits for the maintenance window to open", func() {
+		opsRes, cli := newOpsResource(closedWindow(), false)
+		err := validateMaintenanceWindow(reqCtx, cli, opsRes, behaviour)
+		waitErr, ok := err.(*WaitForMaintenanceWindowErr)
+		Expect(ok).Should(BeTrue())
+		Expect(waitErr.requeueAfter()).Should(Equal(maintenanceWindowRequeueInterval))
+
+		opsRequest := &opsv1alpha1.OpsRequest{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), opsRequest)).Should(Succeed())
+		cond := meta.FindStatusCondition(opsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(opsv1alpha1.ReasonMaintenanceWindowClosed))
+
+		cluster := &appsv1.Cluster{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
+		Expect(cluster.Annotations).ShouldNot(HaveKey(constant.BypassMaintenanceWindowAnnotationKey))
+	})
+
+	It("starts the OpsRequest in the maintenance window", func() {
+		opsRes, cli := newOpsResource(openWindow(), false)
+		Expect(validateMaintenanceWindow(reqCtx, cli, opsRes, behaviour)).Should(Succeed())
+
+		opsRequest := &opsv1alpha1.OpsRequest{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), opsRequest)).Should(Succeed())
+		cond := meta.FindStatusCondition(opsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(opsv1alpha1.ReasonMaintenanceWindowOpened))
+
+		By("expect the cluster to bypass the window for the OpsRequest")
+		cluster := &appsv1.Cluster{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
+		Expect(cluster.Annotations[constant.BypassMaintenanceWindowAnnotationKey]).Should(Equal(opsName))
+	})
+
+	It("bypasses the maintenance window by force", func() {
+		opsRes, cli := newOpsResource(closedWindow(), true)
+		Expect(validateMaintenanceWindow(reqCtx, cli, opsRes, behaviour)).Should(Succeed())
+
+		opsRequest := &opsv1alpha1.OpsRequest{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), opsRequest)).Should(Succeed())
+		cond := meta.FindStatusCondition(opsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Reason).Should(Equal(opsv1alpha1.ReasonMaintenanceWindowBypassed))
+	})
+
+	It("ignores the maintenance window for the OpsRequest that does not recreate pods", func() {
+		opsRes, cli := newOpsResource(closedWindow(), false)
+		Expect(validateMaintenanceWindow(reqCtx, cli, opsRes, OpsBehaviour{})).Should(Succeed())
+		Expect(opsRes.OpsRequest.Status.Conditions).Should(BeEmpty())
+	})
+
+	It("fails the OpsRequest with an invalid maintenance window", func() {
+		opsRes, cli := newOpsResource(&appsv1.MaintenanceWindow{Schedule: "invalid", Duration: metav1.Duration{Duration: time.Hour}}, false)
+		err := validateMaintenanceWindow(reqCtx, cli, opsRes, behaviour)
+		Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+	})
+})
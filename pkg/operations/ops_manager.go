@@ -84,6 +84,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			if _, ok := err.(*WaitForClusterPhaseErr); ok {
 				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, "wait cluster to a right phase"))
 			}
+			if waitErr, ok := err.(*WaitForMaintenanceWindowErr); ok {
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(waitErr.requeueAfter(), reqCtx.Log, waitErr.Error()))
+			}
 			return nil, err
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -125,6 +128,10 @@ func (opsMgr *OpsManager) doPreConditionAndTransPhaseToCreating(reqCtx intctrlut
 	if err != nil || !pass {
 		return err
 	}
+	// validate if the maintenance window of the cluster allows the disruptive operation to start
+	if err = validateMaintenanceWindow(reqCtx, cli, opsRes, opsBehaviour); err != nil {
+		return err
+	}
 	if preConditionDeadlineSecondsIsSet(opsRes.OpsRequest) &&
 		opsRes.OpsRequest.Annotations[constant.QueueEndTimeAnnotationKey] == "" {
 		// set the queue end time for preConditionDeadline validation
@@ -271,6 +278,9 @@ func (opsMgr *OpsManager) checkAndHandleOpsTimeout(reqCtx intctrlutil.RequestCtx
 	}
 	timeoutPoint := opsRes.OpsRequest.Status.StartTimestamp.Add(time.Duration(*timeoutSeconds) * time.Second)
 	if !time.Now().Before(timeoutPoint) {
+		if aborter, ok := opsMgr.OpsMap[opsRes.OpsRequest.Spec.Type].OpsHandler.(actionAborter); ok {
+			aborter.abortActions(reqCtx, opsRes)
+		}
 		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsAbortedPhase,
 			opsv1alpha1.NewAbortedCondition("Aborted due to exceeding the specified timeout period (timeoutSeconds)"))
 	}
@@ -21,8 +21,12 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubectl/pkg/util/podutils"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -201,7 +206,64 @@ func (r *opsRuntime) Switchover(ctx context.Context, namespace, clusterName, com
 		InstanceName:  instanceName,
 		CandidateName: candidateName,
 	}
-	return r.doSwitchover(ctx, r.cli, synthesizedComp, switchover)
+	return r.doSwitchover(ctx, r.cli, synthesizedComp, switchover, nil)
+}
+
+// DryRunSwitchover calls the switchover action in dry-run mode and returns what the action reports it would do.
+func (r *opsRuntime) DryRunSwitchover(ctx context.Context, namespace, clusterName, compName, instanceName, candidateName string) ([]byte, error) {
+	synthesizedComp, err := r.buildSynthesizedCompByCompName(ctx, r.cli, namespace, clusterName, compName)
+	if err != nil {
+		return nil, err
+	}
+	switchover := &opsv1alpha1.Switchover{
+		ComponentName: compName,
+		InstanceName:  instanceName,
+		CandidateName: candidateName,
+		DryRun:        true,
+	}
+	opts := &lifecycle.Options{DryRun: true}
+	err = r.doSwitchover(ctx, r.cli, synthesizedComp, switchover, opts)
+	return opts.DryRunOutput, err
+}
+
+// dryRunConfigParametersVar passes the parameters requested to the reconfigure action called in dry-run mode,
+// as a JSON object of the parameter names and values.
+const dryRunConfigParametersVar = "KB_CONFIG_PARAMETERS"
+
+// DryRunReconfigure calls the reconfigure action in dry-run mode on a replica of the component,
+// it returns the replica called and what the action reports it would do.
+func (r *opsRuntime) DryRunReconfigure(ctx context.Context, namespace, clusterName, compName string, parameters map[string]string) (string, []byte, error) {
+	data, err := json.Marshal(parameters)
+	if err != nil {
+		return "", nil, err
+	}
+	synthesizedComp, err := r.buildSynthesizedCompByCompName(ctx, r.cli, namespace, clusterName, compName)
+	if err != nil {
+		return "", nil, err
+	}
+	if synthesizedComp.LifecycleActions.ComponentLifecycleActions == nil || synthesizedComp.LifecycleActions.Reconfigure == nil {
+		return "", nil, intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" does not define reconfigure lifecycle action`, compName))
+	}
+	pods, err := component.ListOwnedPods(r.dataContext(), r.cli, namespace, clusterName, compName, r.dataListOpts...)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pods) == 0 {
+		return "", nil, intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" has no replica`, compName))
+	}
+	slices.SortFunc(pods, func(a, b *corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	pod := pods[0]
+
+	lfa, err := lifecycle.New(namespace, clusterName, compName,
+		synthesizedComp.LifecycleActions.ComponentLifecycleActions, synthesizedComp.TemplateVars, pod, pods)
+	if err != nil {
+		return "", nil, err
+	}
+	opts := &lifecycle.Options{DryRun: true}
+	err = lfa.Reconfigure(ctx, r.cli, opts, map[string]string{dryRunConfigParametersVar: string(data)})
+	return pod.Name, opts.DryRunOutput, err
 }
 
 func (r *opsRuntime) buildSynthesizedCompByCompName(ctx context.Context, cli client.Client, namespace, clusterName, compName string) (*component.SynthesizedComponent, error) {
@@ -215,7 +277,7 @@ func (r *opsRuntime) buildSynthesizedCompByCompName(ctx context.Context, cli cli
 // We consider a switchover action succeeds if the action returns without error.
 // We don't need to know if a switchover is actually executed.
 func (r *opsRuntime) doSwitchover(ctx context.Context, cli client.Reader, synthesizedComp *component.SynthesizedComponent,
-	switchover *opsv1alpha1.Switchover) error {
+	switchover *opsv1alpha1.Switchover, opts *lifecycle.Options) error {
 	pods, err := component.ListOwnedPods(r.dataContext(), cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, r.dataListOpts...)
 	if err != nil {
 		return err
@@ -253,7 +315,38 @@ func (r *opsRuntime) doSwitchover(ctx context.Context, cli client.Reader, synthe
 	// NOTE: switchover is a blocking action currently. May change to non-blocking for better performance.
 	// Lifecycle preconditions still use the lifecycle reader contract as-is. If a multi-cluster
 	// action needs data-plane runtime readiness checks, model that explicitly in the lifecycle API.
-	return lfa.Switchover(ctx, cli, nil, switchover.CandidateName)
+	return lfa.Switchover(ctx, cli, opts, switchover.CandidateName)
+}
+
+// abortActionOptions bounds the time and retries of aborting the in-flight actions.
+var abortActionOptions = &lifecycle.Options{
+	TimeoutSeconds: ptr.To[int32](30),
+	RetryPolicy: &appsv1.RetryPolicy{
+		MaxRetries:    2,
+		RetryInterval: time.Second,
+	},
+}
+
+// AbortActions aborts the in-flight non-blocking lifecycle actions on all instances of the component.
+func (r *opsRuntime) AbortActions(ctx context.Context, namespace, clusterName, compName string, actions ...string) error {
+	pods, err := component.ListOwnedPods(r.dataContext(), r.cli, namespace, clusterName, compName, r.dataListOpts...)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return nil
+	}
+	lfa, err := lifecycle.New(namespace, clusterName, compName, nil, nil, nil, pods)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, action := range actions {
+		if err = lfa.Abort(ctx, r.cli, abortActionOptions, action); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (r *opsRuntime) buildInstances(namespace, clusterName, compName string, pods []*corev1.Pod) ([]Instance, error) {
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -87,6 +88,10 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 		// delete the opsRequest in Cluster.annotations
 		opsRequestSlice = slices.Delete(opsRequestSlice, index, index+1)
 	}
+	// stop bypassing the maintenance window for the OpsRequest
+	if opsRes.Cluster.Annotations[constant.BypassMaintenanceWindowAnnotationKey] == opsRes.OpsRequest.Name {
+		delete(opsRes.Cluster.Annotations, constant.BypassMaintenanceWindowAnnotationKey)
+	}
 	return opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
 }
 
@@ -188,3 +193,18 @@ func existOtherRunningOps(opsRecorderSlice []opsv1alpha1.OpsRecorder, opsType op
 	}
 	return false
 }
+
+// GetRunningOpsRequestsInCluster gets the OpsRequests running in the cluster, the ones waiting in the queue are excluded.
+func GetRunningOpsRequestsInCluster(cluster *appsv1.Cluster) ([]opsv1alpha1.OpsRecorder, error) {
+	opsRequestSlice, err := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var runningOpsRequests []opsv1alpha1.OpsRecorder
+	for i := range opsRequestSlice {
+		if !opsRequestSlice[i].InQueue {
+			runningOpsRequests = append(runningOpsRequests, opsRequestSlice[i])
+		}
+	}
+	return runningOpsRequests, nil
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -66,6 +67,16 @@ func (r *reconfigureAction) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 
 func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
 	opsDeepCopy := resource.OpsRequest.DeepCopy()
+	failed, err := r.dryRun(reqCtx, cli, resource)
+	if err != nil {
+		return "", noRequeueAfter, err
+	}
+	if len(failed) > 0 {
+		if _, _, err = r.syncReconfigureForOps(reqCtx, cli, resource, opsDeepCopy, opsv1alpha1.OpsFailedPhase); err != nil {
+			return "", noRequeueAfter, err
+		}
+		return opsv1alpha1.OpsFailedPhase, 0, intctrlutil.NewFatalError(fmt.Sprintf("dry-run reconfigure failed: %s", failed))
+	}
 	phase, msg, err := r.aggregatePhase(reqCtx, cli, resource)
 	if err != nil {
 		return "", noRequeueAfter, err
@@ -87,6 +98,9 @@ func (r *reconfigureAction) Action(reqCtx intctrlutil.RequestCtx, cli client.Cli
 		if len(reconfigure.Parameters) == 0 {
 			return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "invalid reconfigure request for component %s: no parameters", reconfigure.ComponentName)
 		}
+		if reconfigure.DryRun {
+			continue // the configuration is not changed, the action is called in dry-run mode by the reconciliation
+		}
 		compNames, err := r.resolveReconfigureComponents(reqCtx.Ctx, cli, resource.Cluster, reconfigure.ComponentName)
 		if err != nil {
 			return err
@@ -107,8 +121,49 @@ func (r *reconfigureAction) syncReconfigureForOps(reqCtx intctrlutil.RequestCtx,
 	return phase, noRequeueAfter, nil
 }
 
+// dryRun calls the reconfigure action in dry-run mode for the components requested, once for each component,
+// and the results are recorded in the component status of the OpsRequest. It returns the error message of the failed one.
+func (r *reconfigureAction) dryRun(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (string, error) {
+	opsRequest := resource.OpsRequest
+	for _, reconfigure := range opsRequest.Spec.Reconfigures {
+		if !reconfigure.DryRun {
+			continue
+		}
+		compNames, err := r.resolveReconfigureComponents(reqCtx.Ctx, cli, resource.Cluster, reconfigure.ComponentName)
+		if err != nil {
+			return "", err
+		}
+		parameters := make(map[string]string)
+		for _, param := range reconfigure.Parameters {
+			parameters[param.Key] = ptr.Deref(param.Value, "")
+		}
+		for _, compName := range compNames {
+			if opsRequest.Status.Components == nil {
+				opsRequest.Status.Components = make(map[string]opsv1alpha1.OpsRequestComponentStatus)
+			}
+			compStatus := opsRequest.Status.Components[compName]
+			if compStatus.DryRunResult == nil {
+				runtime, err := resource.GetRuntime(compName)
+				if err != nil {
+					return "", err
+				}
+				podName, output, err := runtime.DryRunReconfigure(reqCtx.Ctx, resource.Cluster.Namespace, resource.Cluster.Name, compName, parameters)
+				compStatus.DryRunResult = newActionDryRunResult("reconfigure", podName, output, err)
+				opsRequest.Status.Components[compName] = compStatus
+			}
+			if !compStatus.DryRunResult.Succeeded {
+				return fmt.Sprintf("component %s: %s", compName, compStatus.DryRunResult.Output), nil
+			}
+		}
+	}
+	return "", nil
+}
+
 func (r *reconfigureAction) aggregatePhase(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (opsv1alpha1.OpsPhase, string, error) {
 	for _, reconfigure := range resource.OpsRequest.Spec.Reconfigures {
+		if reconfigure.DryRun {
+			continue
+		}
 		compNames, err := r.resolveReconfigureComponents(reqCtx.Ctx, cli, resource.Cluster, reconfigure.ComponentName)
 		if err != nil {
 			return "", "", err
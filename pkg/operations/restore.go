@@ -111,7 +111,7 @@ func markRestoreClusterWithOps(cluster *appsv1.Cluster, opsRequest *opsv1alpha1.
 	if cluster.Annotations == nil {
 		cluster.Annotations = map[string]string{}
 	}
-	cluster.Labels[constant.AppInstanceLabelKey] = opsRequest.Spec.GetClusterName()
+	cluster.Labels[constant.AppInstanceLabelKey] = cluster.Name
 	cluster.Labels[constant.OpsRequestNameLabelKey] = opsRequest.Name
 	cluster.Labels[constant.OpsRequestNamespaceLabelKey] = opsRequest.Namespace
 	cluster.Labels[constant.OpsRequestTypeLabelKey] = string(opsRequest.Spec.Type)
@@ -236,6 +236,12 @@ func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCt
 }
 
 func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, opsRequest *opsv1alpha1.OpsRequest) (*appsv1.Cluster, error) {
+	return r.buildClusterFromBackup(backup, opsRequest.Spec.GetClusterName(), opsRequest.Namespace, opsRequest.Spec.GetRestore())
+}
+
+// buildClusterFromBackup builds the Cluster object named clusterName in the namespace from the cluster snapshot of the backup,
+// with spec.restore pointing to the backup.
+func (r RestoreOpsHandler) buildClusterFromBackup(backup *dpv1alpha1.Backup, clusterName, namespace string, restoreSpec *opsv1alpha1.Restore) (*appsv1.Cluster, error) {
 	cluster := &appsv1.Cluster{}
 	clusterString, ok := backup.Annotations[constant.ClusterSnapshotAnnotationKey]
 	if !ok {
@@ -244,9 +250,8 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 	if err := json.Unmarshal([]byte(clusterString), &cluster); err != nil {
 		return nil, err
 	}
-	restoreSpec := opsRequest.Spec.GetRestore()
-	cluster.Name = opsRequest.Spec.GetClusterName()
-	cluster.Namespace = opsRequest.Namespace
+	cluster.Name = clusterName
+	cluster.Namespace = namespace
 	cluster.Spec.Restore = &appsv1.ClusterRestore{
 		Source: appsv1.ClusterRestoreSource{
 			APIGroup:  dptypes.DataprotectionAPIGroup,
@@ -257,7 +262,7 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 		Parameters: restoreSpecToParametersMap(restoreSpec),
 	}
 	if cluster.Spec.Restore.Source.Namespace == "" {
-		cluster.Spec.Restore.Source.Namespace = opsRequest.Namespace
+		cluster.Spec.Restore.Source.Namespace = namespace
 	}
 	if restoreSpec.RestorePointInTime != "" {
 		cluster.Spec.Restore.PITR = restoreSpec.RestorePointInTime
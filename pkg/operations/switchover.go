@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,9 @@ import (
 // switchover constants
 const (
 	KBSwitchoverKey = "Switchover"
+
+	// maxDryRunOutputLength is the max length of the dry-run output kept in the OpsRequest status.
+	maxDryRunOutputLength = 32768
 )
 
 type switchoverOpsHandler struct{}
@@ -118,6 +122,9 @@ func switchoverPreCheck(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes
 		if synthesizedComp.LifecycleActions.ComponentLifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil {
 			return intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" does not define switchover lifecycle action`, compName))
 		}
+		if switchover.DryRun && !synthesizedComp.LifecycleActions.Switchover.SupportDryRun {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the switchover lifecycle action of component "%s" does not support dry-run`, compName))
+		}
 		if len(synthesizedComp.Roles) == 0 {
 			return intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" does not have any role`, compName))
 		}
@@ -229,6 +236,10 @@ func handleSwitchover(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *
 
 	switch progressDetail.Status {
 	case opsv1alpha1.PendingProgressStatus:
+		if switchover.DryRun {
+			dryRunSwitchover(reqCtx, runtime, synthesizedComp, switchover, opsRequest, progressDetail, compName)
+			break
+		}
 		if err = runtime.Switchover(reqCtx.Ctx, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, switchover.InstanceName, switchover.CandidateName); err != nil {
 			progressDetail.Status = opsv1alpha1.FailedProgressStatus
 			progressDetail.Message = fmt.Sprintf("component %s %s", compName, err.Error())
@@ -263,6 +274,49 @@ func handleSwitchover(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *
 	return nil
 }
 
+// dryRunSwitchover calls the switchover action in dry-run mode, the switchover is completed once the action returns,
+// and the result is recorded in the component status of the OpsRequest.
+func dryRunSwitchover(reqCtx intctrlutil.RequestCtx, runtime OpsRuntime, synthesizedComp *component.SynthesizedComponent,
+	switchover *opsv1alpha1.Switchover, opsRequest *opsv1alpha1.OpsRequest, progressDetail *opsv1alpha1.ProgressStatusDetail, compName string) {
+	output, err := runtime.DryRunSwitchover(reqCtx.Ctx, synthesizedComp.Namespace, synthesizedComp.ClusterName,
+		synthesizedComp.Name, switchover.InstanceName, switchover.CandidateName)
+	result := newActionDryRunResult("switchover", switchover.InstanceName, output, err)
+	if err != nil {
+		progressDetail.Status = opsv1alpha1.FailedProgressStatus
+		progressDetail.Message = fmt.Sprintf("component %s dry-run switchover failed: %s", compName, err.Error())
+	} else {
+		progressDetail.Status = opsv1alpha1.SucceedProgressStatus
+		progressDetail.Message = "dry-run switchover succeed"
+	}
+	progressDetail.StartTime = metav1.Now()
+	compStatus := opsRequest.Status.Components[compName]
+	compStatus.DryRunResult = result
+	opsRequest.Status.Components[compName] = compStatus
+}
+
+// newActionDryRunResult builds the result of the action called in dry-run mode, the output kept is truncated
+// on a rune boundary if it is too long.
+func newActionDryRunResult(action, podName string, output []byte, err error) *opsv1alpha1.ActionDryRunResult {
+	result := &opsv1alpha1.ActionDryRunResult{
+		Action:    action,
+		PodName:   podName,
+		Succeeded: err == nil,
+		Output:    string(output),
+		Time:      metav1.Now(),
+	}
+	if err != nil {
+		result.Output = err.Error()
+	}
+	if len(result.Output) > maxDryRunOutputLength {
+		i := maxDryRunOutputLength
+		for i > 0 && !utf8.RuneStart(result.Output[i]) {
+			i--
+		}
+		result.Output = result.Output[:i]
+	}
+	return result
+}
+
 func getSwitchoverPodBackedInstance(runtime OpsRuntime, namespace, clusterName, compName, instanceName string) (Instance, error) {
 	instance, err := runtime.GetInstance(namespace, clusterName, compName, instanceName)
 	if err != nil {
@@ -285,6 +339,7 @@ func setComponentSwitchoverProgressDetails(recorder record.EventRecorder,
 	opsRequest.Status.Components[componentName] = opsv1alpha1.OpsRequestComponentStatus{
 		Phase:           phase,
 		ProgressDetails: componentProcessDetails,
+		DryRunResult:    opsRequest.Status.Components[componentName].DryRunResult,
 	}
 }
 
@@ -21,7 +21,11 @@ package operations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -29,6 +33,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -195,6 +200,110 @@ var _ = Describe("", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
+		It("Test dry-run switchover OpsRequest", func() {
+			By("declare the dry-run support of the switchover action")
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(compDefObj), func(compDef *appsv1.ComponentDefinition) {
+				compDef.Spec.LifecycleActions.Switchover.SupportDryRun = true
+			})()).Should(Succeed())
+
+			By("create dry-run switchover opsRequest")
+			ops := testops.NewOpsRequestObj("ops-switchover-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, opsv1alpha1.SwitchoverType)
+			instanceName := fmt.Sprintf("%s-%s-%d", clusterObj.Name, defaultCompName, 1)
+			ops.Spec.SwitchoverList = []opsv1alpha1.Switchover{
+				{
+					ComponentName: defaultCompName,
+					InstanceName:  instanceName,
+					DryRun:        true,
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+
+			By("mock switchover OpsRequest phase is Creating")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsCreatingPhase))
+
+			By("do switchover action")
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, opsv1alpha1.ConditionTypeFailed)).Should(BeNil())
+
+			testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+					Expect(req.DryRun).ShouldNot(BeNil())
+					Expect(*req.DryRun).Should(BeTrue())
+					return kbagentproto.ActionResponse{Output: []byte("would transfer the leader")}, nil
+				})
+			})
+
+			By("do reconcile switchover action")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			result := opsRes.OpsRequest.Status.Components[defaultCompName].DryRunResult
+			Expect(result).ShouldNot(BeNil())
+			Expect(result.Succeeded).Should(BeTrue())
+			Expect(result.PodName).Should(Equal(instanceName))
+			Expect(result.Output).Should(Equal("would transfer the leader"))
+		})
+
+		It("Test dry-run reconfigure OpsRequest", func() {
+			By("declare the reconfigure action with the dry-run support")
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(compDefObj), func(compDef *appsv1.ComponentDefinition) {
+				compDef.Spec.LifecycleActions.Reconfigure = testapps.NewLifecycleAction("reconfigure")
+				compDef.Spec.LifecycleActions.Reconfigure.SupportDryRun = true
+			})()).Should(Succeed())
+
+			By("create dry-run reconfigure opsRequest")
+			ops := testops.NewOpsRequestObj("ops-reconfigure-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, opsv1alpha1.ReconfiguringType)
+			ops.Spec.Reconfigures = []opsv1alpha1.Reconfigure{
+				{
+					ComponentOps: opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					Parameters: []opsv1alpha1.ParameterPair{
+						{
+							Key:   "max_connections",
+							Value: ptr.To("200"),
+						},
+					},
+					DryRun: true,
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+
+			By("mock reconfigure OpsRequest phase is Creating")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsCreatingPhase))
+
+			By("do reconfigure action, the configuration is not changed")
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("reconfigure"))
+					Expect(req.DryRun).ShouldNot(BeNil())
+					Expect(*req.DryRun).Should(BeTrue())
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_CONFIG_PARAMETERS", `{"max_connections":"200"}`))
+					return kbagentproto.ActionResponse{Output: []byte("would set max_connections to 200")}, nil
+				})
+			})
+
+			By("do reconcile reconfigure action")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			result := opsRes.OpsRequest.Status.Components[defaultCompName].DryRunResult
+			Expect(result).ShouldNot(BeNil())
+			Expect(result.Action).Should(Equal("reconfigure"))
+			Expect(result.Succeeded).Should(BeTrue())
+			Expect(result.PodName).Should(Equal(fmt.Sprintf("%s-%s-%d", clusterObj.Name, defaultCompName, 0)))
+			Expect(result.Output).Should(Equal("would set max_connections to 200"))
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsSucceedPhase))
+		})
+
 		testSwitchoverWithCandidate := func(useComponentObjectName bool) {
 			By("create switchover opsRequest")
 			ops := testops.NewOpsRequestObj("ops-switchover-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
@@ -742,3 +851,22 @@ var _ = Describe("", func() {
 		})
 	})
 })
+
+func TestNewActionDryRunResult(t *testing.T) {
+	result := newActionDryRunResult("switchover", "pod-0", []byte("ok"), nil)
+	if !result.Succeeded || result.Output != "ok" || result.PodName != "pod-0" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	result = newActionDryRunResult("switchover", "pod-0", []byte("ignored"), errors.New("precondition failed"))
+	if result.Succeeded || result.Output != "precondition failed" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	// the output is truncated on a rune boundary
+	output := strings.Repeat("a", maxDryRunOutputLength-1) + "中文"
+	result = newActionDryRunResult("reconfigure", "pod-0", []byte(output), nil)
+	if len(result.Output) != maxDryRunOutputLength-1 || !utf8.ValidString(result.Output) {
+		t.Fatalf("unexpected truncated output, length: %d, valid: %v", len(result.Output), utf8.ValidString(result.Output))
+	}
+}
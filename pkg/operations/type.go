@@ -54,6 +54,12 @@ type OpsHandler interface {
 	SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 }
 
+// actionAborter is implemented by the ops handlers that run long-running lifecycle actions,
+// to abort the in-flight actions when the opsRequest is aborted for timeout.
+type actionAborter interface {
+	abortActions(reqCtx intctrlutil.RequestCtx, opsRes *OpsResource)
+}
+
 type OpsBehaviour struct {
 	FromClusterPhases []appsv1.ClusterPhase
 
@@ -74,6 +80,10 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// RecreatePods indicates that the operation recreates pods of the cluster, which is disruptive.
+	// The operation waits for the maintenance window of the cluster to open before starting.
+	RecreatePods bool
+
 	OpsHandler OpsHandler
 }
 
@@ -130,6 +140,9 @@ type OpsRuntime interface {
 	GenerateInstanceNameSet(clusterName, compName string, compReplicas int32, instances []appsv1.InstanceTemplate, offlineInstances []string) (map[string]string, error)
 	GenerateTemplateInstanceNames(clusterName, compName, templateName string, replicas int32, offlineInstances []string, ordinals appsv1.Ordinals) ([]string, error)
 	Switchover(ctx context.Context, namespace, clusterName, compName, instanceName, candidateName string) error
+	DryRunSwitchover(ctx context.Context, namespace, clusterName, compName, instanceName, candidateName string) ([]byte, error)
+	DryRunReconfigure(ctx context.Context, namespace, clusterName, compName string, parameters map[string]string) (string, []byte, error)
+	AbortActions(ctx context.Context, namespace, clusterName, compName string, actions ...string) error
 }
 
 type Workload interface {
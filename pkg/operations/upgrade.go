@@ -44,6 +44,7 @@ func init() {
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
+		RecreatePods:      true,
 		OpsHandler:        upgradeOpsHandler{},
 	}
 
@@ -44,6 +44,7 @@ func init() {
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
+		RecreatePods:      true,
 		CancelFunc:        vsHandler.Cancel,
 	}
 